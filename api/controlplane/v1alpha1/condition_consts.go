@@ -0,0 +1,122 @@
+// Copyright 2025 anza-labs contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+// Conditions and condition reasons for the KinkControlPlane object.
+
+const (
+	// RemediationAllowedCondition reports whether unhealthy components can still be remediated
+	// within the retry budget defined in the remediation policy.
+	RemediationAllowedCondition = "RemediationAllowed"
+
+	// RemediationBudgetAvailableReason is used when all components are within their retry budget.
+	RemediationBudgetAvailableReason = "BudgetAvailable"
+
+	// RemediationBudgetExhaustedReason is used when at least one component exhausted its retry budget.
+	RemediationBudgetExhaustedReason = "BudgetExhausted"
+)
+
+const (
+	// HibernatedCondition reports whether the control plane components are scaled to zero.
+	HibernatedCondition = "Hibernated"
+
+	// HibernationEnabledReason is used when the control plane is hibernated on demand.
+	HibernationEnabledReason = "Enabled"
+
+	// HibernationScheduledReason is used when the control plane is hibernated by a schedule.
+	HibernationScheduledReason = "Scheduled"
+
+	// HibernationInvalidScheduleReason is used when a hibernation schedule cannot be parsed.
+	HibernationInvalidScheduleReason = "InvalidSchedule"
+
+	// AwakeReason is used when the control plane is not hibernated.
+	AwakeReason = "Awake"
+)
+
+const (
+	// CertificateAuthoritiesRotatedCondition reports the progress of the rotation of the certificate
+	// authorities. The condition is false while a rotation is in progress and true once it completed,
+	// the reason is the current CertificateRotationPhase.
+	CertificateAuthoritiesRotatedCondition = "CertificateAuthoritiesRotated"
+)
+
+const (
+	// CertificatesExpiringSoonCondition reports whether a certificate of the control plane expires
+	// within the expiration warning window, e.g. because its renewal fails.
+	CertificatesExpiringSoonCondition = "CertificatesExpiringSoon"
+
+	// CertificatesExpiringReason is used when at least one certificate expires within the window.
+	CertificatesExpiringReason = "Expiring"
+
+	// CertificatesValidReason is used when no certificate expires within the window.
+	CertificatesValidReason = "Valid"
+)
+
+const (
+	// ServiceAccountIssuerDiscoveryCondition reports whether the service account issuer discovery
+	// endpoints are exposed to anonymous requests.
+	ServiceAccountIssuerDiscoveryCondition = "ServiceAccountIssuerDiscovery"
+
+	// IssuerDiscoveryExposedReason is used when anonymous requests are allowed.
+	IssuerDiscoveryExposedReason = "Exposed"
+
+	// IssuerDiscoveryFailedReason is used when the access of anonymous requests cannot be configured,
+	// e.g. because the API server is not reachable yet.
+	IssuerDiscoveryFailedReason = "Failed"
+
+	// IssuerDiscoveryNotExposedReason is used when the access of anonymous requests was revoked.
+	IssuerDiscoveryNotExposedReason = "NotExposed"
+)
+
+const (
+	// EndpointReadyCondition reports whether the host and the port of the control plane endpoint
+	// are known, either configured or resolved from the object exposing the API server.
+	EndpointReadyCondition = "EndpointReady"
+
+	// EndpointProvisionedReason is used when the endpoint is known.
+	EndpointProvisionedReason = "Provisioned"
+
+	// EndpointPendingReason is used while the address of the endpoint is not assigned yet.
+	EndpointPendingReason = "Pending"
+
+	// EndpointFailedReason is used when the endpoint cannot be resolved, e.g. because the
+	// Service type does not expose the API server.
+	EndpointFailedReason = "Failed"
+
+	// EndpointNodeUnavailableReason is used when the node whose address is the host of a NodePort
+	// endpoint is gone or not ready, and no other ready node with an address is found.
+	EndpointNodeUnavailableReason = "NodeUnavailable"
+
+	// EndpointMovedReason is used when the host of a NodePort endpoint was moved to the address
+	// of another node, as the node it was selected from is gone or not ready.
+	EndpointMovedReason = "Moved"
+
+	// EndpointConflictReason is used when the host of a control plane exposed through the SNI
+	// router is already routed to a control plane created earlier.
+	EndpointConflictReason = "Conflict"
+)
+
+const (
+	// PatchesAppliedCondition reports whether the patches of the control plane apply to the
+	// generated objects. The condition is absent when no patch is defined.
+	PatchesAppliedCondition = "PatchesApplied"
+
+	// PatchesAppliedReason is used when all the patches are applied.
+	PatchesAppliedReason = "Applied"
+
+	// PatchesFailedReason is used when a patch cannot be applied, in which case the objects are
+	// left as they are.
+	PatchesFailedReason = "Failed"
+)
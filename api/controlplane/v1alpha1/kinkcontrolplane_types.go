@@ -20,6 +20,7 @@ import (
 	kinkcorev1alpha1 "github.com/anza-labs/kink/api/core/v1alpha1"
 
 	corev1 "k8s.io/api/core/v1"
+	netv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -63,6 +64,131 @@ type KinkControlPlaneSpec struct {
 
 	// ControllerManager defines the configuration for the Kubernetes controller manager.
 	ControllerManager ControllerManager `json:"controllerManager"`
+
+	// Remediation defines the opt-in policy used to heal unhealthy control plane components.
+	// Components without a policy are never remediated.
+	// +optional
+	Remediation *Remediation `json:"remediation,omitempty"`
+
+	// Hibernation defines when the control plane is hibernated. A hibernated control plane
+	// has all of its components scaled to zero, while the Kine datastore is preserved. Requires
+	// Kine to persist its datastore in a PersistentVolumeClaim.
+	// +optional
+	Hibernation *Hibernation `json:"hibernation,omitempty"`
+
+	// Monitoring defines the opt-in scraping of the control plane components by the Prometheus Operator.
+	// +optional
+	Monitoring *Monitoring `json:"monitoring,omitempty"`
+
+	// NetworkPolicy defines the opt-in NetworkPolicies isolating the control plane components.
+	// +optional
+	NetworkPolicy *NetworkPolicy `json:"networkPolicy,omitempty"`
+
+	// Certificates defines the PKI of the control plane.
+	// +optional
+	Certificates *Certificates `json:"certificates,omitempty"`
+
+	// Kubeconfigs defines additional kubeconfigs, each authenticated with a dedicated client
+	// certificate, e.g. for CI jobs or read-only dashboards.
+	// +optional
+	// +listType=map
+	// +listMapKey=secretName
+	Kubeconfigs []Kubeconfig `json:"kubeconfigs,omitempty"`
+
+	// Patches modify the objects generated for the control plane, e.g. to set a field which is
+	// not exposed by the KinkControlPlane. The patches are applied in the order they are listed.
+	// While a patch fails, the objects are left as they are and the failure is reported by the
+	// PatchesApplied condition.
+	// +optional
+	// +listType=atomic
+	Patches []Patch `json:"patches,omitempty"`
+}
+
+// PatchType is the format of a patch.
+// +kubebuilder:validation:Enum=StrategicMerge;JSON6902
+type PatchType string
+
+const (
+	// StrategicMergePatchType merges the patch into the object. Lists of custom resources are
+	// replaced, as they do not define a merge strategy.
+	StrategicMergePatchType PatchType = "StrategicMerge"
+
+	// JSON6902PatchType applies a list of JSON patch operations, as defined by RFC 6902.
+	JSON6902PatchType PatchType = "JSON6902"
+)
+
+// Patch modifies the generated objects matching its target.
+type Patch struct {
+	// Target selects the objects to patch.
+	Target PatchTarget `json:"target"`
+
+	// Type is the format of the patch. Defaults to StrategicMerge.
+	// +optional
+	// +kubebuilder:default=StrategicMerge
+	Type PatchType `json:"type,omitempty"`
+
+	// Patch is the content of the patch, as YAML or JSON. The name and the namespace of the
+	// objects cannot be changed.
+	// +kubebuilder:validation:MinLength=1
+	Patch string `json:"patch"`
+}
+
+// PatchTarget selects generated objects by kind and component.
+type PatchTarget struct {
+	// Kind of the objects, e.g. Deployment.
+	// +kubebuilder:validation:MinLength=1
+	Kind string `json:"kind"`
+
+	// Component of the objects, as set in the app.kubernetes.io/component label, e.g. api-server,
+	// controller-manager, scheduler or kine. Matches all the components when empty.
+	// +optional
+	Component string `json:"component,omitempty"`
+}
+
+// KubeconfigEndpoint selects the API server endpoint used by a kubeconfig.
+// +kubebuilder:validation:Enum=Public;Service;Custom
+type KubeconfigEndpoint string
+
+const (
+	// KubeconfigEndpointPublic targets the public endpoint of the control plane.
+	KubeconfigEndpointPublic KubeconfigEndpoint = "Public"
+
+	// KubeconfigEndpointService targets the Service of the API server, which is reachable from
+	// the management cluster only.
+	KubeconfigEndpointService KubeconfigEndpoint = "Service"
+
+	// KubeconfigEndpointCustom targets the server set in the kubeconfig definition.
+	KubeconfigEndpointCustom KubeconfigEndpoint = "Custom"
+)
+
+// Kubeconfig defines an additional kubeconfig, authenticated with a dedicated client certificate.
+type Kubeconfig struct {
+	// SecretName is the name of the Secret holding the kubeconfig under the value key. It must not
+	// be the name of one of the Secrets managed by the operator for the control plane.
+	// +kubebuilder:validation:MinLength=1
+	SecretName string `json:"secretName"`
+
+	// Username is the name of the user, set as the common name of the client certificate.
+	// +kubebuilder:validation:MinLength=1
+	Username string `json:"username"`
+
+	// Groups are the groups of the user, set as the organizations of the client certificate.
+	// +optional
+	Groups []string `json:"groups,omitempty"`
+
+	// Duration is the lifetime of the client certificate, which is renewed after two thirds of it.
+	// Defaults to the lifetime of the leaf certificates.
+	// +optional
+	Duration *metav1.Duration `json:"duration,omitempty"`
+
+	// Endpoint selects the API server endpoint used by the kubeconfig. Defaults to Public.
+	// +optional
+	// +kubebuilder:default=Public
+	Endpoint KubeconfigEndpoint `json:"endpoint,omitempty"`
+
+	// Server is the absolute https URL of the API server, required when the endpoint is Custom.
+	// +optional
+	Server string `json:"server,omitempty"`
 }
 
 // APIEndpoint represents a reachable Kubernetes API endpoint.
@@ -79,6 +205,15 @@ type APIEndpoint struct {
 	// +kubebuilder:default="LoadBalancer"
 	ServiceType corev1.ServiceType `json:"serviceType"`
 
+	// Service configures the Service of the API server.
+	// +optional
+	Service *Service `json:"service,omitempty"`
+
+	// NodePort configures the selection of the node address exposing the API server, when the
+	// Service type is NodePort. The node port is pinned to the port, when it is configured.
+	// +optional
+	NodePort *NodePortEndpoint `json:"nodePort,omitempty"`
+
 	// Gateway.
 	// +optional
 	Gateway *Gateway `json:"gateway,omitempty"`
@@ -86,6 +221,22 @@ type APIEndpoint struct {
 	// Ingress.
 	// +optional
 	Ingress *Ingress `json:"ingress,omitempty"`
+
+	// SNIRouter exposes the API server through the SNI router shared between control planes and
+	// managed by the operator, instead of a LoadBalancer Service per control plane. The host is
+	// assigned under the base domain configured in the operator, and the Service is ClusterIP.
+	// +optional
+	SNIRouter *SNIRouter `json:"sniRouter,omitempty"`
+}
+
+// SNIRouter configures the exposure of the API server through the shared SNI router.
+type SNIRouter struct {
+	// Subdomain is the label prepended to the base domain of the SNI router to form the host.
+	// Defaults to the name of the control plane, suffixed with its namespace.
+	// +optional
+	// +kubebuilder:validation:MaxLength=63
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	Subdomain string `json:"subdomain,omitempty"`
 }
 
 // HostnameOrIP.
@@ -104,6 +255,7 @@ func (h HostnameOrIP) IsHostname() bool {
 // Kine represents ETCD-shim container.
 type Kine struct {
 	kinkcorev1alpha1.Container `json:",inline"`
+	kinkcorev1alpha1.PodExtras `json:",inline"`
 
 	// Persistence specifies volume configuration for Kine data persistence.
 	// Defaults to EmptyDir.
@@ -136,11 +288,65 @@ type ControllerManager struct {
 //   - Defaults to registry.k8s.io/kube-apiserver
 type APIServer struct {
 	KubeComponent `json:",inline"`
+
+	// ServiceAccount configures the issuance and the discovery of the service account tokens.
+	// +optional
+	ServiceAccount *ServiceAccount `json:"serviceAccount,omitempty"`
+
+	// Kubelet configures the connections from the API server to the kubelets. The API server
+	// authenticates to the kubelets with a client certificate in the system:masters group.
+	// +optional
+	Kubelet *KubeletConnection `json:"kubelet,omitempty"`
+}
+
+// KubeletConnection configures the connections from the API server to the kubelets, used for
+// logs, exec, port forwarding and metrics.
+type KubeletConnection struct {
+	// PreferredAddressTypes is the ordered list of node address types used to reach the kubelets.
+	// Defaults to InternalIP, ExternalIP and Hostname.
+	// +optional
+	PreferredAddressTypes []corev1.NodeAddressType `json:"preferredAddressTypes,omitempty"`
+
+	// VerifyServingCertificates verifies the serving certificates of the kubelets against the
+	// cluster CA. The kubelets must then serve certificates signed by the cluster CA, e.g. by
+	// enabling serverTLSBootstrap and approving their certificate signing requests.
+	// +optional
+	VerifyServingCertificates bool `json:"verifyServingCertificates,omitempty"`
+}
+
+// ServiceAccount configures the service account token issuer, e.g. to federate the tokens with
+// a cloud IAM or Vault.
+type ServiceAccount struct {
+	// Issuer is the identifier of the token issuer, set as the iss claim of the issued tokens.
+	// Tokens issued by https://kubernetes.default.svc.cluster.local remain valid after a change.
+	// Defaults to the public endpoint when PublicDiscovery is enabled, otherwise to
+	// https://kubernetes.default.svc.cluster.local.
+	// +optional
+	// +kubebuilder:validation:Pattern=`^https://`
+	Issuer string `json:"issuer,omitempty"`
+
+	// APIAudiences are the audiences accepted by the API server in the tokens.
+	// Defaults to the issuer.
+	// +optional
+	APIAudiences []string `json:"apiAudiences,omitempty"`
+
+	// JWKSURI overrides the URI of the JSON Web Key Set advertised by the discovery document,
+	// e.g. when the keys are published in a bucket. Defaults to the /openid/v1/jwks path of
+	// the issuer.
+	// +optional
+	// +kubebuilder:validation:Pattern=`^https://`
+	JWKSURI string `json:"jwksURI,omitempty"`
+
+	// PublicDiscovery allows anonymous requests to /.well-known/openid-configuration and
+	// /openid/v1/jwks, so that relying parties can fetch the keys through the public endpoint.
+	// +optional
+	PublicDiscovery bool `json:"publicDiscovery,omitempty"`
 }
 
 // KubeComponent defines the base configuration for Kink control plane components.
 type KubeComponent struct {
 	kinkcorev1alpha1.Container `json:",inline"`
+	kinkcorev1alpha1.PodExtras `json:",inline"`
 
 	// Verbosity specifies the log verbosity level for the container. Valid values range from 0 (silent) to 10 (most verbose).
 	// +optional
@@ -151,15 +357,90 @@ type KubeComponent struct {
 	Verbosity uint8 `json:"verbosity"`
 
 	// ExtraArgs defines additional arguments to be passed to the container executable.
+	// The service-account-issuer and service-account-key-file arguments of the API server are
+	// managed by the operator and may not be set.
 	// +optional
 	ExtraArgs map[string]string `json:"extraArgs,omitempty"`
 }
 
-// Gateway.
+// Service configures the Service of the API server.
+type Service struct {
+	// Annotations are added to the Service, e.g. the annotations of the load balancer provider.
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	// Labels are added to the Service.
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// LoadBalancerClass is the class of the load balancer implementation, when the Service type
+	// is LoadBalancer. It cannot be changed once the host of the endpoint is set, since the
+	// recreated Service would get another address.
+	// +optional
+	LoadBalancerClass *string `json:"loadBalancerClass,omitempty"`
+
+	// LoadBalancerSourceRanges restricts the CIDRs allowed to reach the load balancer, when the
+	// Service type is LoadBalancer.
+	// +optional
+	// +listType=atomic
+	LoadBalancerSourceRanges []string `json:"loadBalancerSourceRanges,omitempty"`
+
+	// LoadBalancerIP requests an address of the load balancer, when the Service type is
+	// LoadBalancer and the implementation supports it.
+	// +optional
+	LoadBalancerIP string `json:"loadBalancerIP,omitempty"`
+
+	// ExternalTrafficPolicy describes how nodes distribute the traffic they receive, when the
+	// Service type is NodePort or LoadBalancer.
+	// +optional
+	// +kubebuilder:validation:Enum=Cluster;Local
+	ExternalTrafficPolicy corev1.ServiceExternalTrafficPolicy `json:"externalTrafficPolicy,omitempty"`
+}
+
+// NodePortEndpoint configures the selection of the node address exposing the API server.
+type NodePortEndpoint struct {
+	// AddressTypes lists the node address types in order of preference.
+	// Defaults to ExternalIP, InternalIP and ExternalDNS.
+	// +optional
+	// +listType=atomic
+	AddressTypes []corev1.NodeAddressType `json:"addressTypes,omitempty"`
+
+	// NodeSelector selects the nodes whose address may be used. Defaults to all nodes.
+	// +optional
+	NodeSelector *metav1.LabelSelector `json:"nodeSelector,omitempty"`
+}
+
+// Gateway exposes the API server through a TLSRoute, matched on the control plane host via SNI
+// and passed through to the API server. TLSRoutes are part of the experimental channel of the
+// Gateway API, whose CRDs must be installed.
 type Gateway struct {
-	// GatewayClassName used for this Gateway. This is the name of a
-	// GatewayClass resource.
-	GatewayClassName string `json:"gatewayClassName"`
+	// GatewayClassName used for the Gateway created for the control plane. This is the name of a
+	// GatewayClass resource. Mutually exclusive with ParentRefs.
+	// +optional
+	GatewayClassName string `json:"gatewayClassName,omitempty"`
+
+	// ParentRefs references existing Gateways shared between control planes, to which the
+	// TLSRoute is attached instead of a Gateway created for the control plane. The referenced
+	// listeners must use the TLS protocol in Passthrough mode and allow routes from the
+	// namespace of the control plane. Mutually exclusive with GatewayClassName.
+	// +optional
+	// +listType=atomic
+	ParentRefs []GatewayParentReference `json:"parentRefs,omitempty"`
+}
+
+// GatewayParentReference references a Gateway, and optionally one of its listeners.
+type GatewayParentReference struct {
+	// Name is the name of the Gateway.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Namespace is the namespace of the Gateway. Defaults to the namespace of the control plane.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// SectionName is the name of the listener of the Gateway.
+	// +optional
+	SectionName string `json:"sectionName,omitempty"`
 }
 
 // Ingress.
@@ -175,6 +456,310 @@ type Ingress struct {
 	IngressClassName string `json:"ingressClassName"`
 }
 
+// Remediation defines remediation policies for each of the control plane components.
+type Remediation struct {
+	// APIServer defines the remediation policy for the Kubernetes API server.
+	// +optional
+	APIServer *RemediationPolicy `json:"apiServer,omitempty"`
+
+	// Kine defines the remediation policy for the Kine component.
+	// +optional
+	Kine *RemediationPolicy `json:"kine,omitempty"`
+
+	// Scheduler defines the remediation policy for the Kubernetes scheduler.
+	// +optional
+	Scheduler *RemediationPolicy `json:"scheduler,omitempty"`
+
+	// ControllerManager defines the remediation policy for the Kubernetes controller manager.
+	// +optional
+	ControllerManager *RemediationPolicy `json:"controllerManager,omitempty"`
+}
+
+// RemediationAction is the action taken against an unhealthy component.
+// +kubebuilder:validation:Enum=DeletePod;RestartRollout;RestartKine
+type RemediationAction string
+
+const (
+	// RemediationActionDeletePod deletes the unhealthy pods of the component.
+	RemediationActionDeletePod RemediationAction = "DeletePod"
+
+	// RemediationActionRestartRollout restarts the rollout of the component Deployment.
+	RemediationActionRestartRollout RemediationAction = "RestartRollout"
+
+	// RemediationActionRestartKine restarts the rollout of the Kine Deployment. It is only
+	// allowed for the Kine and API server components, as the API server is the only one
+	// connecting to Kine.
+	RemediationActionRestartKine RemediationAction = "RestartKine"
+)
+
+// RemediationPolicy defines when and how an unhealthy component is remediated.
+type RemediationPolicy struct {
+	// MaxUnhealthyDuration is the duration a pod of the component may stay not ready
+	// before the remediation action is taken. It is also the minimum time between two
+	// consecutive remediation attempts.
+	// +optional
+	// +default="5m"
+	// +kubebuilder:default="5m"
+	MaxUnhealthyDuration metav1.Duration `json:"maxUnhealthyDuration,omitempty"`
+
+	// Action is the remediation action taken against the unhealthy component.
+	// +optional
+	// +default="DeletePod"
+	// +kubebuilder:default="DeletePod"
+	Action RemediationAction `json:"action,omitempty"`
+
+	// MaxRetries is the number of remediation attempts allowed before the controller
+	// gives up. The budget is reset once the component becomes healthy again.
+	// +optional
+	// +default=3
+	// +kubebuilder:default=3
+	// +kubebuilder:validation:Minimum=1
+	MaxRetries int32 `json:"maxRetries,omitempty"`
+}
+
+// RemediationStatus records the remediation attempts of a single component.
+type RemediationStatus struct {
+	// Component is the name of the remediated component.
+	Component string `json:"component"`
+
+	// Attempts is the number of remediation attempts since the component was last healthy.
+	Attempts int32 `json:"attempts"`
+
+	// LastAction is the action taken during the last remediation attempt.
+	// +optional
+	LastAction RemediationAction `json:"lastAction,omitempty"`
+
+	// LastAttemptTime is the time of the last remediation attempt.
+	// +optional
+	LastAttemptTime *metav1.Time `json:"lastAttemptTime,omitempty"`
+}
+
+// Hibernation defines when the control plane components are scaled to zero.
+type Hibernation struct {
+	// Enabled hibernates the control plane regardless of the schedules.
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+
+	// Schedules defines recurring time windows in which the control plane is hibernated.
+	// +optional
+	Schedules []HibernationSchedule `json:"schedules,omitempty"`
+}
+
+// HibernationSchedule defines a recurring hibernation window using cron expressions.
+type HibernationSchedule struct {
+	// Start is a cron expression (in standard five-field format) defining when the control
+	// plane is hibernated, e.g. "0 20 * * 1-5".
+	Start string `json:"start"`
+
+	// End is a cron expression (in standard five-field format) defining when the control
+	// plane wakes up, e.g. "0 7 * * 1-5".
+	End string `json:"end"`
+
+	// Location is the IANA time zone name in which the schedule is evaluated, e.g. "Europe/Warsaw".
+	// Defaults to UTC.
+	// +optional
+	Location string `json:"location,omitempty"`
+}
+
+// Certificates defines the PKI of the control plane.
+type Certificates struct {
+	// IssuerRef references an existing cert-manager issuer, e.g. backed by Vault or a corporate CA,
+	// which issues the cluster CA. The cluster CA then becomes an intermediate CA chained to it,
+	// and issues the Kine and front proxy CAs. Mutually exclusive with CASecretRef.
+	// +optional
+	IssuerRef *IssuerReference `json:"issuerRef,omitempty"`
+
+	// CASecretRef references an existing Secret in the namespace of the control plane, holding
+	// the cluster CA key pair under the tls.crt and tls.key keys and the CA bundle under the ca.crt key.
+	// For a cluster created with kubeadm, the Secret is created from /etc/kubernetes/pki/ca.crt
+	// (as tls.crt and ca.crt) and /etc/kubernetes/pki/ca.key (as tls.key). The cluster CA then
+	// issues the Kine and front proxy CAs. Mutually exclusive with IssuerRef.
+	// +optional
+	CASecretRef *corev1.LocalObjectReference `json:"caSecretRef,omitempty"`
+
+	// CA defines the lifetime and the private key of the CA certificates.
+	// Defaults to a lifetime of 10 years and an RSA 2048 key. The lifetime must not be shorter
+	// than the one of the leaf certificates.
+	// +optional
+	CA *CertificateProfile `json:"ca,omitempty"`
+
+	// Leaf defines the lifetime and the private key of the leaf certificates.
+	// Defaults to a lifetime of 1 year and an RSA 2048 key.
+	// +optional
+	Leaf *CertificateProfile `json:"leaf,omitempty"`
+
+	// ExpirationWarningWindow is the time before the expiration of a certificate from which
+	// the CertificatesExpiringSoon condition is raised. Defaults to 7 days.
+	// +optional
+	ExpirationWarningWindow *metav1.Duration `json:"expirationWarningWindow,omitempty"`
+
+	// ServiceAccountKeyRetention is the time the previous service account keys remain trusted
+	// after the signing key changed, so that the tokens they signed stay valid until they are
+	// refreshed. A newly issued key only signs tokens once it is trusted by all the API server
+	// replicas. Defaults to 24 hours.
+	// +optional
+	ServiceAccountKeyRetention *metav1.Duration `json:"serviceAccountKeyRetention,omitempty"`
+
+	// Rotation requests the rotation of the certificate authorities and the service account
+	// signing key. Equivalent to the control-plane.kink.anza-labs.dev/rotate-certificate-authorities
+	// annotation, which is ignored when this field is set.
+	// +optional
+	Rotation *CertificateRotation `json:"rotation,omitempty"`
+}
+
+// CertificateRotation requests the rotation of the certificate authorities.
+type CertificateRotation struct {
+	// Token identifies the rotation request. Setting it to a value different from the token of
+	// the last rotation starts a new rotation of the cluster CA, the Kine CA, the front proxy CA
+	// and the service account signing key. An imported cluster CA is not rotated.
+	// +kubebuilder:validation:MinLength=1
+	Token string `json:"token"`
+}
+
+// CertificateRotationPhase is a phase of the rotation of the certificate authorities.
+type CertificateRotationPhase string
+
+const (
+	// RotationPhaseIssuingCA issues the new certificate authorities.
+	RotationPhaseIssuingCA CertificateRotationPhase = "IssuingCA"
+
+	// RotationPhaseDistributingTrust restarts the components with a trust bundle containing
+	// both the old and the new certificate authorities.
+	RotationPhaseDistributingTrust CertificateRotationPhase = "DistributingTrust"
+
+	// RotationPhaseReissuingCertificates re-issues the leaf certificates and the kubeconfigs
+	// with the new certificate authorities.
+	RotationPhaseReissuingCertificates CertificateRotationPhase = "ReissuingCertificates"
+
+	// RotationPhaseRemovingOldCA restarts the components with a trust bundle containing only
+	// the new certificate authorities.
+	RotationPhaseRemovingOldCA CertificateRotationPhase = "RemovingOldCA"
+
+	// RotationPhaseCompleted is set when the rotation finished.
+	RotationPhaseCompleted CertificateRotationPhase = "Completed"
+)
+
+// CertificateRotationStatus reports the progress of the rotation of the certificate authorities.
+type CertificateRotationStatus struct {
+	// Token of the rotation in progress or of the last completed rotation.
+	Token string `json:"token"`
+
+	// Phase of the rotation.
+	Phase CertificateRotationPhase `json:"phase"`
+
+	// StartTime is the time at which the rotation started.
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// CompletionTime is the time at which the rotation completed.
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+}
+
+// CertificateProfile defines the lifetime and the private key of a group of certificates.
+type CertificateProfile struct {
+	// Duration is the requested lifetime of the certificates.
+	// +optional
+	Duration *metav1.Duration `json:"duration,omitempty"`
+
+	// RenewBefore is how long before the expiry the certificates are renewed. Defaults to 30 days.
+	// Must be shorter than the lifetime, including the default one.
+	// +optional
+	RenewBefore *metav1.Duration `json:"renewBefore,omitempty"`
+
+	// PrivateKey defines the private keys of the certificates.
+	// +optional
+	PrivateKey *PrivateKey `json:"privateKey,omitempty"`
+}
+
+// PrivateKeyAlgorithm is the algorithm of a private key.
+// +kubebuilder:validation:Enum=RSA;ECDSA
+type PrivateKeyAlgorithm string
+
+const (
+	// RSAKeyAlgorithm generates RSA private keys.
+	RSAKeyAlgorithm PrivateKeyAlgorithm = "RSA"
+
+	// ECDSAKeyAlgorithm generates ECDSA private keys.
+	ECDSAKeyAlgorithm PrivateKeyAlgorithm = "ECDSA"
+)
+
+// PrivateKey defines a private key.
+type PrivateKey struct {
+	// Algorithm of the private key.
+	// +optional
+	// +default="RSA"
+	// +kubebuilder:default="RSA"
+	Algorithm PrivateKeyAlgorithm `json:"algorithm,omitempty"`
+
+	// Size of the private key in bits. Allowed values are 2048, 3072 and 4096 for RSA (defaults to 2048),
+	// and 256, 384 and 521 for ECDSA (defaults to 256).
+	// +optional
+	Size int32 `json:"size,omitempty"`
+}
+
+// IssuerReference references a cert-manager issuer.
+type IssuerReference struct {
+	// Name of the issuer.
+	Name string `json:"name"`
+
+	// Kind of the issuer, e.g. Issuer or ClusterIssuer.
+	// +optional
+	// +default="Issuer"
+	// +kubebuilder:default="Issuer"
+	Kind string `json:"kind,omitempty"`
+
+	// Group of the issuer. Defaults to cert-manager.io, set it when using an external issuer.
+	// +optional
+	Group string `json:"group,omitempty"`
+}
+
+// Monitoring defines the scraping of the control plane components by the Prometheus Operator.
+type Monitoring struct {
+	// Enabled enables the creation of ServiceMonitors for the control plane components.
+	// ServiceMonitors are skipped when the Prometheus Operator CRDs are not installed.
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+
+	// Interval at which the metrics are scraped. Defaults to the global scrape interval of Prometheus.
+	// +optional
+	Interval *metav1.Duration `json:"interval,omitempty"`
+
+	// Labels are added to the ServiceMonitors, e.g. to match the serviceMonitorSelector of Prometheus.
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+// NetworkPolicy defines the NetworkPolicies isolating the control plane components. Kine only
+// accepts connections from the API server, the API server only accepts connections on its port,
+// and the scheduler and controller manager only accept connections from metrics scrapers.
+type NetworkPolicy struct {
+	// Enabled enables the creation of NetworkPolicies for the control plane components.
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+
+	// APIServerCIDRs lists the CIDRs allowed to reach the API server. When the API server is
+	// exposed through a LoadBalancer or NodePort Service and the list is empty, any source is
+	// allowed. Note that the source of the traffic may be translated to a node address, depending
+	// on the external traffic policy of the Service.
+	// +optional
+	// +listType=atomic
+	APIServerCIDRs []string `json:"apiServerCIDRs,omitempty"`
+
+	// APIServerFrom lists the peers allowed to reach the API server, e.g. the pods of the Gateway
+	// or Ingress controller. When the API server is exposed through a Gateway or an Ingress and
+	// the list is empty, all the pods of the cluster are allowed.
+	// +optional
+	// +listType=atomic
+	APIServerFrom []netv1.NetworkPolicyPeer `json:"apiServerFrom,omitempty"`
+
+	// MetricsFrom lists the peers allowed to scrape the metrics of the components. Defaults to the
+	// pods of the namespaces labeled with metrics: enabled.
+	// +optional
+	// +listType=atomic
+	MetricsFrom []netv1.NetworkPolicyPeer `json:"metricsFrom,omitempty"`
+}
+
 // KinkControlPlaneStatus defines the observed state of KinkControlPlane.
 type KinkControlPlaneStatus struct {
 	// Version represents the minimum Kubernetes version for the control plane replicas
@@ -215,14 +800,65 @@ type KinkControlPlaneStatus struct {
 	// +optional
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
 
+	// Remediations records the remediation attempts of the unhealthy components.
+	// +optional
+	// +listType=map
+	// +listMapKey=component
+	Remediations []RemediationStatus `json:"remediations,omitempty"`
+
 	// Initialized denotes that the kink control plane API Server is initialized and thus
-	// it can accept requests.
+	// it can accept requests. Once set, it is not unset when the API Server becomes unavailable.
 	// +optional
 	Initialized bool `json:"initialized"`
 
 	// Ready denotes that the kink control plane is ready to serve requests.
 	// +optional
 	Ready bool `json:"ready"`
+
+	// FirstReadyTime is the time the kink control plane was ready for the first time.
+	// +optional
+	FirstReadyTime *metav1.Time `json:"firstReadyTime,omitempty"`
+
+	// Hibernated denotes that the kink control plane components are scaled to zero.
+	// +optional
+	Hibernated bool `json:"hibernated,omitempty"`
+
+	// CertificateRotation reports the progress of the rotation of the certificate authorities.
+	// +optional
+	CertificateRotation *CertificateRotationStatus `json:"certificateRotation,omitempty"`
+
+	// Certificates summarizes the certificates of the control plane.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	Certificates []CertificateStatus `json:"certificates,omitempty"`
+
+	// CertificatesNotAfter is the expiration time of the certificate expiring first.
+	// +optional
+	CertificatesNotAfter *metav1.Time `json:"certificatesNotAfter,omitempty"`
+
+	// EndpointNodeName is the name of the node whose address was selected as the host of the
+	// control plane endpoint. The address is selected again when the node goes away or is not ready.
+	// +optional
+	EndpointNodeName string `json:"endpointNodeName,omitempty"`
+}
+
+// CertificateStatus summarizes the state of a certificate.
+type CertificateStatus struct {
+	// Name of the cert-manager Certificate.
+	Name string `json:"name"`
+
+	// NotAfter is the expiration time of the certificate.
+	// +optional
+	NotAfter *metav1.Time `json:"notAfter,omitempty"`
+
+	// RenewalTime is the time at which the certificate is renewed.
+	// +optional
+	RenewalTime *metav1.Time `json:"renewalTime,omitempty"`
+
+	// Ready denotes that the certificate is issued and up to date.
+	// +optional
+	Ready bool `json:"ready"`
 }
 
 // +kubebuilder:object:root=true
@@ -237,6 +873,9 @@ type KinkControlPlaneStatus struct {
 // +kubebuilder:printcolumn:name="Unavailable",type="string",JSONPath=".status.unavailableReplicas"
 // +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 // +kubebuilder:printcolumn:name="Version",type="string",JSONPath=".status.version"
+// +kubebuilder:printcolumn:name="Hibernated",type="boolean",JSONPath=".status.hibernated",priority=1
+// +kubebuilder:printcolumn:name="Certificates Expire",type="string",JSONPath=".status.certificatesNotAfter",priority=1
+// +kubebuilder:printcolumn:name="Expiring Soon",type="string",JSONPath=".status.conditions[?(@.type==\"CertificatesExpiringSoon\")].status",priority=1
 
 // KinkControlPlane is the Schema for the kinkcontrolplanes API.
 type KinkControlPlane struct {
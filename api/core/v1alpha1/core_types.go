@@ -32,6 +32,62 @@ type Container struct {
 	// Resources describes the compute resource requirements for the container.
 	// +optional
 	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// SecurityContext replaces the hardened security context of the container, which runs as
+	// a non-root user with a read-only root filesystem, no privilege escalation and all the
+	// capabilities dropped.
+	// +optional
+	SecurityContext *corev1.SecurityContext `json:"securityContext,omitempty"`
+
+	// PodSecurityContext replaces the hardened security context of the pod, which runs as the
+	// non-root user 65532 with the RuntimeDefault seccomp profile.
+	// +optional
+	PodSecurityContext *corev1.PodSecurityContext `json:"podSecurityContext,omitempty"`
+}
+
+// PodExtras defines additional items injected into the pod of a component, e.g. a KMS plugin,
+// a log shipper, extra CA bundles or webhook kubeconfigs. The items are appended after the ones
+// managed by the operator, in the order they are listed. The schemas of the volumes and the
+// containers are not part of the CRD to keep its size within bounds, they are validated when
+// the pod is created.
+type PodExtras struct {
+	// ExtraVolumes are appended to the volumes of the pod. Their names must differ from the
+	// volumes managed by the operator.
+	// +optional
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +kubebuilder:validation:Type=array
+	ExtraVolumes []corev1.Volume `json:"extraVolumes,omitempty"`
+
+	// ExtraVolumeMounts are appended to the volume mounts of the component container. Their mount
+	// paths must not overlap the mounts managed by the operator.
+	// +optional
+	// +listType=atomic
+	ExtraVolumeMounts []corev1.VolumeMount `json:"extraVolumeMounts,omitempty"`
+
+	// ExtraEnv is appended to the environment of the component container.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	ExtraEnv []corev1.EnvVar `json:"extraEnv,omitempty"`
+
+	// ExtraContainers are appended to the containers of the pod, e.g. sidecars. They are not
+	// hardened by the operator and must set their own security context to comply with the
+	// restricted Pod Security Standard.
+	// +optional
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +kubebuilder:validation:Type=array
+	ExtraContainers []corev1.Container `json:"extraContainers,omitempty"`
+
+	// ExtraInitContainers are appended to the init containers of the pod. They are not hardened
+	// by the operator and must set their own security context to comply with the restricted Pod
+	// Security Standard.
+	// +optional
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +kubebuilder:validation:Type=array
+	ExtraInitContainers []corev1.Container `json:"extraInitContainers,omitempty"`
 }
 
 // Container defines the minimum persistence configuration. It always defaults to EmptyDir.
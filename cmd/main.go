@@ -18,23 +18,31 @@ import (
 	"crypto/tls"
 	"flag"
 	"os"
+	"time"
 
 	cmv1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 
 	controlplanev1alpha1 "github.com/anza-labs/kink/api/controlplane/v1alpha1"
 	"github.com/anza-labs/kink/internal/controller/controlplane"
+	"github.com/anza-labs/kink/internal/controller/snirouter"
+	"github.com/anza-labs/kink/internal/metrics"
 	controlplanewebhookv1alpha1 "github.com/anza-labs/kink/internal/webhook/controlplane/v1alpha1"
+	"github.com/anza-labs/kink/version"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/klog/v2"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
+	crmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
 	"sigs.k8s.io/controller-runtime/pkg/metrics/filters"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 	gatewayapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayapiv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	// to ensure that exec-entrypoint and run can make use of them.
@@ -51,7 +59,9 @@ func init() {
 
 	utilruntime.Must(controlplanev1alpha1.AddToScheme(scheme))
 	utilruntime.Must(gatewayapiv1.Install(scheme))
+	utilruntime.Must(gatewayapiv1alpha2.Install(scheme))
 	utilruntime.Must(cmv1.AddToScheme(scheme))
+	utilruntime.Must(monitoringv1.AddToScheme(scheme))
 	// +kubebuilder:scaffold:scheme
 }
 
@@ -62,6 +72,11 @@ func main() {
 	var secureMetrics bool
 	var enableHTTP2 bool
 	var enabledController string
+	var resyncPeriod time.Duration
+	var sniRouterNamespace string
+	var sniRouterBaseDomain string
+	var sniRouterImage string
+	var sniRouterServiceType string
 	var tlsOpts []func(*tls.Config)
 	flag.StringVar(&metricsAddr, "metrics-bind-address", "0", "The address the metrics endpoint binds to. "+
 		"Use :8443 for HTTPS or :8080 for HTTP, or leave as 0 to disable the metrics service.")
@@ -74,6 +89,18 @@ func main() {
 	flag.BoolVar(&enableHTTP2, "enable-http2", false,
 		"If set, HTTP/2 will be enabled for the metrics and webhook servers")
 	flag.StringVar(&enabledController, "enable-controller", "", "The controller to enable. Default: all")
+	flag.DurationVar(&resyncPeriod, "resync-period", 10*time.Minute,
+		"The maximum interval between reconciliations of a control plane, used to correct drift of owned objects. "+
+			"Use 0 to disable the periodic resync.")
+	flag.StringVar(&sniRouterNamespace, "sni-router-namespace", "",
+		"The namespace of the SNI router shared between the control planes exposed through it. "+
+			"Leave empty to disable the SNI router.")
+	flag.StringVar(&sniRouterBaseDomain, "sni-router-base-domain", "",
+		"The domain under which the hosts of the control planes exposed through the SNI router are assigned. "+
+			"A wildcard DNS record of the domain should resolve to the SNI router.")
+	flag.StringVar(&sniRouterImage, "sni-router-image", version.SNIRouter(), "The HAProxy image of the SNI router.")
+	flag.StringVar(&sniRouterServiceType, "sni-router-service-type", string(corev1.ServiceTypeLoadBalancer),
+		"The type of the Service exposing the SNI router.")
 	klog.InitFlags(nil)
 	flag.Parse()
 
@@ -131,23 +158,55 @@ func main() {
 
 	// +kubebuilder:scaffold:builder
 
+	if err := crmetrics.Registry.Register(metrics.NewCollector(mgr.GetClient())); err != nil {
+		setupLog.Error(err, "unable to register metrics collector")
+		os.Exit(1)
+	}
+
 	setupLog.V(2).Info("Enabling control-plane controller")
 	if err := (&controlplane.KinkControlPlaneReconciler{
-		Client: mgr.GetClient(),
-		Scheme: mgr.GetScheme(),
+		Client:       mgr.GetClient(),
+		Scheme:       mgr.GetScheme(),
+		Recorder:     mgr.GetEventRecorderFor("kinkcontrolplane-controller"),
+		ResyncPeriod: resyncPeriod,
+
+		SNIRouterBaseDomain: sniRouterBaseDomain,
+		SNIRouterNamespace:  sniRouterNamespace,
+		OperatorNamespace:   os.Getenv("POD_NAMESPACE"),
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "KinkControlPlane")
 		os.Exit(1)
 	}
 
+	if sniRouterNamespace != "" {
+		if sniRouterBaseDomain == "" {
+			setupLog.Error(nil, "--sni-router-base-domain is required when the SNI router is enabled")
+			os.Exit(1)
+		}
+
+		setupLog.V(2).Info("Enabling SNI router controller")
+		if err := (&snirouter.SNIRouterReconciler{
+			Client:      mgr.GetClient(),
+			Scheme:      mgr.GetScheme(),
+			Namespace:   sniRouterNamespace,
+			BaseDomain:  sniRouterBaseDomain,
+			Image:       sniRouterImage,
+			ServiceType: corev1.ServiceType(sniRouterServiceType),
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "SNIRouter")
+			os.Exit(1)
+		}
+	}
+
 	if os.Getenv("ENABLE_WEBHOOKS") != "false" {
 		setupLog.V(2).Info("Enabling webhook", "webhook", "KinkControlPlane")
-		if err := controlplanewebhookv1alpha1.SetupKinkControlPlaneWebhookWithManager(mgr); err != nil {
+		if err := controlplanewebhookv1alpha1.SetupKinkControlPlaneWebhookWithManager(mgr, sniRouterBaseDomain); err != nil {
 			setupLog.Error(err, "unable to create webhook", "webhook", "KinkControlPlane")
 			os.Exit(1)
 		}
 		setupLog.V(2).Info("Enabling webhook", "webhook", "KinkControlPlaneTemplate")
-		if err := controlplanewebhookv1alpha1.SetupKinkControlPlaneTemplateWebhookWithManager(mgr); err != nil {
+		err := controlplanewebhookv1alpha1.SetupKinkControlPlaneTemplateWebhookWithManager(mgr, sniRouterBaseDomain)
+		if err != nil {
 			setupLog.Error(err, "unable to create webhook", "webhook", "KinkControlPlaneTemplate")
 			os.Exit(1)
 		}
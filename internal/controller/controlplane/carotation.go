@@ -0,0 +1,352 @@
+// Copyright 2025 anza-labs contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controlplane
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"time"
+
+	cmv1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmetav1 "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+
+	controlplanev1alpha1 "github.com/anza-labs/kink/api/controlplane/v1alpha1"
+	"github.com/anza-labs/kink/internal/controller/util"
+	"github.com/anza-labs/kink/internal/manifests/controlplane"
+	"github.com/anza-labs/kink/internal/naming"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	// rotateCertificateAuthoritiesAnnotation requests the rotation of the certificate authorities,
+	// its value is the token identifying the request.
+	rotateCertificateAuthoritiesAnnotation = "control-plane.kink.anza-labs.dev/rotate-certificate-authorities"
+
+	// caRotationPollInterval is the interval at which the progress of a rotation is checked.
+	caRotationPollInterval = 15 * time.Second
+)
+
+// caRotationToken returns the token of the requested rotation of the certificate authorities.
+func caRotationToken(kinkCP *controlplanev1alpha1.KinkControlPlane) string {
+	if certs := kinkCP.Spec.Certificates; certs != nil && certs.Rotation != nil {
+		return certs.Rotation.Token
+	}
+	return kinkCP.Annotations[rotateCertificateAuthoritiesAnnotation]
+}
+
+// caRotationInProgress reports whether the certificate authorities are being rotated.
+func caRotationInProgress(kinkCP *controlplanev1alpha1.KinkControlPlane) bool {
+	status := kinkCP.Status.CertificateRotation
+	return status != nil && status.Phase != controlplanev1alpha1.RotationPhaseCompleted
+}
+
+// startCARotation starts a rotation of the certificate authorities when one was requested with
+// a new token. A request made during a rotation is handled once the rotation completed.
+func (r *KinkControlPlaneReconciler) startCARotation(kinkCP *controlplanev1alpha1.KinkControlPlane) {
+	token := caRotationToken(kinkCP)
+	if token == "" || caRotationInProgress(kinkCP) {
+		return
+	}
+	if status := kinkCP.Status.CertificateRotation; status != nil && status.Token == token {
+		return
+	}
+
+	kinkCP.Status.CertificateRotation = &controlplanev1alpha1.CertificateRotationStatus{
+		Token:     token,
+		StartTime: ptr.To(metav1.Now()),
+	}
+	r.setCARotationPhase(kinkCP, controlplanev1alpha1.RotationPhaseIssuingCA,
+		"Issuing new certificate authorities")
+}
+
+// setCARotationPhase records the phase of the rotation in the status and emits an Event.
+func (r *KinkControlPlaneReconciler) setCARotationPhase(
+	kinkCP *controlplanev1alpha1.KinkControlPlane,
+	phase controlplanev1alpha1.CertificateRotationPhase,
+	message string,
+) {
+	status := metav1.ConditionFalse
+	if phase == controlplanev1alpha1.RotationPhaseCompleted {
+		status = metav1.ConditionTrue
+		kinkCP.Status.CertificateRotation.CompletionTime = ptr.To(metav1.Now())
+	}
+	kinkCP.Status.CertificateRotation.Phase = phase
+
+	r.Recorder.Event(kinkCP, corev1.EventTypeNormal, "CertificateAuthorityRotation", message)
+	meta.SetStatusCondition(&kinkCP.Status.Conditions, metav1.Condition{
+		Type:               controlplanev1alpha1.CertificateAuthoritiesRotatedCondition,
+		Status:             status,
+		Reason:             string(phase),
+		Message:            message,
+		ObservedGeneration: kinkCP.Generation,
+	})
+}
+
+// reconcileCARotation advances the rotation of the certificate authorities. Each phase waits
+// for the effects of the previous one, the trust bundle and the component restarts being
+// handled by the regular reconciliation of the resources:
+//
+//   - IssuingCA: once the current certificates are captured in the trust bundle, new CAs and
+//     a new service account key are issued.
+//   - DistributingTrust: the components are restarted with a trust bundle containing both
+//     the previous and the new certificates.
+//   - ReissuingCertificates: the leaf certificates and the kubeconfigs are re-issued with the
+//     new CAs, and the components restarted.
+//   - RemovingOldCA: the components are restarted with a trust bundle containing only the
+//     new certificates.
+func (r *KinkControlPlaneReconciler) reconcileCARotation(
+	ctx context.Context,
+	kinkCP *controlplanev1alpha1.KinkControlPlane,
+) error {
+	if !caRotationInProgress(kinkCP) {
+		return nil
+	}
+	log := log.FromContext(ctx)
+
+	bundle := &corev1.Secret{}
+	if err := r.Get(ctx, types.NamespacedName{
+		Name:      naming.TrustBundle(kinkCP.Name),
+		Namespace: kinkCP.Namespace,
+	}, bundle); err != nil {
+		return fmt.Errorf("failed to get trust bundle: %w", err)
+	}
+	anchors := (&controlplane.TrustBundle{KinkControlPlane: kinkCP}).Anchors()
+
+	switch kinkCP.Status.CertificateRotation.Phase {
+	case controlplanev1alpha1.RotationPhaseIssuingCA:
+		issued := true
+		for _, anchor := range anchors {
+			if anchor.Certificate == "" {
+				continue
+			}
+			previous, ok := bundle.Data[controlplane.PreviousBundleKey(anchor.Key)]
+			if !ok {
+				log.V(2).Info("Waiting for the current certificates to be captured in the trust bundle")
+				return nil
+			}
+
+			current, err := r.secretCertificate(ctx, kinkCP.Namespace, anchor.SecretName)
+			if err != nil {
+				return err
+			}
+			if current != nil && !controlplane.ContainsCertificate(previous, current) {
+				continue
+			}
+
+			issued = false
+			if err := r.renewCertificate(ctx, kinkCP.Namespace, anchor.Certificate); err != nil {
+				return err
+			}
+		}
+		if issued {
+			r.setCARotationPhase(kinkCP, controlplanev1alpha1.RotationPhaseDistributingTrust,
+				"Restarting components trusting both the previous and the new certificate authorities")
+		}
+
+	case controlplanev1alpha1.RotationPhaseDistributingTrust:
+		for _, anchor := range anchors {
+			current, err := r.secretCertificate(ctx, kinkCP.Namespace, anchor.SecretName)
+			if err != nil {
+				return err
+			}
+			if !controlplane.ContainsCertificate(bundle.Data[anchor.Key], current) {
+				log.V(2).Info("Waiting for the new certificates to be added to the trust bundle")
+				return nil
+			}
+		}
+
+		updated, err := r.componentsUpdated(ctx, kinkCP)
+		if err != nil {
+			return err
+		}
+		if updated {
+			r.setCARotationPhase(kinkCP, controlplanev1alpha1.RotationPhaseReissuingCertificates,
+				"Re-issuing certificates with the new certificate authorities")
+		}
+
+	case controlplanev1alpha1.RotationPhaseReissuingCertificates:
+		reissued := true
+		for _, anchor := range anchors {
+			ca, err := r.secretCertificate(ctx, kinkCP.Namespace, anchor.SecretName)
+			if err != nil {
+				return err
+			}
+			for _, name := range anchor.Issued {
+				leaf, err := r.secretCertificate(ctx, kinkCP.Namespace, name)
+				if err != nil {
+					return err
+				}
+				if signedBy(leaf, ca) {
+					continue
+				}
+
+				reissued = false
+				if err := r.renewCertificate(ctx, kinkCP.Namespace, name); err != nil {
+					return err
+				}
+			}
+		}
+		if !reissued {
+			return nil
+		}
+
+		updated, err := r.componentsUpdated(ctx, kinkCP)
+		if err != nil {
+			return err
+		}
+		if updated {
+			r.setCARotationPhase(kinkCP, controlplanev1alpha1.RotationPhaseRemovingOldCA,
+				"Restarting components trusting only the new certificate authorities")
+		}
+
+	case controlplanev1alpha1.RotationPhaseRemovingOldCA:
+		for _, anchor := range anchors {
+			if _, ok := bundle.Data[controlplane.PreviousBundleKey(anchor.Key)]; ok {
+				log.V(2).Info("Waiting for the previous certificates to be removed from the trust bundle")
+				return nil
+			}
+		}
+
+		updated, err := r.componentsUpdated(ctx, kinkCP)
+		if err != nil {
+			return err
+		}
+		if updated {
+			r.setCARotationPhase(kinkCP, controlplanev1alpha1.RotationPhaseCompleted,
+				"Certificate authorities rotated")
+		}
+	}
+
+	return nil
+}
+
+// componentsUpdated reports whether all components were restarted with the current Secrets.
+func (r *KinkControlPlaneReconciler) componentsUpdated(
+	ctx context.Context,
+	kinkCP *controlplanev1alpha1.KinkControlPlane,
+) (bool, error) {
+	deployments, err := util.FindOwnedObjects(
+		ctx,
+		r.Client,
+		r.Scheme,
+		kinkCP,
+		r.GetOwnedResourceTypes(util.Only[*appsv1.Deployment]{}),
+	)
+	if err != nil {
+		return false, fmt.Errorf("failed to find owned deployments: %w", err)
+	}
+
+	for _, obj := range deployments {
+		depl, ok := obj.(*appsv1.Deployment)
+		if !ok {
+			continue
+		}
+
+		certs, err := r.secretCertificates(ctx, kinkCP.Namespace, depl.Spec.Template.Spec.Volumes)
+		if err != nil {
+			return false, fmt.Errorf("failed to get secrets of %s: %w", depl.Name, err)
+		}
+		if depl.Spec.Template.Annotations[certificatesHashAnnotation] != certificatesHash(certs) ||
+			!rolledOut(depl) {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// secretCertificate returns the first certificate stored in the Secret, or nil if the Secret
+// does not exist yet.
+func (r *KinkControlPlaneReconciler) secretCertificate(
+	ctx context.Context,
+	namespace, name string,
+) ([]byte, error) {
+	secret := &corev1.Secret{}
+	err := r.Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, secret)
+	if client.IgnoreNotFound(err) != nil {
+		return nil, fmt.Errorf("failed to get secret %s: %w", name, err)
+	}
+	return controlplane.FirstCertificate(secret.Data["tls.crt"]), nil
+}
+
+// renewCertificate triggers the re-issuance of the Certificate the same way as `cmctl renew`,
+// unless it is already being issued.
+func (r *KinkControlPlaneReconciler) renewCertificate(ctx context.Context, namespace, name string) error {
+	cert := &cmv1.Certificate{}
+	if err := r.Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, cert); err != nil {
+		return fmt.Errorf("failed to get certificate %s: %w", name, err)
+	}
+
+	condition := cmv1.CertificateCondition{
+		Type:               cmv1.CertificateConditionIssuing,
+		Status:             cmmetav1.ConditionTrue,
+		Reason:             "ManuallyTriggered",
+		Message:            "Re-issuance requested by the rotation of the certificate authorities",
+		LastTransitionTime: ptr.To(metav1.Now()),
+		ObservedGeneration: cert.Generation,
+	}
+	found := false
+	for i, c := range cert.Status.Conditions {
+		if c.Type != cmv1.CertificateConditionIssuing {
+			continue
+		}
+		if c.Status == cmmetav1.ConditionTrue {
+			return nil
+		}
+		cert.Status.Conditions[i] = condition
+		found = true
+	}
+	if !found {
+		cert.Status.Conditions = append(cert.Status.Conditions, condition)
+	}
+
+	log.FromContext(ctx).Info("Triggering certificate re-issuance", "certificate", name)
+	if err := r.Status().Update(ctx, cert); err != nil {
+		return fmt.Errorf("failed to trigger re-issuance of certificate %s: %w", name, err)
+	}
+	return nil
+}
+
+// signedBy reports whether the PEM encoded certificate is signed by the PEM encoded CA.
+func signedBy(certPEM, caPEM []byte) bool {
+	cert, err := parseCertificate(certPEM)
+	if err != nil {
+		return false
+	}
+	ca, err := parseCertificate(caPEM)
+	if err != nil {
+		return false
+	}
+	return cert.CheckSignatureFrom(ca) == nil
+}
+
+func parseCertificate(data []byte) (*x509.Certificate, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM data found")
+	}
+	return x509.ParseCertificate(block.Bytes)
+}
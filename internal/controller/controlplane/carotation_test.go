@@ -0,0 +1,341 @@
+// Copyright 2025 anza-labs contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controlplane
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	cmv1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmetav1 "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	controlplanev1alpha1 "github.com/anza-labs/kink/api/controlplane/v1alpha1"
+	"github.com/anza-labs/kink/internal/manifests/controlplane"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// testCertificate returns a PEM encoded certificate and its key. The certificate is self-signed
+// when the parent is nil.
+func testCertificate(t *testing.T, cn string, parent *x509.Certificate, parentKey crypto.Signer) (
+	*x509.Certificate, crypto.Signer, []byte,
+) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: cn},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  parent == nil,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+	}
+	if parent == nil {
+		parent, parentKey = template, key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, key.Public(), parentKey)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	return cert, key, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+func TestStartCARotation(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		annotations map[string]string
+		spec        *controlplanev1alpha1.CertificateRotation
+		status      *controlplanev1alpha1.CertificateRotationStatus
+		expected    *controlplanev1alpha1.CertificateRotationStatus
+	}{
+		"NotRequested": {
+			expected: nil,
+		},
+		"RequestedBySpec": {
+			spec: &controlplanev1alpha1.CertificateRotation{Token: "1"},
+			expected: &controlplanev1alpha1.CertificateRotationStatus{
+				Token: "1",
+				Phase: controlplanev1alpha1.RotationPhaseIssuingCA,
+			},
+		},
+		"RequestedByAnnotation": {
+			annotations: map[string]string{rotateCertificateAuthoritiesAnnotation: "a"},
+			expected: &controlplanev1alpha1.CertificateRotationStatus{
+				Token: "a",
+				Phase: controlplanev1alpha1.RotationPhaseIssuingCA,
+			},
+		},
+		"SpecOverridesAnnotation": {
+			annotations: map[string]string{rotateCertificateAuthoritiesAnnotation: "a"},
+			spec:        &controlplanev1alpha1.CertificateRotation{Token: "1"},
+			expected: &controlplanev1alpha1.CertificateRotationStatus{
+				Token: "1",
+				Phase: controlplanev1alpha1.RotationPhaseIssuingCA,
+			},
+		},
+		"AlreadyCompleted": {
+			spec: &controlplanev1alpha1.CertificateRotation{Token: "1"},
+			status: &controlplanev1alpha1.CertificateRotationStatus{
+				Token: "1",
+				Phase: controlplanev1alpha1.RotationPhaseCompleted,
+			},
+			expected: &controlplanev1alpha1.CertificateRotationStatus{
+				Token: "1",
+				Phase: controlplanev1alpha1.RotationPhaseCompleted,
+			},
+		},
+		"InProgress": {
+			spec: &controlplanev1alpha1.CertificateRotation{Token: "2"},
+			status: &controlplanev1alpha1.CertificateRotationStatus{
+				Token: "1",
+				Phase: controlplanev1alpha1.RotationPhaseDistributingTrust,
+			},
+			expected: &controlplanev1alpha1.CertificateRotationStatus{
+				Token: "1",
+				Phase: controlplanev1alpha1.RotationPhaseDistributingTrust,
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			// prepare
+			r := &KinkControlPlaneReconciler{Recorder: record.NewFakeRecorder(10)}
+			kcp := &controlplanev1alpha1.KinkControlPlane{
+				ObjectMeta: metav1.ObjectMeta{Annotations: tc.annotations},
+				Spec: controlplanev1alpha1.KinkControlPlaneSpec{
+					Certificates: &controlplanev1alpha1.Certificates{Rotation: tc.spec},
+				},
+				Status: controlplanev1alpha1.KinkControlPlaneStatus{CertificateRotation: tc.status},
+			}
+
+			// test
+			r.startCARotation(kcp)
+
+			// validate
+			actual := kcp.Status.CertificateRotation
+			if tc.expected == nil {
+				assert.Nil(t, actual)
+				return
+			}
+			require.NotNil(t, actual)
+			assert.Equal(t, tc.expected.Token, actual.Token)
+			assert.Equal(t, tc.expected.Phase, actual.Phase)
+		})
+	}
+}
+
+func TestReconcileCARotation(t *testing.T) {
+	t.Parallel()
+
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(cmv1.AddToScheme(scheme))
+	utilruntime.Must(controlplanev1alpha1.AddToScheme(scheme))
+
+	oldCA, oldCAKey, oldCAPEM := testCertificate(t, "old", nil, nil)
+	_, _, newCAPEM := testCertificate(t, "new", nil, nil)
+	_, _, oldLeafPEM := testCertificate(t, "leaf", oldCA, oldCAKey)
+
+	secret := func(name string, cert []byte) *corev1.Secret {
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Data:       map[string][]byte{"tls.crt": cert},
+		}
+	}
+	certificate := func(name string) *cmv1.Certificate {
+		return &cmv1.Certificate{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"}}
+	}
+	kcp := func(phase controlplanev1alpha1.CertificateRotationPhase) *controlplanev1alpha1.KinkControlPlane {
+		return &controlplanev1alpha1.KinkControlPlane{
+			ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+			Status: controlplanev1alpha1.KinkControlPlaneStatus{
+				CertificateRotation: &controlplanev1alpha1.CertificateRotationStatus{Token: "1", Phase: phase},
+			},
+		}
+	}
+	bundle := func(previous bool) *corev1.Secret {
+		data := map[string][]byte{}
+		for _, key := range []string{
+			controlplane.ClusterCABundleKey,
+			controlplane.KineCABundleKey,
+			controlplane.FrontProxyCABundleKey,
+			controlplane.ServiceAccountsBundleKey,
+		} {
+			data[key] = oldCAPEM
+			if previous {
+				data[controlplane.PreviousBundleKey(key)] = oldCAPEM
+			}
+		}
+		s := secret("test-trust-bundle", nil)
+		s.Data = data
+		return s
+	}
+	certificates := []client.Object{
+		certificate("test-ca"), certificate("test-etcd"), certificate("test-proxy"), certificate("test-sa"),
+		certificate("test-api-server"), certificate("test-admin-cert"),
+		certificate("test-scheduler-cert"), certificate("test-controller-manager-cert"),
+		certificate("test-kubelet-client-cert"),
+		certificate("test-etcd-server"), certificate("test-etcd-client"),
+	}
+
+	issuing := func(c *cmv1.Certificate) bool {
+		for _, cond := range c.Status.Conditions {
+			if cond.Type == cmv1.CertificateConditionIssuing && cond.Status == cmmetav1.ConditionTrue {
+				return true
+			}
+		}
+		return false
+	}
+
+	for name, tc := range map[string]struct {
+		kcp              *controlplanev1alpha1.KinkControlPlane
+		objects          []client.Object
+		expectedPhase    controlplanev1alpha1.CertificateRotationPhase
+		expectedRenewals []string
+	}{
+		"WaitingForCapture": {
+			kcp: kcp(controlplanev1alpha1.RotationPhaseIssuingCA),
+			objects: []client.Object{
+				bundle(false),
+				secret("test-ca", oldCAPEM), secret("test-etcd", oldCAPEM),
+				secret("test-proxy", oldCAPEM), secret("test-sa", oldCAPEM),
+			},
+			expectedPhase:    controlplanev1alpha1.RotationPhaseIssuingCA,
+			expectedRenewals: []string{},
+		},
+		"IssuingCA": {
+			kcp: kcp(controlplanev1alpha1.RotationPhaseIssuingCA),
+			objects: []client.Object{
+				bundle(true),
+				secret("test-ca", oldCAPEM), secret("test-etcd", newCAPEM),
+				secret("test-proxy", oldCAPEM), secret("test-sa", oldCAPEM),
+			},
+			expectedPhase:    controlplanev1alpha1.RotationPhaseIssuingCA,
+			expectedRenewals: []string{"test-ca", "test-proxy", "test-sa"},
+		},
+		"IssuedCA": {
+			kcp: kcp(controlplanev1alpha1.RotationPhaseIssuingCA),
+			objects: []client.Object{
+				bundle(true),
+				secret("test-ca", newCAPEM), secret("test-etcd", newCAPEM),
+				secret("test-proxy", newCAPEM), secret("test-sa", newCAPEM),
+			},
+			expectedPhase:    controlplanev1alpha1.RotationPhaseDistributingTrust,
+			expectedRenewals: []string{},
+		},
+		"ReissuingCertificates": {
+			kcp: kcp(controlplanev1alpha1.RotationPhaseReissuingCertificates),
+			objects: []client.Object{
+				bundle(true),
+				secret("test-ca", oldCAPEM), secret("test-etcd", newCAPEM),
+				secret("test-proxy", oldCAPEM), secret("test-sa", oldCAPEM),
+				secret("test-api-server", oldLeafPEM), secret("test-admin-cert", oldLeafPEM),
+				secret("test-scheduler-cert", oldLeafPEM), secret("test-controller-manager-cert", oldLeafPEM),
+				secret("test-kubelet-client-cert", oldLeafPEM),
+				secret("test-etcd-server", oldLeafPEM), secret("test-etcd-client", oldLeafPEM),
+			},
+			expectedPhase:    controlplanev1alpha1.RotationPhaseReissuingCertificates,
+			expectedRenewals: []string{"test-etcd-client", "test-etcd-server"},
+		},
+		"RemovingOldCA": {
+			kcp: kcp(controlplanev1alpha1.RotationPhaseRemovingOldCA),
+			objects: []client.Object{
+				bundle(false),
+			},
+			expectedPhase:    controlplanev1alpha1.RotationPhaseCompleted,
+			expectedRenewals: []string{},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			// prepare
+			c := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(append(tc.objects, certificates...)...).
+				WithStatusSubresource(&cmv1.Certificate{}).
+				Build()
+			r := &KinkControlPlaneReconciler{
+				Client:   c,
+				Scheme:   scheme,
+				Recorder: record.NewFakeRecorder(10),
+			}
+
+			// test
+			err := r.reconcileCARotation(t.Context(), tc.kcp)
+
+			// validate
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedPhase, tc.kcp.Status.CertificateRotation.Phase)
+			cond := meta.FindStatusCondition(tc.kcp.Status.Conditions,
+				controlplanev1alpha1.CertificateAuthoritiesRotatedCondition)
+			if tc.expectedPhase != controlplanev1alpha1.RotationPhaseIssuingCA &&
+				tc.expectedPhase != controlplanev1alpha1.RotationPhaseReissuingCertificates {
+				require.NotNil(t, cond)
+				assert.Equal(t, string(tc.expectedPhase), cond.Reason)
+			}
+
+			renewals := []string{}
+			list := &cmv1.CertificateList{}
+			require.NoError(t, c.List(t.Context(), list))
+			for _, cert := range list.Items {
+				if issuing(&cert) {
+					renewals = append(renewals, cert.Name)
+				}
+			}
+			assert.ElementsMatch(t, tc.expectedRenewals, renewals)
+		})
+	}
+}
+
+func TestSignedBy(t *testing.T) {
+	t.Parallel()
+
+	// prepare
+	ca, caKey, caPEM := testCertificate(t, "ca", nil, nil)
+	_, _, otherPEM := testCertificate(t, "other", nil, nil)
+	_, _, leafPEM := testCertificate(t, "leaf", ca, caKey)
+
+	// validate
+	assert.True(t, signedBy(leafPEM, caPEM))
+	assert.False(t, signedBy(leafPEM, otherPEM))
+	assert.False(t, signedBy(nil, caPEM))
+}
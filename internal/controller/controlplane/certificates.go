@@ -0,0 +1,116 @@
+// Copyright 2025 anza-labs contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controlplane
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	cmv1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmetav1 "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+
+	controlplanev1alpha1 "github.com/anza-labs/kink/api/controlplane/v1alpha1"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// defaultExpirationWarningWindow is the default time before the expiration of a certificate
+// from which the CertificatesExpiringSoon condition is raised.
+const defaultExpirationWarningWindow = 7 * 24 * time.Hour
+
+// certificateStatuses summarizes the Certificates found among the owned objects, sorted by name.
+func certificateStatuses(ownedObjects map[types.UID]client.Object) []controlplanev1alpha1.CertificateStatus {
+	statuses := []controlplanev1alpha1.CertificateStatus{}
+	for _, obj := range ownedObjects {
+		cert, ok := obj.(*cmv1.Certificate)
+		if !ok {
+			continue
+		}
+
+		statuses = append(statuses, controlplanev1alpha1.CertificateStatus{
+			Name:        cert.Name,
+			NotAfter:    cert.Status.NotAfter,
+			RenewalTime: cert.Status.RenewalTime,
+			Ready:       certificateReady(cert) == cmmetav1.ConditionTrue,
+		})
+	}
+	slices.SortFunc(statuses, func(a, b controlplanev1alpha1.CertificateStatus) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return statuses
+}
+
+// expiringCertificates returns the names of the certificates expiring within the window.
+func expiringCertificates(
+	statuses []controlplanev1alpha1.CertificateStatus,
+	window time.Duration,
+	now time.Time,
+) []string {
+	expiring := []string{}
+	for _, status := range statuses {
+		if status.NotAfter != nil && status.NotAfter.Sub(now) < window {
+			expiring = append(expiring, status.Name)
+		}
+	}
+	return expiring
+}
+
+// setCertificatesStatus records the summary of the certificates in the status, and raises the
+// CertificatesExpiringSoon condition when a certificate expires within the warning window.
+func (r *KinkControlPlaneReconciler) setCertificatesStatus(
+	kinkCP *controlplanev1alpha1.KinkControlPlane,
+	ownedObjects map[types.UID]client.Object,
+	now time.Time,
+) {
+	statuses := certificateStatuses(ownedObjects)
+	kinkCP.Status.Certificates = statuses
+
+	var notAfter *metav1.Time
+	for _, status := range statuses {
+		if status.NotAfter != nil && (notAfter == nil || status.NotAfter.Before(notAfter)) {
+			notAfter = status.NotAfter.DeepCopy()
+		}
+	}
+	kinkCP.Status.CertificatesNotAfter = notAfter
+
+	window := defaultExpirationWarningWindow
+	if certs := kinkCP.Spec.Certificates; certs != nil && certs.ExpirationWarningWindow != nil {
+		window = certs.ExpirationWarningWindow.Duration
+	}
+
+	condition := metav1.Condition{
+		Type:               controlplanev1alpha1.CertificatesExpiringSoonCondition,
+		Status:             metav1.ConditionFalse,
+		Reason:             controlplanev1alpha1.CertificatesValidReason,
+		Message:            fmt.Sprintf("No certificate expires within %s", window),
+		ObservedGeneration: kinkCP.Generation,
+	}
+	if expiring := expiringCertificates(statuses, window, now); len(expiring) > 0 {
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = controlplanev1alpha1.CertificatesExpiringReason
+		condition.Message = fmt.Sprintf("Certificates expiring within %s: %s", window, strings.Join(expiring, ", "))
+
+		if !meta.IsStatusConditionTrue(kinkCP.Status.Conditions, condition.Type) {
+			r.Recorder.Event(kinkCP, corev1.EventTypeWarning, "CertificatesExpiringSoon", condition.Message)
+		}
+	}
+	meta.SetStatusCondition(&kinkCP.Status.Conditions, condition)
+}
@@ -0,0 +1,160 @@
+// Copyright 2025 anza-labs contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controlplane
+
+import (
+	"testing"
+	"time"
+
+	cmv1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmetav1 "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	controlplanev1alpha1 "github.com/anza-labs/kink/api/controlplane/v1alpha1"
+
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestSetCertificatesStatus(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+	certificate := func(name string, notAfter time.Time, ready bool) *cmv1.Certificate {
+		status := cmmetav1.ConditionFalse
+		if ready {
+			status = cmmetav1.ConditionTrue
+		}
+		return &cmv1.Certificate{
+			ObjectMeta: metav1.ObjectMeta{Name: name, UID: types.UID(name)},
+			Status: cmv1.CertificateStatus{
+				NotAfter:    &metav1.Time{Time: notAfter},
+				RenewalTime: &metav1.Time{Time: notAfter.Add(-time.Hour)},
+				Conditions: []cmv1.CertificateCondition{
+					{Type: cmv1.CertificateConditionReady, Status: status},
+				},
+			},
+		}
+	}
+	owned := func(objs ...client.Object) map[types.UID]client.Object {
+		m := map[types.UID]client.Object{}
+		for _, obj := range objs {
+			m[obj.GetUID()] = obj
+		}
+		return m
+	}
+
+	for name, tc := range map[string]struct {
+		window           *metav1.Duration
+		ownedObjects     map[types.UID]client.Object
+		expectedNames    []string
+		expectedNotAfter *metav1.Time
+		expectedStatus   metav1.ConditionStatus
+		expectedEvents   int
+	}{
+		"NoCertificates": {
+			ownedObjects:   owned(&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "test", UID: "test"}}),
+			expectedNames:  []string{},
+			expectedStatus: metav1.ConditionFalse,
+		},
+		"Valid": {
+			ownedObjects: owned(
+				certificate("b", now.Add(90*24*time.Hour), true),
+				certificate("a", now.Add(30*24*time.Hour), true),
+			),
+			expectedNames:    []string{"a", "b"},
+			expectedNotAfter: &metav1.Time{Time: now.Add(30 * 24 * time.Hour)},
+			expectedStatus:   metav1.ConditionFalse,
+		},
+		"ExpiringSoon": {
+			ownedObjects: owned(
+				certificate("a", now.Add(90*24*time.Hour), true),
+				certificate("b", now.Add(24*time.Hour), false),
+			),
+			expectedNames:    []string{"a", "b"},
+			expectedNotAfter: &metav1.Time{Time: now.Add(24 * time.Hour)},
+			expectedStatus:   metav1.ConditionTrue,
+			expectedEvents:   1,
+		},
+		"CustomWindow": {
+			window: &metav1.Duration{Duration: 60 * 24 * time.Hour},
+			ownedObjects: owned(
+				certificate("a", now.Add(30*24*time.Hour), true),
+			),
+			expectedNames:    []string{"a"},
+			expectedNotAfter: &metav1.Time{Time: now.Add(30 * 24 * time.Hour)},
+			expectedStatus:   metav1.ConditionTrue,
+			expectedEvents:   1,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			// prepare
+			recorder := record.NewFakeRecorder(10)
+			r := &KinkControlPlaneReconciler{Recorder: recorder}
+			kinkCP := &controlplanev1alpha1.KinkControlPlane{}
+			if tc.window != nil {
+				kinkCP.Spec.Certificates = &controlplanev1alpha1.Certificates{ExpirationWarningWindow: tc.window}
+			}
+
+			// test
+			r.setCertificatesStatus(kinkCP, tc.ownedObjects, now)
+			// A condition that is already true must not emit the event again.
+			r.setCertificatesStatus(kinkCP, tc.ownedObjects, now)
+
+			// validate
+			names := []string{}
+			for _, status := range kinkCP.Status.Certificates {
+				names = append(names, status.Name)
+			}
+			assert.Equal(t, tc.expectedNames, names)
+			assert.Equal(t, tc.expectedNotAfter, kinkCP.Status.CertificatesNotAfter)
+
+			condition := meta.FindStatusCondition(
+				kinkCP.Status.Conditions,
+				controlplanev1alpha1.CertificatesExpiringSoonCondition,
+			)
+			require.NotNil(t, condition)
+			assert.Equal(t, tc.expectedStatus, condition.Status)
+			assert.Len(t, recorder.Events, tc.expectedEvents)
+		})
+	}
+}
+
+func TestCertificateStatuses(t *testing.T) {
+	t.Parallel()
+
+	// prepare
+	cert := &cmv1.Certificate{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", UID: "test"},
+		Status: cmv1.CertificateStatus{
+			Conditions: []cmv1.CertificateCondition{
+				{Type: cmv1.CertificateConditionReady, Status: cmmetav1.ConditionTrue},
+			},
+		},
+	}
+
+	// test
+	actual := certificateStatuses(map[types.UID]client.Object{cert.UID: cert})
+
+	// validate
+	assert.Equal(t, []controlplanev1alpha1.CertificateStatus{{Name: "test", Ready: true}}, actual)
+}
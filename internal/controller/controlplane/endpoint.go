@@ -0,0 +1,460 @@
+// Copyright 2025 anza-labs contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controlplane
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	controlplanev1alpha1 "github.com/anza-labs/kink/api/controlplane/v1alpha1"
+	"github.com/anza-labs/kink/internal/manifests/controlplane"
+	"github.com/anza-labs/kink/internal/manifests/snirouter"
+	"github.com/anza-labs/kink/internal/naming"
+
+	corev1 "k8s.io/api/core/v1"
+	netv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	gatewayapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// errEndpointPending is returned while the address of the endpoint is not assigned yet.
+var errEndpointPending = errors.New("endpoint pending")
+
+// defaultNodeAddressTypes lists the node address types exposing NodePort Services, by preference.
+var defaultNodeAddressTypes = []corev1.NodeAddressType{
+	corev1.NodeExternalIP,
+	corev1.NodeInternalIP,
+	corev1.NodeExternalDNS,
+}
+
+// resolvedEndpoint is the endpoint on which the API server is exposed.
+type resolvedEndpoint struct {
+	host controlplanev1alpha1.HostnameOrIP
+	port int32
+
+	// node is the name of the node whose address is the host, for NodePort Services.
+	node string
+}
+
+// reconcileEndpoint fills the host and the port of the control plane endpoint, when they are not
+// configured, from the status of the object exposing the API server, and reports the progress
+// with the EndpointReady condition.
+func (r *KinkControlPlaneReconciler) reconcileEndpoint(
+	ctx context.Context,
+	kinkCP *controlplanev1alpha1.KinkControlPlane,
+) error {
+	endpoint := &kinkCP.Spec.ControlPlaneEndpoint
+	condition := metav1.Condition{
+		Type:               controlplanev1alpha1.EndpointReadyCondition,
+		ObservedGeneration: kinkCP.Generation,
+	}
+
+	if !nodePortEndpoint(kinkCP) {
+		kinkCP.Status.EndpointNodeName = ""
+	}
+	// unavailableNode is the node of the endpoint which went away, whose address is replaced.
+	unavailableNode := ""
+	if kinkCP.Status.EndpointNodeName != "" && endpoint.Host != "" {
+		available, err := r.endpointNodeAvailable(ctx, kinkCP)
+		if err != nil {
+			return err
+		}
+		if !available {
+			unavailableNode = kinkCP.Status.EndpointNodeName
+			log.FromContext(ctx).Info("Node of the control plane endpoint went away, selecting another address",
+				"node", unavailableNode, "host", endpoint.Host)
+			r.Recorder.Eventf(kinkCP, corev1.EventTypeWarning, "EndpointNodeUnavailable",
+				"The node %s of the endpoint %s is gone or not ready, selecting another address",
+				unavailableNode, endpoint.Host)
+			endpoint.Host = ""
+		}
+	}
+
+	if endpoint.SNIRouter != nil && r.SNIRouterBaseDomain != "" {
+		owner, err := r.sniRouterHostOwner(ctx, kinkCP)
+		if err != nil {
+			return err
+		}
+		if owner != nil && owner.UID != kinkCP.UID {
+			// The host is neither routed to this control plane nor written to its spec, until the
+			// control plane owning it is deleted.
+			condition.Status = metav1.ConditionFalse
+			condition.Reason = controlplanev1alpha1.EndpointConflictReason
+			condition.Message = fmt.Sprintf("The host %s is routed to the control plane %s/%s created earlier",
+				snirouter.ClaimedHost(kinkCP, r.SNIRouterBaseDomain), owner.Namespace, owner.Name)
+			meta.SetStatusCondition(&kinkCP.Status.Conditions, condition)
+			r.Recorder.Event(kinkCP, corev1.EventTypeWarning, "EndpointConflict", condition.Message)
+			return nil
+		}
+	}
+
+	if endpoint.Host == "" || endpoint.Port == 0 {
+		resolved, err := r.resolveEndpoint(ctx, kinkCP)
+		switch {
+		case errors.Is(err, errEndpointPending) && unavailableNode != "":
+			condition.Status = metav1.ConditionFalse
+			condition.Reason = controlplanev1alpha1.EndpointNodeUnavailableReason
+			condition.Message = fmt.Sprintf("The node %s of the endpoint is gone or not ready: %s", unavailableNode, err)
+			meta.SetStatusCondition(&kinkCP.Status.Conditions, condition)
+			return nil
+
+		case errors.Is(err, errEndpointPending) && (endpoint.Host == "" || resolved.port == 0):
+			condition.Status = metav1.ConditionFalse
+			condition.Reason = controlplanev1alpha1.EndpointPendingReason
+			condition.Message = err.Error()
+			meta.SetStatusCondition(&kinkCP.Status.Conditions, condition)
+			return nil
+
+		case errors.Is(err, errEndpointPending):
+			// The host is configured, only the port is needed.
+
+		case err != nil:
+			condition.Status = metav1.ConditionFalse
+			condition.Reason = controlplanev1alpha1.EndpointFailedReason
+			condition.Message = err.Error()
+			meta.SetStatusCondition(&kinkCP.Status.Conditions, condition)
+			return err
+		}
+
+		// The update returns the stored status, discarding the changes of this reconciliation.
+		status := kinkCP.Status.DeepCopy()
+		if endpoint.Host == "" {
+			endpoint.Host = resolved.host
+			status.EndpointNodeName = resolved.node
+		}
+		if endpoint.Port == 0 {
+			endpoint.Port = resolved.port
+		}
+
+		if err := r.Update(ctx, kinkCP); err != nil {
+			return fmt.Errorf("failed to update control plane endpoint: %w", err)
+		}
+		kinkCP.Status = *status
+	}
+
+	condition.Status = metav1.ConditionTrue
+	condition.Reason = controlplanev1alpha1.EndpointProvisionedReason
+	condition.Message = "The API server is exposed at " + naming.PublicAPIServerEndpoint(endpoint.Host, endpoint.Port)
+	if unavailableNode != "" {
+		condition.Reason = controlplanev1alpha1.EndpointMovedReason
+		condition.Message += fmt.Sprintf(", moved from the node %s which is gone or not ready", unavailableNode)
+	}
+	meta.SetStatusCondition(&kinkCP.Status.Conditions, condition)
+	return nil
+}
+
+// resolveEndpoint returns the endpoint on which the API server is exposed. When the address is
+// not assigned yet, the port is returned along with errEndpointPending.
+func (r *KinkControlPlaneReconciler) resolveEndpoint(
+	ctx context.Context,
+	kinkCP *controlplanev1alpha1.KinkControlPlane,
+) (resolvedEndpoint, error) {
+	switch {
+	case kinkCP.Spec.ControlPlaneEndpoint.SNIRouter != nil:
+		if r.SNIRouterBaseDomain == "" {
+			return resolvedEndpoint{}, errors.New("the SNI router is not enabled in the operator")
+		}
+		return resolvedEndpoint{host: snirouter.Host(kinkCP, r.SNIRouterBaseDomain), port: snirouter.Port}, nil
+	case kinkCP.Spec.ControlPlaneEndpoint.Gateway != nil:
+		return r.gatewayEndpoint(ctx, kinkCP)
+	case kinkCP.Spec.ControlPlaneEndpoint.Ingress != nil:
+		return r.ingressEndpoint(ctx, kinkCP)
+	default:
+		return r.serviceEndpoint(ctx, kinkCP)
+	}
+}
+
+// gatewayEndpoint resolves the endpoint from the addresses of the Gateway, which is either the
+// Gateway of the control plane or the first shared Gateway.
+func (r *KinkControlPlaneReconciler) gatewayEndpoint(
+	ctx context.Context,
+	kinkCP *controlplanev1alpha1.KinkControlPlane,
+) (resolvedEndpoint, error) {
+	ref := types.NamespacedName{Name: naming.APIServer(kinkCP.Name), Namespace: kinkCP.Namespace}
+	sectionName := ref.Name
+	if parents := kinkCP.Spec.ControlPlaneEndpoint.Gateway.ParentRefs; len(parents) > 0 {
+		ref.Name = parents[0].Name
+		if parents[0].Namespace != "" {
+			ref.Namespace = parents[0].Namespace
+		}
+		sectionName = parents[0].SectionName
+	}
+
+	gtw := &gatewayapiv1.Gateway{}
+	if err := r.Get(ctx, ref, gtw); apierrors.IsNotFound(err) {
+		return resolvedEndpoint{}, fmt.Errorf("%w: Gateway %s not found", errEndpointPending, ref)
+	} else if err != nil {
+		return resolvedEndpoint{}, fmt.Errorf("failed to get Gateway %s: %w", ref, err)
+	}
+
+	port := controlplane.RoutedPort(kinkCP)
+	for _, listener := range gtw.Spec.Listeners {
+		if string(listener.Name) == sectionName ||
+			(sectionName == "" && listener.Protocol == gatewayapiv1.TLSProtocolType) {
+			port = int32(listener.Port)
+			break
+		}
+	}
+
+	if len(gtw.Status.Addresses) == 0 || gtw.Status.Addresses[0].Value == "" {
+		return resolvedEndpoint{port: port}, fmt.Errorf("%w: Gateway %s has no address", errEndpointPending, ref)
+	}
+	host := controlplanev1alpha1.HostnameOrIP(gtw.Status.Addresses[0].Value)
+	return resolvedEndpoint{host: host, port: port}, nil
+}
+
+// ingressEndpoint resolves the endpoint from the load balancer of the Ingress.
+func (r *KinkControlPlaneReconciler) ingressEndpoint(
+	ctx context.Context,
+	kinkCP *controlplanev1alpha1.KinkControlPlane,
+) (resolvedEndpoint, error) {
+	ref := types.NamespacedName{Name: naming.APIServer(kinkCP.Name), Namespace: kinkCP.Namespace}
+	port := controlplane.RoutedPort(kinkCP)
+
+	ing := &netv1.Ingress{}
+	if err := r.Get(ctx, ref, ing); apierrors.IsNotFound(err) {
+		return resolvedEndpoint{port: port}, fmt.Errorf("%w: Ingress %s not found", errEndpointPending, ref)
+	} else if err != nil {
+		return resolvedEndpoint{}, fmt.Errorf("failed to get Ingress %s: %w", ref, err)
+	}
+
+	for _, lb := range ing.Status.LoadBalancer.Ingress {
+		if host := cmp.Or(lb.IP, lb.Hostname); host != "" {
+			return resolvedEndpoint{host: controlplanev1alpha1.HostnameOrIP(host), port: port}, nil
+		}
+	}
+	return resolvedEndpoint{port: port}, fmt.Errorf("%w: Ingress %s has no address", errEndpointPending, ref)
+}
+
+// serviceEndpoint resolves the endpoint from the NodePort or LoadBalancer Service of the API server.
+func (r *KinkControlPlaneReconciler) serviceEndpoint(
+	ctx context.Context,
+	kinkCP *controlplanev1alpha1.KinkControlPlane,
+) (resolvedEndpoint, error) {
+	svc := &corev1.Service{}
+	err := r.Get(ctx, types.NamespacedName{
+		Name:      naming.APIServer(kinkCP.Name),
+		Namespace: kinkCP.Namespace,
+	}, svc)
+	if apierrors.IsNotFound(err) {
+		return resolvedEndpoint{}, fmt.Errorf("%w: API server service not found", errEndpointPending)
+	} else if err != nil {
+		return resolvedEndpoint{}, fmt.Errorf("failed to get API server service: %w", err)
+	}
+
+	if len(svc.Spec.Ports) == 0 {
+		return resolvedEndpoint{}, fmt.Errorf("API server service has no ports")
+	}
+
+	switch svc.Spec.Type {
+	case corev1.ServiceTypeNodePort:
+		return r.nodeEndpoint(ctx, kinkCP, svc.Spec.Ports[0].NodePort)
+
+	case corev1.ServiceTypeLoadBalancer:
+		port := svc.Spec.Ports[0].Port
+		// Some cloud load balancers, e.g. AWS ELB, are assigned a hostname instead of an IP.
+		for _, lb := range svc.Status.LoadBalancer.Ingress {
+			if host := cmp.Or(lb.IP, lb.Hostname); host != "" {
+				return resolvedEndpoint{host: controlplanev1alpha1.HostnameOrIP(host), port: port}, nil
+			}
+		}
+		return resolvedEndpoint{port: port}, fmt.Errorf("%w: LoadBalancer service has no address", errEndpointPending)
+
+	default:
+		return resolvedEndpoint{}, fmt.Errorf("unsupported service type: %s", svc.Spec.Type)
+	}
+}
+
+// nodePortEndpoint reports whether the endpoint is the address of a node exposing the NodePort Service.
+func nodePortEndpoint(kinkCP *controlplanev1alpha1.KinkControlPlane) bool {
+	endpoint := kinkCP.Spec.ControlPlaneEndpoint
+	return endpoint.Gateway == nil && endpoint.Ingress == nil && endpoint.SNIRouter == nil &&
+		endpoint.ServiceType == corev1.ServiceTypeNodePort
+}
+
+// nodeEndpoint selects the address of a ready node exposing the NodePort, following the
+// preference of the address types, and then the name of the nodes.
+func (r *KinkControlPlaneReconciler) nodeEndpoint(
+	ctx context.Context,
+	kinkCP *controlplanev1alpha1.KinkControlPlane,
+	port int32,
+) (resolvedEndpoint, error) {
+	nodes, err := r.endpointNodes(ctx, kinkCP)
+	if err != nil {
+		return resolvedEndpoint{}, err
+	}
+
+	addressTypes := defaultNodeAddressTypes
+	if np := kinkCP.Spec.ControlPlaneEndpoint.NodePort; np != nil && len(np.AddressTypes) > 0 {
+		addressTypes = np.AddressTypes
+	}
+
+	for _, addressType := range addressTypes {
+		for _, node := range nodes {
+			if !nodeReady(&node) {
+				continue
+			}
+			if address := nodeAddress(&node, addressType); address != "" {
+				return resolvedEndpoint{
+					host: controlplanev1alpha1.HostnameOrIP(address),
+					port: port,
+					node: node.Name,
+				}, nil
+			}
+		}
+	}
+	return resolvedEndpoint{port: port}, fmt.Errorf("%w: no ready node with an address of type %v found",
+		errEndpointPending, addressTypes)
+}
+
+// endpointNodeAvailable reports whether the node whose address was selected still exists, is
+// selected, is ready and still has the address, as required by nodeEndpoint.
+func (r *KinkControlPlaneReconciler) endpointNodeAvailable(
+	ctx context.Context,
+	kinkCP *controlplanev1alpha1.KinkControlPlane,
+) (bool, error) {
+	nodes, err := r.endpointNodes(ctx, kinkCP)
+	if err != nil {
+		return false, err
+	}
+
+	for _, node := range nodes {
+		if node.Name != kinkCP.Status.EndpointNodeName || !nodeReady(&node) {
+			continue
+		}
+		for _, addr := range node.Status.Addresses {
+			if addr.Address == string(kinkCP.Spec.ControlPlaneEndpoint.Host) {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+// endpointNodes lists the nodes selected by the node selector, sorted by name.
+func (r *KinkControlPlaneReconciler) endpointNodes(
+	ctx context.Context,
+	kinkCP *controlplanev1alpha1.KinkControlPlane,
+) ([]corev1.Node, error) {
+	opts := []client.ListOption{}
+	if np := kinkCP.Spec.ControlPlaneEndpoint.NodePort; np != nil && np.NodeSelector != nil {
+		selector, err := metav1.LabelSelectorAsSelector(np.NodeSelector)
+		if err != nil {
+			return nil, fmt.Errorf("invalid node selector: %w", err)
+		}
+		opts = append(opts, client.MatchingLabelsSelector{Selector: selector})
+	}
+
+	nodes := &corev1.NodeList{}
+	if err := r.List(ctx, nodes, opts...); err != nil {
+		return nil, fmt.Errorf("failed to list nodes: %w", err)
+	}
+	slices.SortFunc(nodes.Items, func(a, b corev1.Node) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return nodes.Items, nil
+}
+
+// nodeReady reports whether the node is ready.
+func nodeReady(node *corev1.Node) bool {
+	for _, cond := range node.Status.Conditions {
+		if cond.Type == corev1.NodeReady {
+			return cond.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// nodeAddress returns the first address of the given type of the node.
+func nodeAddress(node *corev1.Node, addressType corev1.NodeAddressType) string {
+	for _, addr := range node.Status.Addresses {
+		if addr.Type == addressType {
+			return addr.Address
+		}
+	}
+	return ""
+}
+
+// sniRouterHostOwner returns the control plane to which the SNI router routes the host claimed by
+// the control plane, nil when it is not routed yet.
+func (r *KinkControlPlaneReconciler) sniRouterHostOwner(
+	ctx context.Context,
+	kinkCP *controlplanev1alpha1.KinkControlPlane,
+) (*controlplanev1alpha1.KinkControlPlane, error) {
+	kinkCPs := &controlplanev1alpha1.KinkControlPlaneList{}
+	if err := r.List(ctx, kinkCPs); err != nil {
+		return nil, fmt.Errorf("failed to list KinkControlPlanes: %w", err)
+	}
+	host := snirouter.ClaimedHost(kinkCP, r.SNIRouterBaseDomain)
+	return snirouter.HostOwners(kinkCPs.Items, r.SNIRouterBaseDomain)[host], nil
+}
+
+// sniRouterHostToControlPlanes maps a deleted control plane to the control planes claiming its
+// host through the SNI router, one of which takes it over.
+func (r *KinkControlPlaneReconciler) sniRouterHostToControlPlanes(
+	ctx context.Context,
+	obj client.Object,
+) []reconcile.Request {
+	deleted, ok := obj.(*controlplanev1alpha1.KinkControlPlane)
+	if !ok || deleted.Spec.ControlPlaneEndpoint.SNIRouter == nil {
+		return nil
+	}
+
+	kinkCPs := &controlplanev1alpha1.KinkControlPlaneList{}
+	if err := r.List(ctx, kinkCPs); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to list KinkControlPlanes")
+		return nil
+	}
+
+	host := snirouter.ClaimedHost(deleted, r.SNIRouterBaseDomain)
+	requests := []reconcile.Request{}
+	for _, kinkCP := range kinkCPs.Items {
+		if kinkCP.UID != deleted.UID && kinkCP.Spec.ControlPlaneEndpoint.SNIRouter != nil &&
+			snirouter.ClaimedHost(&kinkCP, r.SNIRouterBaseDomain) == host {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&kinkCP)})
+		}
+	}
+	return requests
+}
+
+// endpointNodeToControlPlanes maps a node to the control planes whose endpoint is the address of it.
+func (r *KinkControlPlaneReconciler) endpointNodeToControlPlanes(
+	ctx context.Context,
+	obj client.Object,
+) []reconcile.Request {
+	kinkCPs := &controlplanev1alpha1.KinkControlPlaneList{}
+	if err := r.List(ctx, kinkCPs); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to list KinkControlPlanes")
+		return nil
+	}
+
+	requests := []reconcile.Request{}
+	for _, kinkCP := range kinkCPs.Items {
+		if kinkCP.Status.EndpointNodeName == obj.GetName() {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&kinkCP)})
+		}
+	}
+	return requests
+}
@@ -0,0 +1,380 @@
+// Copyright 2025 anza-labs contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controlplane
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	controlplanev1alpha1 "github.com/anza-labs/kink/api/controlplane/v1alpha1"
+
+	corev1 "k8s.io/api/core/v1"
+	netv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gatewayapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+func TestReconcileEndpoint(t *testing.T) {
+	t.Parallel()
+
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(gatewayapiv1.Install(scheme))
+	utilruntime.Must(controlplanev1alpha1.AddToScheme(scheme))
+
+	gateway := func(name, namespace string, addresses ...string) *gatewayapiv1.Gateway {
+		gtw := &gatewayapiv1.Gateway{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Spec: gatewayapiv1.GatewaySpec{
+				Listeners: []gatewayapiv1.Listener{
+					{Name: "https", Port: 443, Protocol: gatewayapiv1.HTTPSProtocolType},
+					{Name: "tls", Port: 8443, Protocol: gatewayapiv1.TLSProtocolType},
+				},
+			},
+		}
+		for _, address := range addresses {
+			gtw.Status.Addresses = append(gtw.Status.Addresses, gatewayapiv1.GatewayStatusAddress{Value: address})
+		}
+		return gtw
+	}
+	ingress := func(lb ...netv1.IngressLoadBalancerIngress) *netv1.Ingress {
+		return &netv1.Ingress{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-api-server", Namespace: "default"},
+			Status: netv1.IngressStatus{
+				LoadBalancer: netv1.IngressLoadBalancerStatus{Ingress: lb},
+			},
+		}
+	}
+	service := func(serviceType corev1.ServiceType, lb ...corev1.LoadBalancerIngress) *corev1.Service {
+		return &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-api-server", Namespace: "default"},
+			Spec: corev1.ServiceSpec{
+				Type:  serviceType,
+				Ports: []corev1.ServicePort{{Port: 6443, NodePort: 30443}},
+			},
+			Status: corev1.ServiceStatus{
+				LoadBalancer: corev1.LoadBalancerStatus{Ingress: lb},
+			},
+		}
+	}
+	node := func(name string, ready bool, labels map[string]string, addresses ...corev1.NodeAddress) *corev1.Node {
+		status := corev1.ConditionFalse
+		if ready {
+			status = corev1.ConditionTrue
+		}
+		return &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels},
+			Status: corev1.NodeStatus{
+				Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: status}},
+				Addresses:  addresses,
+			},
+		}
+	}
+	internalIP := func(address string) corev1.NodeAddress {
+		return corev1.NodeAddress{Type: corev1.NodeInternalIP, Address: address}
+	}
+	externalIP := func(address string) corev1.NodeAddress {
+		return corev1.NodeAddress{Type: corev1.NodeExternalIP, Address: address}
+	}
+	nodePort := controlplanev1alpha1.APIEndpoint{ServiceType: corev1.ServiceTypeNodePort}
+
+	for name, tc := range map[string]struct {
+		endpoint       controlplanev1alpha1.APIEndpoint
+		objects        []client.Object
+		expectedError  bool
+		expectedHost   controlplanev1alpha1.HostnameOrIP
+		expectedPort   int32
+		expectedNode   string
+		expectedReason string
+		endpointNode   string
+	}{
+		"Configured": {
+			endpoint:       controlplanev1alpha1.APIEndpoint{Host: "test.example.com", Port: 443},
+			expectedHost:   "test.example.com",
+			expectedPort:   443,
+			expectedReason: controlplanev1alpha1.EndpointProvisionedReason,
+		},
+		"Gateway": {
+			endpoint: controlplanev1alpha1.APIEndpoint{
+				Gateway: &controlplanev1alpha1.Gateway{GatewayClassName: "test"},
+			},
+			objects:        []client.Object{gateway("test-api-server", "default", "192.0.2.1")},
+			expectedHost:   "192.0.2.1",
+			expectedPort:   443,
+			expectedReason: controlplanev1alpha1.EndpointProvisionedReason,
+		},
+		"GatewayPending": {
+			endpoint: controlplanev1alpha1.APIEndpoint{
+				Gateway: &controlplanev1alpha1.Gateway{GatewayClassName: "test"},
+			},
+			objects:        []client.Object{gateway("test-api-server", "default")},
+			expectedReason: controlplanev1alpha1.EndpointPendingReason,
+		},
+		"GatewayPendingWithHost": {
+			endpoint: controlplanev1alpha1.APIEndpoint{
+				Host:    "test.example.com",
+				Gateway: &controlplanev1alpha1.Gateway{GatewayClassName: "test"},
+			},
+			objects:        []client.Object{gateway("test-api-server", "default")},
+			expectedHost:   "test.example.com",
+			expectedPort:   443,
+			expectedReason: controlplanev1alpha1.EndpointProvisionedReason,
+		},
+		"SharedGateway": {
+			endpoint: controlplanev1alpha1.APIEndpoint{
+				Gateway: &controlplanev1alpha1.Gateway{
+					ParentRefs: []controlplanev1alpha1.GatewayParentReference{
+						{Name: "shared", Namespace: "gateways"},
+					},
+				},
+			},
+			objects:        []client.Object{gateway("shared", "gateways", "gateway.example.com")},
+			expectedHost:   "gateway.example.com",
+			expectedPort:   8443,
+			expectedReason: controlplanev1alpha1.EndpointProvisionedReason,
+		},
+		"SharedGatewayNotFound": {
+			endpoint: controlplanev1alpha1.APIEndpoint{
+				Gateway: &controlplanev1alpha1.Gateway{
+					ParentRefs: []controlplanev1alpha1.GatewayParentReference{{Name: "shared"}},
+				},
+			},
+			expectedReason: controlplanev1alpha1.EndpointPendingReason,
+		},
+		"Ingress": {
+			endpoint: controlplanev1alpha1.APIEndpoint{
+				Ingress: &controlplanev1alpha1.Ingress{IngressClassName: "test"},
+			},
+			objects:        []client.Object{ingress(netv1.IngressLoadBalancerIngress{Hostname: "lb.example.com"})},
+			expectedHost:   "lb.example.com",
+			expectedPort:   443,
+			expectedReason: controlplanev1alpha1.EndpointProvisionedReason,
+		},
+		"IngressConfiguredPort": {
+			endpoint: controlplanev1alpha1.APIEndpoint{
+				Port:    8443,
+				Ingress: &controlplanev1alpha1.Ingress{IngressClassName: "test"},
+			},
+			objects:        []client.Object{ingress(netv1.IngressLoadBalancerIngress{IP: "192.0.2.1"})},
+			expectedHost:   "192.0.2.1",
+			expectedPort:   8443,
+			expectedReason: controlplanev1alpha1.EndpointProvisionedReason,
+		},
+		"IngressPending": {
+			endpoint: controlplanev1alpha1.APIEndpoint{
+				Ingress: &controlplanev1alpha1.Ingress{IngressClassName: "test"},
+			},
+			objects:        []client.Object{ingress()},
+			expectedReason: controlplanev1alpha1.EndpointPendingReason,
+		},
+		"SNIRouter": {
+			endpoint: controlplanev1alpha1.APIEndpoint{
+				SNIRouter: &controlplanev1alpha1.SNIRouter{},
+			},
+			expectedHost:   "test-default.example.com",
+			expectedPort:   443,
+			expectedReason: controlplanev1alpha1.EndpointProvisionedReason,
+		},
+		"SNIRouterHostConflict": {
+			endpoint: controlplanev1alpha1.APIEndpoint{
+				SNIRouter: &controlplanev1alpha1.SNIRouter{Subdomain: "prod"},
+			},
+			objects: []client.Object{&controlplanev1alpha1.KinkControlPlane{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              "prod",
+					Namespace:         "other",
+					UID:               "prod",
+					CreationTimestamp: metav1.NewTime(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)),
+				},
+				Spec: controlplanev1alpha1.KinkControlPlaneSpec{
+					ControlPlaneEndpoint: controlplanev1alpha1.APIEndpoint{
+						SNIRouter: &controlplanev1alpha1.SNIRouter{Subdomain: "prod"},
+					},
+				},
+			}},
+			expectedReason: controlplanev1alpha1.EndpointConflictReason,
+		},
+		"LoadBalancerHostname": {
+			endpoint: controlplanev1alpha1.APIEndpoint{ServiceType: corev1.ServiceTypeLoadBalancer},
+			objects: []client.Object{
+				service(corev1.ServiceTypeLoadBalancer, corev1.LoadBalancerIngress{Hostname: "elb.example.com"}),
+			},
+			expectedHost:   "elb.example.com",
+			expectedPort:   6443,
+			expectedReason: controlplanev1alpha1.EndpointProvisionedReason,
+		},
+		"NodePortPreference": {
+			endpoint: nodePort,
+			objects: []client.Object{
+				service(corev1.ServiceTypeNodePort),
+				node("a", true, nil, internalIP("10.0.0.1")),
+				node("b", true, nil, internalIP("10.0.0.2"), externalIP("192.0.2.2")),
+				node("c", false, nil, externalIP("192.0.2.3")),
+			},
+			expectedHost:   "192.0.2.2",
+			expectedPort:   30443,
+			expectedNode:   "b",
+			expectedReason: controlplanev1alpha1.EndpointProvisionedReason,
+		},
+		"NodePortInternalIP": {
+			endpoint: nodePort,
+			objects: []client.Object{
+				service(corev1.ServiceTypeNodePort),
+				node("b", true, nil, internalIP("10.0.0.2")),
+				node("a", true, nil, internalIP("10.0.0.1")),
+			},
+			expectedHost:   "10.0.0.1",
+			expectedPort:   30443,
+			expectedNode:   "a",
+			expectedReason: controlplanev1alpha1.EndpointProvisionedReason,
+		},
+		"NodePortSelector": {
+			endpoint: controlplanev1alpha1.APIEndpoint{
+				ServiceType: corev1.ServiceTypeNodePort,
+				NodePort: &controlplanev1alpha1.NodePortEndpoint{
+					AddressTypes: []corev1.NodeAddressType{corev1.NodeInternalIP},
+					NodeSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"edge": "true"}},
+				},
+			},
+			objects: []client.Object{
+				service(corev1.ServiceTypeNodePort),
+				node("a", true, nil, internalIP("10.0.0.1"), externalIP("192.0.2.1")),
+				node("b", true, map[string]string{"edge": "true"}, internalIP("10.0.0.2"), externalIP("192.0.2.2")),
+			},
+			expectedHost:   "10.0.0.2",
+			expectedPort:   30443,
+			expectedNode:   "b",
+			expectedReason: controlplanev1alpha1.EndpointProvisionedReason,
+		},
+		"NodePortPending": {
+			endpoint: nodePort,
+			objects: []client.Object{
+				service(corev1.ServiceTypeNodePort),
+				node("a", false, nil, internalIP("10.0.0.1")),
+			},
+			expectedReason: controlplanev1alpha1.EndpointPendingReason,
+		},
+		"NodePortNodeAvailable": {
+			endpoint: controlplanev1alpha1.APIEndpoint{ServiceType: corev1.ServiceTypeNodePort, Host: "10.0.0.2", Port: 30443},
+			objects: []client.Object{
+				service(corev1.ServiceTypeNodePort),
+				node("a", true, nil, internalIP("10.0.0.1")),
+				node("b", true, nil, internalIP("10.0.0.2")),
+			},
+			endpointNode:   "b",
+			expectedHost:   "10.0.0.2",
+			expectedPort:   30443,
+			expectedNode:   "b",
+			expectedReason: controlplanev1alpha1.EndpointProvisionedReason,
+		},
+		"NodePortNodeWentAway": {
+			endpoint: controlplanev1alpha1.APIEndpoint{ServiceType: corev1.ServiceTypeNodePort, Host: "10.0.0.2", Port: 30443},
+			objects: []client.Object{
+				service(corev1.ServiceTypeNodePort),
+				node("a", true, nil, internalIP("10.0.0.1")),
+			},
+			endpointNode:   "b",
+			expectedHost:   "10.0.0.1",
+			expectedPort:   30443,
+			expectedNode:   "a",
+			expectedReason: controlplanev1alpha1.EndpointMovedReason,
+		},
+		"NodePortNodeNotReady": {
+			endpoint: controlplanev1alpha1.APIEndpoint{ServiceType: corev1.ServiceTypeNodePort, Host: "10.0.0.1", Port: 30443},
+			objects: []client.Object{
+				service(corev1.ServiceTypeNodePort),
+				node("a", false, nil, internalIP("10.0.0.1")),
+				node("b", true, nil, internalIP("10.0.0.2")),
+			},
+			endpointNode:   "a",
+			expectedHost:   "10.0.0.2",
+			expectedPort:   30443,
+			expectedNode:   "b",
+			expectedReason: controlplanev1alpha1.EndpointMovedReason,
+		},
+		"NodePortNoNodeAvailable": {
+			endpoint: controlplanev1alpha1.APIEndpoint{ServiceType: corev1.ServiceTypeNodePort, Host: "10.0.0.2", Port: 30443},
+			objects: []client.Object{
+				service(corev1.ServiceTypeNodePort),
+				node("a", false, nil, internalIP("10.0.0.1")),
+			},
+			endpointNode:   "b",
+			expectedPort:   30443,
+			expectedNode:   "b",
+			expectedReason: controlplanev1alpha1.EndpointNodeUnavailableReason,
+		},
+		"LoadBalancerPending": {
+			endpoint:       controlplanev1alpha1.APIEndpoint{ServiceType: corev1.ServiceTypeLoadBalancer},
+			objects:        []client.Object{service(corev1.ServiceTypeLoadBalancer)},
+			expectedReason: controlplanev1alpha1.EndpointPendingReason,
+		},
+		"UnsupportedServiceType": {
+			endpoint:       controlplanev1alpha1.APIEndpoint{ServiceType: corev1.ServiceTypeClusterIP},
+			objects:        []client.Object{service(corev1.ServiceTypeClusterIP)},
+			expectedError:  true,
+			expectedReason: controlplanev1alpha1.EndpointFailedReason,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			// prepare
+			kinkCP := &controlplanev1alpha1.KinkControlPlane{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              "test",
+					Namespace:         "default",
+					UID:               "test",
+					CreationTimestamp: metav1.NewTime(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)),
+				},
+				Spec:   controlplanev1alpha1.KinkControlPlaneSpec{ControlPlaneEndpoint: tc.endpoint},
+				Status: controlplanev1alpha1.KinkControlPlaneStatus{EndpointNodeName: tc.endpointNode},
+			}
+			c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(tc.objects...).Build()
+			require.NoError(t, c.Create(t.Context(), kinkCP))
+			r := &KinkControlPlaneReconciler{
+				Client:              c,
+				Scheme:              scheme,
+				Recorder:            record.NewFakeRecorder(10),
+				SNIRouterBaseDomain: "example.com",
+			}
+
+			// test
+			err := r.reconcileEndpoint(t.Context(), kinkCP)
+
+			// validate
+			if tc.expectedError {
+				assert.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tc.expectedHost, kinkCP.Spec.ControlPlaneEndpoint.Host)
+			assert.Equal(t, tc.expectedPort, kinkCP.Spec.ControlPlaneEndpoint.Port)
+			assert.Equal(t, tc.expectedNode, kinkCP.Status.EndpointNodeName)
+			condition := meta.FindStatusCondition(kinkCP.Status.Conditions, controlplanev1alpha1.EndpointReadyCondition)
+			require.NotNil(t, condition)
+			assert.Equal(t, tc.expectedReason, condition.Reason)
+		})
+	}
+}
@@ -0,0 +1,120 @@
+// Copyright 2025 anza-labs contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controlplane
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/robfig/cron/v3"
+
+	controlplanev1alpha1 "github.com/anza-labs/kink/api/controlplane/v1alpha1"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// hibernationState reports whether the control plane should be hibernated at the given time,
+// the reason for it and the duration after which the state may change next. A zero duration
+// means that no change is scheduled.
+func hibernationState(
+	hibernation *controlplanev1alpha1.Hibernation,
+	now time.Time,
+) (bool, string, time.Duration, error) {
+	if hibernation == nil {
+		return false, controlplanev1alpha1.AwakeReason, 0, nil
+	}
+
+	hibernated := false
+	reason := controlplanev1alpha1.AwakeReason
+	if hibernation.Enabled {
+		hibernated = true
+		reason = controlplanev1alpha1.HibernationEnabledReason
+	}
+
+	var next time.Duration
+	for i, schedule := range hibernation.Schedules {
+		loc := time.UTC
+		if schedule.Location != "" {
+			l, err := time.LoadLocation(schedule.Location)
+			if err != nil {
+				return false, controlplanev1alpha1.HibernationInvalidScheduleReason, 0,
+					fmt.Errorf("invalid location in schedule %d: %w", i, err)
+			}
+			loc = l
+		}
+
+		start, err := cron.ParseStandard(schedule.Start)
+		if err != nil {
+			return false, controlplanev1alpha1.HibernationInvalidScheduleReason, 0,
+				fmt.Errorf("invalid start in schedule %d: %w", i, err)
+		}
+		end, err := cron.ParseStandard(schedule.End)
+		if err != nil {
+			return false, controlplanev1alpha1.HibernationInvalidScheduleReason, 0,
+				fmt.Errorf("invalid end in schedule %d: %w", i, err)
+		}
+
+		local := now.In(loc)
+		nextStart := start.Next(local)
+		nextEnd := end.Next(local)
+
+		// The window is active when the control plane is due to wake up before it is due
+		// to be hibernated again.
+		if nextEnd.Before(nextStart) && !hibernated {
+			hibernated = true
+			reason = controlplanev1alpha1.HibernationScheduledReason
+		}
+
+		for _, t := range []time.Time{nextStart, nextEnd} {
+			if d := t.Sub(local); d > 0 && (next == 0 || d < next) {
+				next = d
+			}
+		}
+	}
+
+	return hibernated, reason, next, nil
+}
+
+// setHibernationStatus records the hibernation state in the status and emits an Event on change.
+func (r *KinkControlPlaneReconciler) setHibernationStatus(
+	kinkCP *controlplanev1alpha1.KinkControlPlane,
+	hibernated bool,
+	reason, message string,
+) {
+	if kinkCP.Status.Hibernated != hibernated {
+		if hibernated {
+			r.Recorder.Event(kinkCP, corev1.EventTypeNormal, "Hibernating",
+				"Scaling control plane components to zero")
+		} else {
+			r.Recorder.Event(kinkCP, corev1.EventTypeNormal, "WakingUp",
+				"Restoring control plane component replicas")
+		}
+	}
+	kinkCP.Status.Hibernated = hibernated
+
+	status := metav1.ConditionFalse
+	if hibernated {
+		status = metav1.ConditionTrue
+	}
+	meta.SetStatusCondition(&kinkCP.Status.Conditions, metav1.Condition{
+		Type:               controlplanev1alpha1.HibernatedCondition,
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: kinkCP.Generation,
+	})
+}
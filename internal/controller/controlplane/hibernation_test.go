@@ -0,0 +1,118 @@
+// Copyright 2025 anza-labs contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controlplane
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	controlplanev1alpha1 "github.com/anza-labs/kink/api/controlplane/v1alpha1"
+)
+
+func TestHibernationState(t *testing.T) {
+	t.Parallel()
+
+	// Wednesday
+	now := time.Date(2025, 1, 1, 22, 0, 0, 0, time.UTC)
+
+	nightly := controlplanev1alpha1.HibernationSchedule{
+		Start: "0 20 * * *",
+		End:   "0 7 * * *",
+	}
+
+	for name, tc := range map[string]struct {
+		hibernation       *controlplanev1alpha1.Hibernation
+		expected          bool
+		expectedReason    string
+		expectedRequeue   time.Duration
+		expectedErrSubstr string
+	}{
+		"nil": {
+			hibernation:    nil,
+			expected:       false,
+			expectedReason: controlplanev1alpha1.AwakeReason,
+		},
+		"enabled": {
+			hibernation:    &controlplanev1alpha1.Hibernation{Enabled: true},
+			expected:       true,
+			expectedReason: controlplanev1alpha1.HibernationEnabledReason,
+		},
+		"inside_window": {
+			hibernation: &controlplanev1alpha1.Hibernation{
+				Schedules: []controlplanev1alpha1.HibernationSchedule{nightly},
+			},
+			expected:        true,
+			expectedReason:  controlplanev1alpha1.HibernationScheduledReason,
+			expectedRequeue: 9 * time.Hour,
+		},
+		"outside_window": {
+			hibernation: &controlplanev1alpha1.Hibernation{
+				Schedules: []controlplanev1alpha1.HibernationSchedule{
+					{Start: "0 23 * * *", End: "0 7 * * *"},
+				},
+			},
+			expected:        false,
+			expectedReason:  controlplanev1alpha1.AwakeReason,
+			expectedRequeue: time.Hour,
+		},
+		"location": {
+			hibernation: &controlplanev1alpha1.Hibernation{
+				Schedules: []controlplanev1alpha1.HibernationSchedule{
+					{Start: "0 23 * * *", End: "0 7 * * *", Location: "Europe/Warsaw"},
+				},
+			},
+			expected:        true,
+			expectedReason:  controlplanev1alpha1.HibernationScheduledReason,
+			expectedRequeue: 8 * time.Hour,
+		},
+		"invalid_cron": {
+			hibernation: &controlplanev1alpha1.Hibernation{
+				Schedules: []controlplanev1alpha1.HibernationSchedule{
+					{Start: "invalid", End: "0 7 * * *"},
+				},
+			},
+			expectedReason:    controlplanev1alpha1.HibernationInvalidScheduleReason,
+			expectedErrSubstr: "invalid start in schedule 0",
+		},
+		"invalid_location": {
+			hibernation: &controlplanev1alpha1.Hibernation{
+				Schedules: []controlplanev1alpha1.HibernationSchedule{
+					{Start: "0 20 * * *", End: "0 7 * * *", Location: "Nowhere/Invalid"},
+				},
+			},
+			expectedReason:    controlplanev1alpha1.HibernationInvalidScheduleReason,
+			expectedErrSubstr: "invalid location in schedule 0",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			// test
+			actual, reason, requeue, err := hibernationState(tc.hibernation, now)
+
+			// validate
+			if tc.expectedErrSubstr != "" {
+				assert.ErrorContains(t, err, tc.expectedErrSubstr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expected, actual)
+				assert.Equal(t, tc.expectedRequeue, requeue)
+			}
+			assert.Equal(t, tc.expectedReason, reason)
+		})
+	}
+}
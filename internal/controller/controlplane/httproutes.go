@@ -0,0 +1,55 @@
+// Copyright 2025 anza-labs contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controlplane
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	controlplanev1alpha1 "github.com/anza-labs/kink/api/controlplane/v1alpha1"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	gatewayapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// cleanupHTTPRoutes deletes the HTTPRoutes which exposed the API servers before they were replaced
+// by TLSRoutes. HTTPRoutes are no longer owned types, so they are not pruned when reconciling.
+func cleanupHTTPRoutes(ctx context.Context, reader client.Reader, c client.Client) error {
+	log := log.FromContext(ctx)
+
+	routes := &gatewayapiv1.HTTPRouteList{}
+	if err := reader.List(ctx, routes); err != nil {
+		return fmt.Errorf("failed to list HTTPRoutes: %w", err)
+	}
+
+	var errs error
+	for i := range routes.Items {
+		route := &routes.Items[i]
+		owner := metav1.GetControllerOf(route)
+		if owner == nil || owner.Kind != "KinkControlPlane" ||
+			owner.APIVersion != controlplanev1alpha1.GroupVersion.String() {
+			continue
+		}
+
+		log.Info("Deleting HTTPRoute replaced by a TLSRoute", "namespace", route.Namespace, "name", route.Name)
+		if err := c.Delete(ctx, route); client.IgnoreNotFound(err) != nil {
+			errs = errors.Join(errs, fmt.Errorf("failed to delete HTTPRoute %s/%s: %w", route.Namespace, route.Name, err))
+		}
+	}
+	return errs
+}
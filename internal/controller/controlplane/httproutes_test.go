@@ -0,0 +1,69 @@
+// Copyright 2025 anza-labs contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controlplane
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	controlplanev1alpha1 "github.com/anza-labs/kink/api/controlplane/v1alpha1"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gatewayapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+func TestCleanupHTTPRoutes(t *testing.T) {
+	t.Parallel()
+
+	// prepare
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(gatewayapiv1.Install(scheme))
+
+	route := func(name string, owners ...metav1.OwnerReference) *gatewayapiv1.HTTPRoute {
+		return &gatewayapiv1.HTTPRoute{ObjectMeta: metav1.ObjectMeta{
+			Name:            name,
+			Namespace:       "default",
+			OwnerReferences: owners,
+		}}
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		route("test-api-server", metav1.OwnerReference{
+			APIVersion: controlplanev1alpha1.GroupVersion.String(),
+			Kind:       "KinkControlPlane",
+			Name:       "test",
+			UID:        "test",
+			Controller: ptr.To(true),
+		}),
+		route("unrelated"),
+	).Build()
+
+	// test
+	err := cleanupHTTPRoutes(t.Context(), c, c)
+
+	// validate
+	require.NoError(t, err)
+	routes := &gatewayapiv1.HTTPRouteList{}
+	require.NoError(t, c.List(t.Context(), routes))
+	require.Len(t, routes.Items, 1)
+	assert.Equal(t, "unrelated", routes.Items[0].Name)
+}
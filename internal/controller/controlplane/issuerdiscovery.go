@@ -0,0 +1,208 @@
+// Copyright 2025 anza-labs contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controlplane
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"sync"
+	"time"
+
+	controlplanev1alpha1 "github.com/anza-labs/kink/api/controlplane/v1alpha1"
+	"github.com/anza-labs/kink/internal/controller/util"
+	"github.com/anza-labs/kink/internal/manifests/controlplane"
+	"github.com/anza-labs/kink/internal/naming"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// workloadClientTimeout is the timeout of the requests sent to the workload cluster.
+const workloadClientTimeout = 10 * time.Second
+
+// workloadClientFunc returns a client of the workload cluster of the control plane.
+type workloadClientFunc func(
+	ctx context.Context,
+	kinkCP *controlplanev1alpha1.KinkControlPlane,
+) (client.Client, error)
+
+// workloadClientCache holds the clients of the workload clusters, so that the discovery of their
+// REST mappings is not repeated on every reconciliation. A client is replaced when the
+// credentials or the trust bundle it was created with change, e.g. after a rotation.
+type workloadClientCache struct {
+	mu      sync.Mutex
+	clients map[types.NamespacedName]cachedWorkloadClient
+}
+
+// cachedWorkloadClient is a client along with the hash of the configuration it was created with.
+type cachedWorkloadClient struct {
+	hash   string
+	client client.Client
+}
+
+// get returns the cached client of the control plane, creating it when there is none or the
+// configuration changed.
+func (c *workloadClientCache) get(
+	key types.NamespacedName,
+	config *rest.Config,
+	newClient func(*rest.Config) (client.Client, error),
+) (client.Client, error) {
+	h := sha256.New()
+	for _, data := range [][]byte{[]byte(config.Host), config.CAData, config.CertData, config.KeyData} {
+		h.Write(data)
+		h.Write([]byte{0})
+	}
+	hash := fmt.Sprintf("%x", h.Sum(nil))
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if cached, ok := c.clients[key]; ok && cached.hash == hash {
+		return cached.client, nil
+	}
+	cl, err := newClient(config)
+	if err != nil {
+		return nil, err
+	}
+	if c.clients == nil {
+		c.clients = map[types.NamespacedName]cachedWorkloadClient{}
+	}
+	c.clients[key] = cachedWorkloadClient{hash: hash, client: cl}
+	return cl, nil
+}
+
+// forget drops the client of a deleted control plane.
+func (c *workloadClientCache) forget(key types.NamespacedName) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.clients, key)
+}
+
+// reconcileIssuerDiscovery allows or revokes the anonymous access to the service account issuer
+// discovery endpoints of the workload cluster, by managing a ClusterRoleBinding in it.
+func (r *KinkControlPlaneReconciler) reconcileIssuerDiscovery(
+	ctx context.Context,
+	kinkCP *controlplanev1alpha1.KinkControlPlane,
+) error {
+	sa := kinkCP.Spec.APIServer.ServiceAccount
+	public := sa != nil && sa.PublicDiscovery
+	exposed := meta.IsStatusConditionTrue(kinkCP.Status.Conditions,
+		controlplanev1alpha1.ServiceAccountIssuerDiscoveryCondition)
+	if !public && !exposed {
+		meta.RemoveStatusCondition(&kinkCP.Status.Conditions, controlplanev1alpha1.ServiceAccountIssuerDiscoveryCondition)
+		return nil
+	}
+	if kinkCP.Status.Hibernated {
+		return nil
+	}
+
+	condition := metav1.Condition{
+		Type:               controlplanev1alpha1.ServiceAccountIssuerDiscoveryCondition,
+		ObservedGeneration: kinkCP.Generation,
+	}
+	err := r.applyIssuerDiscovery(ctx, kinkCP, public)
+	switch {
+	case err != nil:
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = controlplanev1alpha1.IssuerDiscoveryFailedReason
+		condition.Message = err.Error()
+		// Keep reporting the access as granted until it is actually revoked.
+		if exposed && !public {
+			condition.Status = metav1.ConditionTrue
+		}
+	case public:
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = controlplanev1alpha1.IssuerDiscoveryExposedReason
+		condition.Message = "The service account issuer discovery endpoints allow anonymous requests"
+	default:
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = controlplanev1alpha1.IssuerDiscoveryNotExposedReason
+		condition.Message = "The service account issuer discovery endpoints require authentication"
+	}
+	meta.SetStatusCondition(&kinkCP.Status.Conditions, condition)
+
+	return err
+}
+
+// applyIssuerDiscovery creates or deletes the ClusterRoleBinding in the workload cluster.
+func (r *KinkControlPlaneReconciler) applyIssuerDiscovery(
+	ctx context.Context,
+	kinkCP *controlplanev1alpha1.KinkControlPlane,
+	public bool,
+) error {
+	newClient := r.newWorkloadClient
+	if newClient == nil {
+		newClient = r.workloadClient
+	}
+	c, err := newClient(ctx, kinkCP)
+	if err != nil {
+		return fmt.Errorf("failed to create workload cluster client: %w", err)
+	}
+
+	crb := (&controlplane.APIServer{KinkControlPlane: kinkCP}).IssuerDiscoveryClusterRoleBinding()
+	if !public {
+		log.FromContext(ctx).Info("Revoking anonymous access to the service account issuer discovery")
+		if err := c.Delete(ctx, crb); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed to delete ClusterRoleBinding %s: %w", crb.Name, err)
+		}
+		return nil
+	}
+
+	// The binding lives in the workload cluster, thus it is neither owned nor pruned.
+	if err := util.ReconcileDesiredObjects(ctx, c, kinkCP, r.Scheme, []client.Object{crb}, nil); err != nil {
+		return fmt.Errorf("failed to apply ClusterRoleBinding %s: %w", crb.Name, err)
+	}
+	return nil
+}
+
+// workloadClient returns a client of the workload cluster, authenticated with the admin
+// certificate and connected through the Service of the API server.
+func (r *KinkControlPlaneReconciler) workloadClient(
+	ctx context.Context,
+	kinkCP *controlplanev1alpha1.KinkControlPlane,
+) (client.Client, error) {
+	admin := &corev1.Secret{}
+	adminRef := types.NamespacedName{Name: naming.AdminCertificate(kinkCP.Name), Namespace: kinkCP.Namespace}
+	if err := r.Get(ctx, adminRef, admin); err != nil {
+		return nil, fmt.Errorf("failed to get secret %s: %w", adminRef, err)
+	}
+
+	bundle := &corev1.Secret{}
+	bundleRef := types.NamespacedName{Name: naming.TrustBundle(kinkCP.Name), Namespace: kinkCP.Namespace}
+	if err := r.Get(ctx, bundleRef, bundle); err != nil {
+		return nil, fmt.Errorf("failed to get secret %s: %w", bundleRef, err)
+	}
+
+	config := &rest.Config{
+		Host: naming.LocalAPIServerEndpoint(kinkCP.Name, kinkCP.Namespace),
+		TLSClientConfig: rest.TLSClientConfig{
+			CAData:   bundle.Data[controlplane.ClusterCABundleKey],
+			CertData: admin.Data[corev1.TLSCertKey],
+			KeyData:  admin.Data[corev1.TLSPrivateKeyKey],
+		},
+		Timeout: workloadClientTimeout,
+	}
+	newClient := func(config *rest.Config) (client.Client, error) {
+		return client.New(config, client.Options{Scheme: r.Scheme})
+	}
+	return r.workloadClients.get(client.ObjectKeyFromObject(kinkCP), config, newClient)
+}
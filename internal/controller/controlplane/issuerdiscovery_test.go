@@ -0,0 +1,180 @@
+// Copyright 2025 anza-labs contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controlplane
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	controlplanev1alpha1 "github.com/anza-labs/kink/api/controlplane/v1alpha1"
+	"github.com/anza-labs/kink/internal/manifests/controlplane"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestReconcileIssuerDiscovery(t *testing.T) {
+	t.Parallel()
+
+	exposed := metav1.Condition{
+		Type:   controlplanev1alpha1.ServiceAccountIssuerDiscoveryCondition,
+		Status: metav1.ConditionTrue,
+		Reason: controlplanev1alpha1.IssuerDiscoveryExposedReason,
+	}
+
+	for name, tc := range map[string]struct {
+		public         bool
+		hibernated     bool
+		conditions     []metav1.Condition
+		clientErr      error
+		expectedError  bool
+		expectedReason string
+		expectedStatus metav1.ConditionStatus
+		expectedExists bool
+	}{
+		"Disabled": {
+			expectedExists: true,
+		},
+		"Revoked": {
+			conditions:     []metav1.Condition{exposed},
+			expectedReason: controlplanev1alpha1.IssuerDiscoveryNotExposedReason,
+			expectedStatus: metav1.ConditionFalse,
+		},
+		"RevokeFailed": {
+			conditions:     []metav1.Condition{exposed},
+			clientErr:      errors.New("unreachable"),
+			expectedError:  true,
+			expectedReason: controlplanev1alpha1.IssuerDiscoveryFailedReason,
+			expectedStatus: metav1.ConditionTrue,
+			expectedExists: true,
+		},
+		"ExposeFailed": {
+			public:         true,
+			clientErr:      errors.New("unreachable"),
+			expectedError:  true,
+			expectedReason: controlplanev1alpha1.IssuerDiscoveryFailedReason,
+			expectedStatus: metav1.ConditionFalse,
+			expectedExists: true,
+		},
+		"Hibernated": {
+			public:         true,
+			hibernated:     true,
+			conditions:     []metav1.Condition{exposed},
+			expectedReason: controlplanev1alpha1.IssuerDiscoveryExposedReason,
+			expectedStatus: metav1.ConditionTrue,
+			expectedExists: true,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			// prepare
+			kinkCP := &controlplanev1alpha1.KinkControlPlane{
+				ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+				Spec: controlplanev1alpha1.KinkControlPlaneSpec{
+					APIServer: controlplanev1alpha1.APIServer{
+						ServiceAccount: &controlplanev1alpha1.ServiceAccount{PublicDiscovery: tc.public},
+					},
+				},
+				Status: controlplanev1alpha1.KinkControlPlaneStatus{
+					Hibernated: tc.hibernated,
+					Conditions: tc.conditions,
+				},
+			}
+			crb := (&controlplane.APIServer{KinkControlPlane: kinkCP}).IssuerDiscoveryClusterRoleBinding()
+			workload := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(crb.DeepCopy()).Build()
+			r := &KinkControlPlaneReconciler{
+				Scheme: scheme.Scheme,
+				newWorkloadClient: func(
+					context.Context,
+					*controlplanev1alpha1.KinkControlPlane,
+				) (client.Client, error) {
+					return workload, tc.clientErr
+				},
+			}
+
+			// test
+			err := r.reconcileIssuerDiscovery(t.Context(), kinkCP)
+
+			// validate
+			if tc.expectedError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			condition := meta.FindStatusCondition(
+				kinkCP.Status.Conditions,
+				controlplanev1alpha1.ServiceAccountIssuerDiscoveryCondition,
+			)
+			if tc.expectedReason == "" {
+				assert.Nil(t, condition)
+			} else {
+				require.NotNil(t, condition)
+				assert.Equal(t, tc.expectedReason, condition.Reason)
+				assert.Equal(t, tc.expectedStatus, condition.Status)
+			}
+
+			err = workload.Get(t.Context(), client.ObjectKeyFromObject(crb), &rbacv1.ClusterRoleBinding{})
+			assert.Equal(t, tc.expectedExists, !apierrors.IsNotFound(err))
+		})
+	}
+}
+
+func TestWorkloadClientCache(t *testing.T) {
+	t.Parallel()
+
+	// prepare
+	cache := &workloadClientCache{}
+	key := types.NamespacedName{Name: "test", Namespace: "default"}
+	created := 0
+	newClient := func(*rest.Config) (client.Client, error) {
+		created++
+		return fake.NewClientBuilder().Build(), nil
+	}
+	config := func(cert string) *rest.Config {
+		return &rest.Config{
+			Host:            "https://test-api-server.default.svc:6443",
+			TLSClientConfig: rest.TLSClientConfig{CertData: []byte(cert)},
+		}
+	}
+
+	// test
+	first, err := cache.get(key, config("cert"), newClient)
+	require.NoError(t, err)
+	cached, err := cache.get(key, config("cert"), newClient)
+	require.NoError(t, err)
+	renewed, err := cache.get(key, config("renewed"), newClient)
+	require.NoError(t, err)
+	cache.forget(key)
+	_, err = cache.get(key, config("renewed"), newClient)
+	require.NoError(t, err)
+
+	// validate
+	assert.Same(t, first, cached)
+	assert.NotSame(t, first, renewed)
+	assert.Equal(t, 3, created)
+}
@@ -18,30 +18,40 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"maps"
 	"math"
+	"slices"
+	"time"
 
 	"github.com/Masterminds/semver/v3"
 	cmv1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 
 	controlplanev1alpha1 "github.com/anza-labs/kink/api/controlplane/v1alpha1"
 	"github.com/anza-labs/kink/internal/controller/util"
 	"github.com/anza-labs/kink/internal/manifests/controlplane"
 	"github.com/anza-labs/kink/internal/manifests/manifestutils"
-	"github.com/anza-labs/kink/internal/naming"
+	"github.com/anza-labs/kink/internal/metrics"
 
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
 	netv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
-	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
-	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
 	gatewayapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayapiv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
 )
 
 const (
@@ -52,11 +62,42 @@ const (
 // KinkControlPlaneReconciler reconciles a KinkControlPlane object.
 type KinkControlPlaneReconciler struct {
 	client.Client
-	Scheme *runtime.Scheme
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+
+	// ResyncPeriod is the maximum interval between two reconciliations of a control plane,
+	// which ensures that drift of the owned objects is corrected. Zero disables the periodic resync.
+	ResyncPeriod time.Duration
+
+	// SNIRouterBaseDomain is the domain under which the hosts of the control planes exposed
+	// through the shared SNI router are assigned. Empty when the SNI router is not enabled.
+	SNIRouterBaseDomain string
+
+	// SNIRouterNamespace is the namespace of the shared SNI router, allowed to reach the API
+	// servers exposed through it. Empty when the SNI router is not enabled.
+	SNIRouterNamespace string
+
+	// OperatorNamespace is the namespace of the operator, allowed to reach the API servers by the
+	// NetworkPolicies of the control planes.
+	OperatorNamespace string
+
+	// serviceMonitors reports whether the Prometheus Operator CRDs are installed.
+	serviceMonitors bool
+
+	// tlsRoutes reports whether the TLSRoute CRD of the experimental Gateway API channel is installed.
+	tlsRoutes bool
+
+	// newWorkloadClient creates the clients of the workload clusters, defaults to workloadClient.
+	newWorkloadClient workloadClientFunc
+
+	// workloadClients caches the clients of the workload clusters created by workloadClient.
+	workloadClients workloadClientCache
 }
 
 //nolint:lll // kubebuilder directives cannot be split into lines
 // +kubebuilder:rbac:groups=core,resources=nodes,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=pods,verbs=get;list;watch;delete
+// +kubebuilder:rbac:groups=core,resources=events,verbs=create;patch
 // +kubebuilder:rbac:groups=controlplane.cluster.x-k8s.io,resources=kinkcontrolplanes,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=controlplane.cluster.x-k8s.io,resources=kinkcontrolplanes/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=controlplane.cluster.x-k8s.io,resources=kinkcontrolplanes/finalizers,verbs=update
@@ -81,12 +122,16 @@ type KinkControlPlaneReconciler struct {
 // +kubebuilder:rbac:groups=networking.k8s.io,resources=ingresses,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=networking.k8s.io,resources=ingresses/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=networking.k8s.io,resources=ingresses/finalizers,verbs=update
+// +kubebuilder:rbac:groups=networking.k8s.io,resources=networkpolicies,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=gateways,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=gateways/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=gateways/finalizers,verbs=update
-// +kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=httproutes,verbs=get;list;watch;create;update;patch;delete
-// +kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=httproutes/status,verbs=get;update;patch
-// +kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=httproutes/finalizers,verbs=update
+// +kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=tlsroutes,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=tlsroutes/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=tlsroutes/finalizers,verbs=update
+// +kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=httproutes,verbs=list;delete
+// +kubebuilder:rbac:groups=autoscaling,resources=horizontalpodautoscalers,verbs=get;list;watch
+// +kubebuilder:rbac:groups=monitoring.coreos.com,resources=servicemonitors,verbs=get;list;watch;create;update;patch;delete
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -115,6 +160,7 @@ func (r *KinkControlPlaneReconciler) Reconcile(ctx context.Context, req ctrl.Req
 				return ctrl.Result{}, err
 			}
 		}
+		r.workloadClients.forget(req.NamespacedName)
 		return ctrl.Result{}, nil
 	}
 
@@ -133,15 +179,53 @@ func (r *KinkControlPlaneReconciler) Reconcile(ctx context.Context, req ctrl.Req
 		}
 	}
 
+	log.V(2).Info("Evaluating hibernation")
+	hibernated, reason, requeueAfter, err := hibernationState(kinkCP.Spec.Hibernation, time.Now())
+	if err != nil {
+		// Keep the current state until the schedule is fixed.
+		log.Error(err, "Failed to evaluate hibernation schedule")
+		r.setHibernationStatus(kinkCP, kinkCP.Status.Hibernated, reason, err.Error())
+	} else {
+		r.setHibernationStatus(kinkCP, hibernated, reason, "")
+	}
+
+	r.startCARotation(kinkCP)
+
 	log.V(2).Info("Starting ControlPlane resource reconciliation")
-	if err := r.reconcileResources(ctx, kinkCP); err != nil {
+	if err := r.reconcileResources(ctx, kinkCP); errors.Is(err, errPatchFailed) {
+		// The failure is recorded in the status, which must be updated regardless.
+		log.Error(err, "Failed to patch resources")
+	} else if err != nil {
 		log.Error(err, "Failed to reconcile resources")
 		return ctrl.Result{}, err
 	}
 
+	if err := r.reconcileCARotation(ctx, kinkCP); err != nil {
+		// The progress of the rotation is recorded in the status, which must be updated regardless.
+		log.Error(err, "Failed to rotate certificate authorities")
+	}
+
+	endpointProvisioned := kinkCP.Spec.ControlPlaneEndpoint.Host != ""
 	if err := r.reconcileEndpoint(ctx, kinkCP); err != nil {
+		// The failure is recorded in the status, which must be updated regardless.
 		log.Error(err, "Failed to reconcile endpoint")
-		return ctrl.Result{}, err
+	}
+	if !endpointProvisioned && kinkCP.Spec.ControlPlaneEndpoint.Host != "" {
+		metrics.EndpointProvisioningLatency.Observe(time.Since(kinkCP.CreationTimestamp.Time).Seconds())
+	}
+
+	if err := r.reconcileIssuerDiscovery(ctx, kinkCP); err != nil {
+		// The failure is recorded in the status, which must be updated regardless.
+		log.Error(err, "Failed to reconcile service account issuer discovery")
+	}
+
+	var remediationRequeueAfter time.Duration
+	if !kinkCP.Status.Hibernated {
+		remediationRequeueAfter, err = r.reconcileRemediation(ctx, kinkCP)
+		if err != nil {
+			// Failed attempts are recorded in the status, which must be updated regardless.
+			log.Error(err, "Failed to remediate unhealthy components")
+		}
 	}
 
 	if err := r.reconcileStatus(ctx, kinkCP); err != nil {
@@ -149,8 +233,18 @@ func (r *KinkControlPlaneReconciler) Reconcile(ctx context.Context, req ctrl.Req
 		return ctrl.Result{}, err
 	}
 
+	if r.ResyncPeriod > 0 && (requeueAfter == 0 || r.ResyncPeriod < requeueAfter) {
+		requeueAfter = r.ResyncPeriod
+	}
+	if caRotationInProgress(kinkCP) && (requeueAfter == 0 || caRotationPollInterval < requeueAfter) {
+		requeueAfter = caRotationPollInterval
+	}
+	if remediationRequeueAfter > 0 && (requeueAfter == 0 || remediationRequeueAfter < requeueAfter) {
+		requeueAfter = remediationRequeueAfter
+	}
+
 	log.V(2).Info("Reconciliation successful")
-	return ctrl.Result{}, nil
+	return ctrl.Result{RequeueAfter: requeueAfter}, nil
 }
 
 func (r *KinkControlPlaneReconciler) checkOwnership(
@@ -176,11 +270,62 @@ func (r *KinkControlPlaneReconciler) reconcileResources(
 	log := log.FromContext(ctx)
 
 	log.V(2).Info("Building components")
-	obj, err := (&controlplane.Builder{}).Build(kinkCP)
+	monitoring := &controlplane.Monitoring{KinkControlPlane: kinkCP}
+	if monitoring.Enabled() && !r.serviceMonitors {
+		log.Info("Monitoring is enabled, but the Prometheus Operator CRDs are not installed, skipping ServiceMonitors")
+		r.Recorder.Event(kinkCP, corev1.EventTypeWarning, "MonitoringUnavailable",
+			"ServiceMonitors are not created, because the Prometheus Operator CRDs are not installed")
+	}
+	if kinkCP.Spec.ControlPlaneEndpoint.Gateway != nil && !r.tlsRoutes {
+		log.Info("Gateway is configured, but the TLSRoute CRD is not installed, skipping TLSRoute")
+		r.Recorder.Event(kinkCP, corev1.EventTypeWarning, "TLSRouteUnavailable",
+			"TLSRoute is not created, because the experimental Gateway API CRDs are not installed")
+	}
+
+	verifyingKeys, err := r.verifyingServiceAccountKeys(ctx, kinkCP)
+	if err != nil {
+		return err
+	}
+	saKeys, saSigningKey, err := (&controlplane.ServiceAccountKeys{
+		Client:           r.Client,
+		KinkControlPlane: kinkCP,
+		VerifyingKeys:    verifyingKeys,
+	}).Build(ctx)
+	if apierrors.IsNotFound(err) {
+		log.V(2).Info("Service account key not issued yet, verifying tokens with the signing key")
+		saKeys, saSigningKey = nil, nil
+	} else if err != nil {
+		return fmt.Errorf("failed to build service account keys: %w", err)
+	}
+
+	timer := metrics.PhaseTimer(metrics.PhaseBuild)
+	obj, err := (&controlplane.Builder{
+		Hibernated:               kinkCP.Status.Hibernated,
+		ServiceMonitors:          r.serviceMonitors,
+		TLSRoutes:                r.tlsRoutes,
+		ServiceAccountKeys:       saKeys,
+		ServiceAccountSigningKey: saSigningKey,
+		OperatorNamespace:        r.OperatorNamespace,
+		SNIRouterNamespace:       r.SNIRouterNamespace,
+	}).Build(kinkCP)
+	timer.ObserveDuration()
 	if err != nil {
 		return fmt.Errorf("failed to build components: %w", err)
 	}
 
+	obj, err = applyPatches(r.Scheme, obj, kinkCP.Spec.Patches)
+	setPatchesCondition(kinkCP, err)
+	if err != nil {
+		// Applying the objects without the patches could revert the settings they carry, so the
+		// objects are left as they are until the patches are fixed.
+		r.Recorder.Event(kinkCP, corev1.EventTypeWarning, "PatchFailed", err.Error())
+		return fmt.Errorf("%w: %w", errPatchFailed, err)
+	}
+
+	if err := r.annotateCertificatesHash(ctx, kinkCP, obj); err != nil {
+		return fmt.Errorf("failed to annotate certificates hash: %w", err)
+	}
+
 	ownedObjects, err := util.FindOwnedObjects(
 		ctx,
 		r.Client,
@@ -194,25 +339,47 @@ func (r *KinkControlPlaneReconciler) reconcileResources(
 	log.V(8).Info("Found objects", "objects", len(ownedObjects))
 
 	log.V(2).Info("Reconciling components", "object_count", len(ownedObjects), "expected_count", len(obj))
-	if err := util.ReconcileDesiredObjects(
+	timer = metrics.PhaseTimer(metrics.PhaseApply)
+	err = util.ReconcileDesiredObjects(
 		ctx,
 		r.Client,
 		kinkCP,
 		r.Scheme,
 		obj,
 		ownedObjects,
-	); err != nil {
+	)
+	timer.ObserveDuration()
+	if err != nil {
 		return fmt.Errorf("failed to ensure resources: %w", err)
 	}
 
 	log.V(2).Info("Building kubeconfigs")
-	kc, err := (&controlplane.Kubeconfig{
+	defer metrics.PhaseTimer(metrics.PhaseKubeconfig).ObserveDuration()
+	trustBundle, err := (&controlplane.TrustBundle{
 		Client:           r.Client,
 		KinkControlPlane: kinkCP,
 	}).Build(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to build trust bundle: %w", err)
+	}
+	kubeconfigs := &controlplane.Kubeconfig{
+		Client:           r.Client,
+		KinkControlPlane: kinkCP,
+		CABundle:         trustBundle.Data[controlplane.ClusterCABundleKey],
+	}
+	kc, err := kubeconfigs.Build(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to build kubeconfigs: %w", err)
 	}
+	kc = append(kc, trustBundle)
+
+	users, pending := kubeconfigs.Users(ctx)
+	kc = append(kc, users...)
+	for _, name := range slices.Sorted(maps.Keys(pending)) {
+		log.Info("User kubeconfig is not ready yet", "secret", name, "reason", pending[name].Error())
+		r.Recorder.Eventf(kinkCP, corev1.EventTypeWarning, "KubeconfigPending",
+			"Kubeconfig %s is not ready yet: %v", name, pending[name])
+	}
 
 	ownedSecrets, err := util.FindOwnedObjects(
 		ctx,
@@ -224,6 +391,24 @@ func (r *KinkControlPlaneReconciler) reconcileResources(
 	if err != nil {
 		return fmt.Errorf("failed to find owned secrets: %w", err)
 	}
+	for uid, secret := range ownedSecrets {
+		// Certificate Secrets are managed by cert-manager, deleting them would re-issue the
+		// certificates and restart all components.
+		if _, ok := secret.GetAnnotations()[cmv1.CertificateNameKey]; ok {
+			delete(ownedSecrets, uid)
+		}
+		// The service account keys are applied with the components.
+		if saKeys != nil && secret.GetName() == saKeys.Name {
+			delete(ownedSecrets, uid)
+		}
+		if saSigningKey != nil && secret.GetName() == saSigningKey.Name {
+			delete(ownedSecrets, uid)
+		}
+		// Pending user kubeconfigs keep their previous Secret until they can be built again.
+		if _, ok := pending[secret.GetName()]; ok {
+			delete(ownedSecrets, uid)
+		}
+	}
 	log.V(8).Info("Found objects", "objects", ownedSecrets)
 
 	log.V(2).Info("Reconciling kubeconfigs", "object_count", len(ownedSecrets), "expected_count", len(kc))
@@ -246,6 +431,7 @@ func (r *KinkControlPlaneReconciler) reconcileStatus(
 	kinkCP *controlplanev1alpha1.KinkControlPlane,
 ) error {
 	logger := log.FromContext(ctx)
+	defer metrics.PhaseTimer(metrics.PhaseStatus).ObserveDuration()
 
 	ownedObjects, err := util.FindOwnedObjects(
 		ctx,
@@ -260,6 +446,7 @@ func (r *KinkControlPlaneReconciler) reconcileStatus(
 	logger.V(8).Info("Found objects", "objects", len(ownedObjects))
 
 	// Aggregated status variables.
+	wasReady := kinkCP.Status.Ready
 	hasReadyAPIServer := false
 	allReady := true
 	// Use a high initial value to find the minimums.
@@ -343,9 +530,25 @@ func (r *KinkControlPlaneReconciler) reconcileStatus(
 		}
 	}
 
+	// The control plane is not ready to Cluster API until its endpoint is.
+	if kinkCP.Spec.ControlPlaneEndpoint.Host == "" ||
+		meta.IsStatusConditionFalse(kinkCP.Status.Conditions, controlplanev1alpha1.EndpointReadyCondition) {
+		errs = errors.Join(errs, errors.New("endpoint not ready"))
+		allReady = false
+	}
+
 	// Set status fields.
-	kinkCP.Status.Initialized = hasReadyAPIServer
-	kinkCP.Status.Ready = allReady
+	// Following the Cluster API contract, the control plane is initialized once its API server
+	// has been reachable at least once, and it does not become uninitialized afterwards, e.g.
+	// when the API server restarts or the control plane is hibernated.
+	kinkCP.Status.Initialized = kinkCP.Status.Initialized || hasReadyAPIServer
+	// Components are scaled to zero on purpose when hibernated.
+	kinkCP.Status.Ready = allReady && !kinkCP.Status.Hibernated
+	// Control planes which were ready before the first ready time was recorded are not observed.
+	firstReady := kinkCP.Status.FirstReadyTime == nil && kinkCP.Status.Ready && !wasReady
+	if kinkCP.Status.FirstReadyTime == nil && kinkCP.Status.Ready {
+		kinkCP.Status.FirstReadyTime = &metav1.Time{Time: time.Now()}
+	}
 	kinkCP.Status.Replicas = minReplicas
 	kinkCP.Status.ReadyReplicas = minReady
 	kinkCP.Status.UpdatedReplicas = minUpdated
@@ -356,77 +559,22 @@ func (r *KinkControlPlaneReconciler) reconcileStatus(
 		kinkCP.Status.Version = ptr.To(lowestVersion.Original())
 	}
 
-	if kinkCP.Spec.ControlPlaneEndpoint.Host == "" {
-		errs = errors.Join(errs, errors.New("endpoint not ready"))
-		allReady = false
-	}
+	r.setCertificatesStatus(kinkCP, ownedObjects, time.Now())
 
-	if !allReady {
+	if !allReady && !kinkCP.Status.Hibernated {
 		errs = errors.Join(errs, errors.New("not all components are ready"))
 	}
 
 	if err := r.Status().Update(ctx, kinkCP); err != nil {
 		errs = errors.Join(errs, fmt.Errorf("failed to apply status changes: %w", err))
+	} else if firstReady {
+		// Observed once the first ready time is stored, as a failed update is retried.
+		metrics.TimeToFirstReady.Observe(kinkCP.Status.FirstReadyTime.Sub(kinkCP.CreationTimestamp.Time).Seconds())
 	}
 
 	return errs
 }
 
-func (r *KinkControlPlaneReconciler) reconcileEndpoint(
-	ctx context.Context,
-	kinkCP *controlplanev1alpha1.KinkControlPlane,
-) error {
-	if kinkCP.Spec.ControlPlaneEndpoint.Host != "" {
-		return nil
-	}
-
-	svc := &corev1.Service{}
-	err := r.Get(ctx, types.NamespacedName{
-		Name:      naming.APIServer(kinkCP.Name),
-		Namespace: kinkCP.Namespace,
-	}, svc)
-	if err != nil {
-		return fmt.Errorf("failed to get API server service: %w", err)
-	}
-
-	switch svc.Spec.Type {
-	case corev1.ServiceTypeNodePort:
-		if len(svc.Spec.Ports) == 0 {
-			return fmt.Errorf("API server service has no ports")
-		}
-		kinkCP.Spec.ControlPlaneEndpoint.Port = svc.Spec.Ports[0].NodePort
-
-		nodes := &corev1.NodeList{}
-		if err := r.List(ctx, nodes); err != nil {
-			return fmt.Errorf("failed to list nodes: %w", err)
-		}
-		for _, node := range nodes.Items {
-			for _, addr := range node.Status.Addresses {
-				if addr.Type == corev1.NodeExternalIP {
-					kinkCP.Spec.ControlPlaneEndpoint.Host = controlplanev1alpha1.HostnameOrIP(addr.Address)
-					return r.Update(ctx, kinkCP)
-				}
-			}
-		}
-		return fmt.Errorf("no node with external IP found")
-
-	case corev1.ServiceTypeLoadBalancer:
-		if len(svc.Spec.Ports) == 0 {
-			return fmt.Errorf("API server service has no ports")
-		}
-		kinkCP.Spec.ControlPlaneEndpoint.Port = svc.Spec.Ports[0].Port
-
-		if len(svc.Status.LoadBalancer.Ingress) == 0 || svc.Status.LoadBalancer.Ingress[0].IP == "" {
-			return fmt.Errorf("LoadBalancer service has no external IP")
-		}
-		kinkCP.Spec.ControlPlaneEndpoint.Host = controlplanev1alpha1.HostnameOrIP(svc.Status.LoadBalancer.Ingress[0].IP)
-		return r.Update(ctx, kinkCP)
-
-	default:
-		return fmt.Errorf("unsupported service type: %s", svc.Spec.Type)
-	}
-}
-
 // GetOwnedResourceTypes returns all the resource types the controller can own.
 // Even though this method returns an array of client.Object, these are (empty)
 // example structs rather than actual resources.
@@ -439,8 +587,14 @@ func (r *KinkControlPlaneReconciler) GetOwnedResourceTypes(filters ...util.Filte
 		&cmv1.Issuer{},
 		&cmv1.Certificate{},
 		&netv1.Ingress{},
+		&netv1.NetworkPolicy{},
 		&gatewayapiv1.Gateway{},
-		&gatewayapiv1.HTTPRoute{},
+	}
+	if r.tlsRoutes {
+		objs = append(objs, &gatewayapiv1alpha2.TLSRoute{})
+	}
+	if r.serviceMonitors {
+		objs = append(objs, &monitoringv1.ServiceMonitor{})
 	}
 	for _, filter := range filters {
 		objs = filter.Filter(objs)
@@ -477,12 +631,66 @@ func (r *KinkControlPlaneReconciler) cleanupResources(
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *KinkControlPlaneReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	serviceMonitors, err := util.HasKind(
+		mgr.GetRESTMapper(),
+		monitoringv1.SchemeGroupVersion.WithKind(monitoringv1.ServiceMonitorsKind),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to discover ServiceMonitor support: %w", err)
+	}
+	r.serviceMonitors = serviceMonitors
+
+	tlsRoutes, err := util.HasKind(mgr.GetRESTMapper(), gatewayapiv1alpha2.SchemeGroupVersion.WithKind("TLSRoute"))
+	if err != nil {
+		return fmt.Errorf("failed to discover TLSRoute support: %w", err)
+	}
+	r.tlsRoutes = tlsRoutes
+
+	if err := mgr.GetFieldIndexer().IndexField(
+		context.Background(),
+		&autoscalingv2.HorizontalPodAutoscaler{},
+		util.ScaleTargetIndex,
+		util.IndexScaleTarget,
+	); err != nil {
+		return fmt.Errorf("failed to index horizontal pod autoscalers: %w", err)
+	}
+
+	httpRoutes, err := util.HasKind(mgr.GetRESTMapper(), gatewayapiv1.SchemeGroupVersion.WithKind("HTTPRoute"))
+	if err != nil {
+		return fmt.Errorf("failed to discover HTTPRoute support: %w", err)
+	}
+	if httpRoutes {
+		if err := mgr.Add(manager.RunnableFunc(func(ctx context.Context) error {
+			// A failed cleanup is retried on the next start of the operator, it must not stop it.
+			if err := cleanupHTTPRoutes(ctx, mgr.GetAPIReader(), r.Client); err != nil {
+				log.FromContext(ctx).Error(err, "Failed to clean up HTTPRoutes")
+			}
+			return nil
+		})); err != nil {
+			return fmt.Errorf("failed to add HTTPRoute cleanup: %w", err)
+		}
+	}
+
 	c := ctrl.NewControllerManagedBy(mgr).
 		For(&controlplanev1alpha1.KinkControlPlane{}).
 		Named("kinkcontrolplane")
 
 	for _, obj := range r.GetOwnedResourceTypes() {
-		c = c.Owns(obj, builder.WithPredicates(predicate.GenerationChangedPredicate{}))
+		c = c.Owns(obj, builder.WithPredicates(ownedObjectPredicate()))
+	}
+
+	c = c.Watches(
+		&corev1.Node{},
+		handler.EnqueueRequestsFromMapFunc(r.endpointNodeToControlPlanes),
+		builder.WithPredicates(endpointNodePredicate()),
+	)
+
+	if r.SNIRouterBaseDomain != "" {
+		c = c.Watches(
+			&controlplanev1alpha1.KinkControlPlane{},
+			handler.EnqueueRequestsFromMapFunc(r.sniRouterHostToControlPlanes),
+			builder.WithPredicates(deletedPredicate()),
+		)
 	}
 
 	return c.Complete(r)
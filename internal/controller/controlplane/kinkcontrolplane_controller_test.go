@@ -13,3 +13,186 @@
 // limitations under the License.
 
 package controlplane
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	controlplanev1alpha1 "github.com/anza-labs/kink/api/controlplane/v1alpha1"
+	"github.com/anza-labs/kink/internal/manifests/controlplane"
+	"github.com/anza-labs/kink/internal/manifests/manifestutils"
+
+	cmv1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gatewayapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+func TestReconcileStatus(t *testing.T) {
+	t.Parallel()
+
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(cmv1.AddToScheme(scheme))
+	utilruntime.Must(gatewayapiv1.Install(scheme))
+	utilruntime.Must(controlplanev1alpha1.AddToScheme(scheme))
+
+	deployment := func(component string, ready int32) *appsv1.Deployment {
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            "test-" + component,
+				Namespace:       "default",
+				UID:             types.UID(component),
+				Labels:          map[string]string{manifestutils.LabelComponent: component},
+				OwnerReferences: []metav1.OwnerReference{{UID: "test"}},
+			},
+			Spec: appsv1.DeploymentSpec{Replicas: ptr.To[int32](1)},
+			Status: appsv1.DeploymentStatus{
+				Replicas:          1,
+				ReadyReplicas:     ready,
+				AvailableReplicas: ready,
+				UpdatedReplicas:   1,
+			},
+		}
+	}
+	endpointReady := func(status metav1.ConditionStatus) []metav1.Condition {
+		return []metav1.Condition{{
+			Type:   controlplanev1alpha1.EndpointReadyCondition,
+			Status: status,
+			Reason: controlplanev1alpha1.EndpointPendingReason,
+		}}
+	}
+
+	for name, tc := range map[string]struct {
+		host                controlplanev1alpha1.HostnameOrIP
+		conditions          []metav1.Condition
+		initialized         bool
+		hibernated          bool
+		objects             []client.Object
+		expectedReady       bool
+		expectedInitialized bool
+	}{
+		"Ready": {
+			host:       "example.com",
+			conditions: endpointReady(metav1.ConditionTrue),
+			objects: []client.Object{
+				deployment(controlplane.ComponentKine, 1),
+				deployment(controlplane.ComponentAPIServer, 1),
+			},
+			expectedReady:       true,
+			expectedInitialized: true,
+		},
+		"ComponentNotReady": {
+			host:       "example.com",
+			conditions: endpointReady(metav1.ConditionTrue),
+			objects: []client.Object{
+				deployment(controlplane.ComponentKine, 0),
+				deployment(controlplane.ComponentAPIServer, 1),
+			},
+			expectedReady:       false,
+			expectedInitialized: true,
+		},
+		"EndpointNotReady": {
+			host:       "example.com",
+			conditions: endpointReady(metav1.ConditionFalse),
+			objects: []client.Object{
+				deployment(controlplane.ComponentKine, 1),
+				deployment(controlplane.ComponentAPIServer, 1),
+			},
+			expectedReady:       false,
+			expectedInitialized: true,
+		},
+		"EndpointHostMissing": {
+			objects: []client.Object{
+				deployment(controlplane.ComponentKine, 1),
+				deployment(controlplane.ComponentAPIServer, 1),
+			},
+			expectedReady:       false,
+			expectedInitialized: true,
+		},
+		"NotInitialized": {
+			host:       "example.com",
+			conditions: endpointReady(metav1.ConditionTrue),
+			objects: []client.Object{
+				deployment(controlplane.ComponentKine, 1),
+				deployment(controlplane.ComponentAPIServer, 0),
+			},
+			expectedReady:       false,
+			expectedInitialized: false,
+		},
+		"APIServerRestarting": {
+			host:        "example.com",
+			conditions:  endpointReady(metav1.ConditionTrue),
+			initialized: true,
+			objects: []client.Object{
+				deployment(controlplane.ComponentKine, 1),
+				deployment(controlplane.ComponentAPIServer, 0),
+			},
+			expectedReady:       false,
+			expectedInitialized: true,
+		},
+		"Hibernated": {
+			host:        "example.com",
+			conditions:  endpointReady(metav1.ConditionTrue),
+			initialized: true,
+			hibernated:  true,
+			objects: []client.Object{
+				deployment(controlplane.ComponentKine, 1),
+				deployment(controlplane.ComponentAPIServer, 0),
+			},
+			expectedReady:       false,
+			expectedInitialized: true,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			// prepare
+			kinkCP := &controlplanev1alpha1.KinkControlPlane{
+				ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default", UID: "test"},
+				Spec: controlplanev1alpha1.KinkControlPlaneSpec{
+					ControlPlaneEndpoint: controlplanev1alpha1.APIEndpoint{Host: tc.host},
+				},
+				Status: controlplanev1alpha1.KinkControlPlaneStatus{
+					Conditions:  tc.conditions,
+					Initialized: tc.initialized,
+					Hibernated:  tc.hibernated,
+				},
+			}
+			c := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(append(tc.objects, kinkCP)...).
+				WithStatusSubresource(&controlplanev1alpha1.KinkControlPlane{}).
+				Build()
+			r := &KinkControlPlaneReconciler{
+				Client:   c,
+				Scheme:   scheme,
+				Recorder: record.NewFakeRecorder(10),
+			}
+
+			// test
+			err := r.reconcileStatus(t.Context(), kinkCP)
+
+			// validate
+			// Components scaled to zero by the hibernation are not reported.
+			if tc.expectedReady || tc.hibernated {
+				require.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+			assert.Equal(t, tc.expectedReady, kinkCP.Status.Ready)
+			assert.Equal(t, tc.expectedReady, kinkCP.Status.FirstReadyTime != nil)
+			assert.Equal(t, tc.expectedInitialized, kinkCP.Status.Initialized)
+		})
+	}
+}
@@ -0,0 +1,155 @@
+// Copyright 2025 anza-labs contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controlplane
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	jsonpatch "github.com/evanphx/json-patch/v5"
+
+	controlplanev1alpha1 "github.com/anza-labs/kink/api/controlplane/v1alpha1"
+	"github.com/anza-labs/kink/internal/manifests/manifestutils"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/yaml"
+)
+
+// errPatchFailed is returned when a patch of the control plane cannot be applied, in which case
+// the failure is reported by the PatchesApplied condition.
+var errPatchFailed = errors.New("failed to apply patches")
+
+// applyPatches returns the objects with the patches matching them applied, in order. The objects
+// are not modified.
+func applyPatches(
+	scheme *runtime.Scheme,
+	objects []client.Object,
+	patches []controlplanev1alpha1.Patch,
+) ([]client.Object, error) {
+	if len(patches) == 0 {
+		return objects, nil
+	}
+
+	patched := make([]client.Object, 0, len(objects))
+	for _, obj := range objects {
+		gvk, err := apiutil.GVKForObject(obj, scheme)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get kind of %s: %w", obj.GetName(), err)
+		}
+
+		for i, patch := range patches {
+			if !patchTargets(patch.Target, gvk.Kind, obj) {
+				continue
+			}
+			result, err := applyPatch(scheme, gvk, obj, patch)
+			if err != nil {
+				return nil, fmt.Errorf("failed to apply patch %d to %s %s: %w", i, gvk.Kind, obj.GetName(), err)
+			}
+			obj = result
+		}
+		patched = append(patched, obj)
+	}
+	return patched, nil
+}
+
+// patchTargets reports whether the object of the given kind is selected by the target.
+func patchTargets(target controlplanev1alpha1.PatchTarget, kind string, obj client.Object) bool {
+	if target.Kind != kind {
+		return false
+	}
+	return target.Component == "" || obj.GetLabels()[manifestutils.LabelComponent] == target.Component
+}
+
+func applyPatch(
+	scheme *runtime.Scheme,
+	gvk schema.GroupVersionKind,
+	obj client.Object,
+	patch controlplanev1alpha1.Patch,
+) (client.Object, error) {
+	original, err := json.Marshal(obj)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal object: %w", err)
+	}
+	patchJSON, err := yaml.YAMLToJSON([]byte(patch.Patch))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse patch: %w", err)
+	}
+
+	var data []byte
+	switch patch.Type {
+	case controlplanev1alpha1.JSON6902PatchType:
+		operations, err := jsonpatch.DecodePatch(patchJSON)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode JSON patch: %w", err)
+		}
+		data, err = operations.Apply(original)
+		if err != nil {
+			return nil, err
+		}
+
+	default:
+		data, err = strategicpatch.StrategicMergePatch(original, patchJSON, obj)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	newObj, err := scheme.New(gvk)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create object: %w", err)
+	}
+	result, ok := newObj.(client.Object)
+	if !ok {
+		return nil, fmt.Errorf("unexpected object type %T", newObj)
+	}
+	if err := json.Unmarshal(data, result); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal patched object: %w", err)
+	}
+
+	// The objects are pruned and owned by name, renaming them would orphan the original ones.
+	if result.GetName() != obj.GetName() || result.GetNamespace() != obj.GetNamespace() {
+		return nil, errors.New("the name and the namespace of the object cannot be changed")
+	}
+	return result, nil
+}
+
+// setPatchesCondition reports the outcome of the patches with the PatchesApplied condition.
+func setPatchesCondition(kinkCP *controlplanev1alpha1.KinkControlPlane, err error) {
+	if len(kinkCP.Spec.Patches) == 0 {
+		meta.RemoveStatusCondition(&kinkCP.Status.Conditions, controlplanev1alpha1.PatchesAppliedCondition)
+		return
+	}
+
+	condition := metav1.Condition{
+		Type:               controlplanev1alpha1.PatchesAppliedCondition,
+		Status:             metav1.ConditionTrue,
+		Reason:             controlplanev1alpha1.PatchesAppliedReason,
+		Message:            fmt.Sprintf("%d patches are applied", len(kinkCP.Spec.Patches)),
+		ObservedGeneration: kinkCP.Generation,
+	}
+	if err != nil {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = controlplanev1alpha1.PatchesFailedReason
+		condition.Message = err.Error()
+	}
+	meta.SetStatusCondition(&kinkCP.Status.Conditions, condition)
+}
@@ -0,0 +1,244 @@
+// Copyright 2025 anza-labs contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controlplane
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	controlplanev1alpha1 "github.com/anza-labs/kink/api/controlplane/v1alpha1"
+	"github.com/anza-labs/kink/internal/manifests/manifestutils"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestApplyPatches(t *testing.T) {
+	t.Parallel()
+
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+
+	deployment := func(component string) *appsv1.Deployment {
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-" + component,
+				Namespace: "default",
+				Labels:    map[string]string{manifestutils.LabelComponent: component},
+			},
+			Spec: appsv1.DeploymentSpec{
+				Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{
+							{Name: component, Args: []string{"--v=4"}},
+							{Name: "sidecar"},
+						},
+					},
+				},
+			},
+		}
+	}
+	objects := func() []client.Object {
+		return []client.Object{
+			deployment("api-server"),
+			deployment("scheduler"),
+			&corev1.Service{ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-api-server",
+				Namespace: "default",
+				Labels:    map[string]string{manifestutils.LabelComponent: "api-server"},
+			}},
+		}
+	}
+	args := func(obj client.Object) []string {
+		return obj.(*appsv1.Deployment).Spec.Template.Spec.Containers[0].Args
+	}
+
+	for name, tc := range map[string]struct {
+		patches       []controlplanev1alpha1.Patch
+		expectedError bool
+		validate      func(t *testing.T, actual []client.Object)
+	}{
+		"NoPatches": {
+			validate: func(t *testing.T, actual []client.Object) {
+				assert.Equal(t, objects(), actual)
+			},
+		},
+		"StrategicMerge": {
+			patches: []controlplanev1alpha1.Patch{
+				{
+					Target: controlplanev1alpha1.PatchTarget{Kind: "Deployment", Component: "api-server"},
+					Patch: `
+spec:
+  template:
+    spec:
+      containers:
+      - name: api-server
+        env:
+        - name: GOMEMLIMIT
+          value: 1GiB
+`,
+				},
+			},
+			validate: func(t *testing.T, actual []client.Object) {
+				containers := actual[0].(*appsv1.Deployment).Spec.Template.Spec.Containers
+				require.Len(t, containers, 2)
+				assert.Equal(t, []corev1.EnvVar{{Name: "GOMEMLIMIT", Value: "1GiB"}}, containers[0].Env)
+				assert.Equal(t, []string{"--v=4"}, containers[0].Args)
+				assert.Equal(t, "sidecar", containers[1].Name)
+				assert.Empty(t, actual[1].(*appsv1.Deployment).Spec.Template.Spec.Containers[0].Env)
+			},
+		},
+		"JSON6902": {
+			patches: []controlplanev1alpha1.Patch{
+				{
+					Target: controlplanev1alpha1.PatchTarget{Kind: "Deployment"},
+					Type:   controlplanev1alpha1.JSON6902PatchType,
+					Patch:  `[{"op": "add", "path": "/spec/template/spec/containers/0/args/-", "value": "--profiling=false"}]`,
+				},
+			},
+			validate: func(t *testing.T, actual []client.Object) {
+				assert.Equal(t, []string{"--v=4", "--profiling=false"}, args(actual[0]))
+				assert.Equal(t, []string{"--v=4", "--profiling=false"}, args(actual[1]))
+			},
+		},
+		"InOrder": {
+			patches: []controlplanev1alpha1.Patch{
+				{
+					Target: controlplanev1alpha1.PatchTarget{Kind: "Deployment", Component: "scheduler"},
+					Type:   controlplanev1alpha1.JSON6902PatchType,
+					Patch:  `[{"op": "replace", "path": "/spec/template/spec/containers/0/args/0", "value": "--v=2"}]`,
+				},
+				{
+					Target: controlplanev1alpha1.PatchTarget{Kind: "Deployment", Component: "scheduler"},
+					Type:   controlplanev1alpha1.JSON6902PatchType,
+					Patch:  `[{"op": "test", "path": "/spec/template/spec/containers/0/args/0", "value": "--v=2"}]`,
+				},
+			},
+			validate: func(t *testing.T, actual []client.Object) {
+				assert.Equal(t, []string{"--v=4"}, args(actual[0]))
+				assert.Equal(t, []string{"--v=2"}, args(actual[1]))
+			},
+		},
+		"Rename": {
+			patches: []controlplanev1alpha1.Patch{
+				{
+					Target: controlplanev1alpha1.PatchTarget{Kind: "Service"},
+					Patch:  `{"metadata": {"name": "renamed"}}`,
+				},
+			},
+			expectedError: true,
+		},
+		"FailedOperation": {
+			patches: []controlplanev1alpha1.Patch{
+				{
+					Target: controlplanev1alpha1.PatchTarget{Kind: "Deployment"},
+					Type:   controlplanev1alpha1.JSON6902PatchType,
+					Patch:  `[{"op": "remove", "path": "/spec/missing"}]`,
+				},
+			},
+			expectedError: true,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			// prepare
+			original := objects()
+
+			// test
+			actual, err := applyPatches(scheme, original, tc.patches)
+
+			// validate
+			assert.Equal(t, objects(), original)
+			if tc.expectedError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			tc.validate(t, actual)
+		})
+	}
+}
+
+func TestSetPatchesCondition(t *testing.T) {
+	t.Parallel()
+
+	patches := []controlplanev1alpha1.Patch{{Target: controlplanev1alpha1.PatchTarget{Kind: "Deployment"}}}
+
+	for name, tc := range map[string]struct {
+		patches  []controlplanev1alpha1.Patch
+		err      error
+		expected *metav1.Condition
+	}{
+		"NoPatches": {},
+		"Applied": {
+			patches: patches,
+			expected: &metav1.Condition{
+				Type:   controlplanev1alpha1.PatchesAppliedCondition,
+				Status: metav1.ConditionTrue,
+				Reason: controlplanev1alpha1.PatchesAppliedReason,
+			},
+		},
+		"Failed": {
+			patches: patches,
+			err:     errPatchFailed,
+			expected: &metav1.Condition{
+				Type:    controlplanev1alpha1.PatchesAppliedCondition,
+				Status:  metav1.ConditionFalse,
+				Reason:  controlplanev1alpha1.PatchesFailedReason,
+				Message: errPatchFailed.Error(),
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			// prepare
+			kinkCP := &controlplanev1alpha1.KinkControlPlane{
+				Spec: controlplanev1alpha1.KinkControlPlaneSpec{Patches: tc.patches},
+				Status: controlplanev1alpha1.KinkControlPlaneStatus{
+					Conditions: []metav1.Condition{{
+						Type:   controlplanev1alpha1.PatchesAppliedCondition,
+						Status: metav1.ConditionUnknown,
+					}},
+				},
+			}
+
+			// test
+			setPatchesCondition(kinkCP, tc.err)
+
+			// validate
+			actual := meta.FindStatusCondition(kinkCP.Status.Conditions, controlplanev1alpha1.PatchesAppliedCondition)
+			if tc.expected == nil {
+				assert.Nil(t, actual)
+				return
+			}
+			require.NotNil(t, actual)
+			assert.Equal(t, tc.expected.Status, actual.Status)
+			assert.Equal(t, tc.expected.Reason, actual.Reason)
+			if tc.expected.Message != "" {
+				assert.Equal(t, tc.expected.Message, actual.Message)
+			}
+		})
+	}
+}
@@ -0,0 +1,173 @@
+// Copyright 2025 anza-labs contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controlplane
+
+import (
+	cmv1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmetav1 "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	netv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	gatewayapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// ownedObjectPredicate triggers a reconcile when an owned object's spec changes, or when
+// its status changes in a way that affects the status or the endpoint of the control plane.
+func ownedObjectPredicate() predicate.Predicate {
+	return predicate.Or(
+		predicate.GenerationChangedPredicate{},
+		predicate.Funcs{
+			UpdateFunc: func(e event.UpdateEvent) bool {
+				if e.ObjectOld == nil || e.ObjectNew == nil {
+					return false
+				}
+				return ownedObjectChanged(e.ObjectOld, e.ObjectNew)
+			},
+		},
+	)
+}
+
+// endpointNodePredicate triggers a reconcile when a node goes away, or its readiness, addresses or
+// labels change, so that the control planes using the address of it as endpoint select another one.
+func endpointNodePredicate() predicate.Predicate {
+	return predicate.Funcs{
+		CreateFunc:  func(event.CreateEvent) bool { return false },
+		DeleteFunc:  func(event.DeleteEvent) bool { return true },
+		GenericFunc: func(event.GenericEvent) bool { return false },
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldNode, ok := e.ObjectOld.(*corev1.Node)
+			if !ok {
+				return false
+			}
+			newNode, ok := e.ObjectNew.(*corev1.Node)
+			if !ok {
+				return false
+			}
+			return nodeReady(oldNode) != nodeReady(newNode) ||
+				!equality.Semantic.DeepEqual(oldNode.Status.Addresses, newNode.Status.Addresses) ||
+				!equality.Semantic.DeepEqual(oldNode.Labels, newNode.Labels)
+		},
+	}
+}
+
+// deletedPredicate triggers a reconcile when an object is deleted only.
+func deletedPredicate() predicate.Predicate {
+	return predicate.Funcs{
+		CreateFunc:  func(event.CreateEvent) bool { return false },
+		UpdateFunc:  func(event.UpdateEvent) bool { return false },
+		DeleteFunc:  func(event.DeleteEvent) bool { return true },
+		GenericFunc: func(event.GenericEvent) bool { return false },
+	}
+}
+
+// ownedObjectChanged reports whether there is a relevant change between the two versions
+// of the object, which is not reflected by the generation.
+func ownedObjectChanged(oldObj, newObj client.Object) bool {
+	switch newObj := newObj.(type) {
+	case *appsv1.Deployment:
+		oldObj, ok := oldObj.(*appsv1.Deployment)
+		if !ok {
+			return true
+		}
+		return deploymentStatusChanged(oldObj.Status, newObj.Status)
+
+	case *corev1.Service:
+		// Services are not versioned with generation, so spec changes are detected here.
+		oldObj, ok := oldObj.(*corev1.Service)
+		if !ok {
+			return true
+		}
+		return !equality.Semantic.DeepEqual(oldObj.Spec, newObj.Spec) ||
+			!equality.Semantic.DeepEqual(oldObj.Status.LoadBalancer, newObj.Status.LoadBalancer)
+
+	case *corev1.ConfigMap:
+		oldObj, ok := oldObj.(*corev1.ConfigMap)
+		if !ok {
+			return true
+		}
+		return !equality.Semantic.DeepEqual(oldObj.Data, newObj.Data) ||
+			!equality.Semantic.DeepEqual(oldObj.BinaryData, newObj.BinaryData)
+
+	case *corev1.Secret:
+		oldObj, ok := oldObj.(*corev1.Secret)
+		if !ok {
+			return true
+		}
+		return !equality.Semantic.DeepEqual(oldObj.Data, newObj.Data)
+
+	case *cmv1.Certificate:
+		oldObj, ok := oldObj.(*cmv1.Certificate)
+		if !ok {
+			return true
+		}
+		return certificateReady(oldObj) != certificateReady(newObj) ||
+			!equality.Semantic.DeepEqual(oldObj.Status.NotAfter, newObj.Status.NotAfter) ||
+			!equality.Semantic.DeepEqual(oldObj.Status.Revision, newObj.Status.Revision)
+
+	case *gatewayapiv1.Gateway:
+		oldObj, ok := oldObj.(*gatewayapiv1.Gateway)
+		if !ok {
+			return true
+		}
+		return !equality.Semantic.DeepEqual(oldObj.Status.Addresses, newObj.Status.Addresses) ||
+			conditionStatus(oldObj.Status.Conditions, string(gatewayapiv1.GatewayConditionProgrammed)) !=
+				conditionStatus(newObj.Status.Conditions, string(gatewayapiv1.GatewayConditionProgrammed))
+
+	case *netv1.Ingress:
+		oldObj, ok := oldObj.(*netv1.Ingress)
+		if !ok {
+			return true
+		}
+		return !equality.Semantic.DeepEqual(oldObj.Status.LoadBalancer, newObj.Status.LoadBalancer)
+
+	default:
+		return false
+	}
+}
+
+// deploymentStatusChanged reports whether the replica counts of the Deployment changed.
+func deploymentStatusChanged(oldStatus, newStatus appsv1.DeploymentStatus) bool {
+	return oldStatus.ObservedGeneration != newStatus.ObservedGeneration ||
+		oldStatus.Replicas != newStatus.Replicas ||
+		oldStatus.ReadyReplicas != newStatus.ReadyReplicas ||
+		oldStatus.AvailableReplicas != newStatus.AvailableReplicas ||
+		oldStatus.UpdatedReplicas != newStatus.UpdatedReplicas ||
+		oldStatus.UnavailableReplicas != newStatus.UnavailableReplicas
+}
+
+// certificateReady returns the status of the Ready condition of the Certificate.
+func certificateReady(cert *cmv1.Certificate) cmmetav1.ConditionStatus {
+	for _, cond := range cert.Status.Conditions {
+		if cond.Type == cmv1.CertificateConditionReady {
+			return cond.Status
+		}
+	}
+	return cmmetav1.ConditionUnknown
+}
+
+// conditionStatus returns the status of the condition, or Unknown if it is not set.
+func conditionStatus(conditions []metav1.Condition, conditionType string) metav1.ConditionStatus {
+	if cond := meta.FindStatusCondition(conditions, conditionType); cond != nil {
+		return cond.Status
+	}
+	return metav1.ConditionUnknown
+}
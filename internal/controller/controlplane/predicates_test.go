@@ -0,0 +1,179 @@
+// Copyright 2025 anza-labs contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controlplane
+
+import (
+	"testing"
+
+	cmv1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmetav1 "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/stretchr/testify/assert"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	netv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	gatewayapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+func TestOwnedObjectPredicate(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		oldObj   client.Object
+		newObj   client.Object
+		expected bool
+	}{
+		"DeploymentGenerationChanged": {
+			oldObj:   &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Generation: 1}},
+			newObj:   &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Generation: 2}},
+			expected: true,
+		},
+		"DeploymentBecameReady": {
+			oldObj:   &appsv1.Deployment{Status: appsv1.DeploymentStatus{ReadyReplicas: 0}},
+			newObj:   &appsv1.Deployment{Status: appsv1.DeploymentStatus{ReadyReplicas: 1}},
+			expected: true,
+		},
+		"DeploymentUnchanged": {
+			oldObj:   &appsv1.Deployment{Status: appsv1.DeploymentStatus{ReadyReplicas: 1}},
+			newObj:   &appsv1.Deployment{Status: appsv1.DeploymentStatus{ReadyReplicas: 1}},
+			expected: false,
+		},
+		"ServiceLoadBalancerAssigned": {
+			oldObj: &corev1.Service{},
+			newObj: &corev1.Service{Status: corev1.ServiceStatus{LoadBalancer: corev1.LoadBalancerStatus{
+				Ingress: []corev1.LoadBalancerIngress{{IP: "192.0.2.10"}},
+			}}},
+			expected: true,
+		},
+		"ServiceSpecChanged": {
+			oldObj:   &corev1.Service{Spec: corev1.ServiceSpec{Type: corev1.ServiceTypeClusterIP}},
+			newObj:   &corev1.Service{Spec: corev1.ServiceSpec{Type: corev1.ServiceTypeNodePort}},
+			expected: true,
+		},
+		"ServiceUnchanged": {
+			oldObj:   &corev1.Service{ObjectMeta: metav1.ObjectMeta{ResourceVersion: "1"}},
+			newObj:   &corev1.Service{ObjectMeta: metav1.ObjectMeta{ResourceVersion: "2"}},
+			expected: false,
+		},
+		"SecretDataChanged": {
+			oldObj:   &corev1.Secret{Data: map[string][]byte{"value": []byte("a")}},
+			newObj:   &corev1.Secret{Data: map[string][]byte{"value": []byte("b")}},
+			expected: true,
+		},
+		"CertificateBecameReady": {
+			oldObj: &cmv1.Certificate{},
+			newObj: &cmv1.Certificate{Status: cmv1.CertificateStatus{Conditions: []cmv1.CertificateCondition{{
+				Type:   cmv1.CertificateConditionReady,
+				Status: cmmetav1.ConditionTrue,
+			}}}},
+			expected: true,
+		},
+		"CertificateUnrelatedStatusChange": {
+			oldObj:   &cmv1.Certificate{},
+			newObj:   &cmv1.Certificate{Status: cmv1.CertificateStatus{FailedIssuanceAttempts: new(int)}},
+			expected: false,
+		},
+		"GatewayProgrammed": {
+			oldObj: &gatewayapiv1.Gateway{},
+			newObj: &gatewayapiv1.Gateway{Status: gatewayapiv1.GatewayStatus{Conditions: []metav1.Condition{{
+				Type:   string(gatewayapiv1.GatewayConditionProgrammed),
+				Status: metav1.ConditionTrue,
+			}}}},
+			expected: true,
+		},
+		"IngressAddressAssigned": {
+			oldObj: &netv1.Ingress{},
+			newObj: &netv1.Ingress{Status: netv1.IngressStatus{LoadBalancer: netv1.IngressLoadBalancerStatus{
+				Ingress: []netv1.IngressLoadBalancerIngress{{Hostname: "api.example.com"}},
+			}}},
+			expected: true,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			// prepare
+			p := ownedObjectPredicate()
+
+			// test
+			actual := p.Update(event.UpdateEvent{ObjectOld: tc.oldObj, ObjectNew: tc.newObj})
+
+			// validate
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
+func TestEndpointNodePredicate(t *testing.T) {
+	t.Parallel()
+
+	node := func(ready corev1.ConditionStatus, address string, labels map[string]string) *corev1.Node {
+		return &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: "a", Labels: labels},
+			Status: corev1.NodeStatus{
+				Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: ready}},
+				Addresses:  []corev1.NodeAddress{{Type: corev1.NodeInternalIP, Address: address}},
+			},
+		}
+	}
+
+	for name, tc := range map[string]struct {
+		oldObj   client.Object
+		newObj   client.Object
+		expected bool
+	}{
+		"BecameNotReady": {
+			oldObj:   node(corev1.ConditionTrue, "10.0.0.1", nil),
+			newObj:   node(corev1.ConditionUnknown, "10.0.0.1", nil),
+			expected: true,
+		},
+		"BecameReady": {
+			oldObj:   node(corev1.ConditionFalse, "10.0.0.1", nil),
+			newObj:   node(corev1.ConditionTrue, "10.0.0.1", nil),
+			expected: true,
+		},
+		"AddressChanged": {
+			oldObj:   node(corev1.ConditionTrue, "10.0.0.1", nil),
+			newObj:   node(corev1.ConditionTrue, "10.0.0.2", nil),
+			expected: true,
+		},
+		"LabelsChanged": {
+			oldObj:   node(corev1.ConditionTrue, "10.0.0.1", nil),
+			newObj:   node(corev1.ConditionTrue, "10.0.0.1", map[string]string{"edge": "true"}),
+			expected: true,
+		},
+		"HeartbeatOnly": {
+			oldObj:   node(corev1.ConditionTrue, "10.0.0.1", nil),
+			newObj:   node(corev1.ConditionTrue, "10.0.0.1", nil),
+			expected: false,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			// prepare
+			p := endpointNodePredicate()
+
+			// test
+			actual := p.Update(event.UpdateEvent{ObjectOld: tc.oldObj, ObjectNew: tc.newObj})
+
+			// validate
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
@@ -0,0 +1,325 @@
+// Copyright 2025 anza-labs contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controlplane
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	controlplanev1alpha1 "github.com/anza-labs/kink/api/controlplane/v1alpha1"
+	"github.com/anza-labs/kink/internal/manifests/controlplane"
+	"github.com/anza-labs/kink/internal/manifests/manifestutils"
+	"github.com/anza-labs/kink/internal/naming"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	// restartedAtAnnotation is set on the pod template to restart the rollout of a Deployment,
+	// the same way `kubectl rollout restart` does.
+	restartedAtAnnotation = "control-plane.kink.anza-labs.dev/restartedAt"
+
+	// remediationFieldManager is the field manager used for remediation patches, so the
+	// restart annotation is not claimed by the server-side apply field manager.
+	remediationFieldManager = "kink-remediation"
+
+	// defaultMaxUnhealthyDuration is used when the policy does not specify the duration.
+	defaultMaxUnhealthyDuration = 5 * time.Minute
+
+	// defaultMaxRetries is used when the policy does not specify the retry budget.
+	defaultMaxRetries = 3
+)
+
+// remediationTarget binds a control plane component to its Deployment and remediation policy.
+type remediationTarget struct {
+	component  string
+	deployment string
+	policy     controlplanev1alpha1.RemediationPolicy
+}
+
+// remediationTargets returns the components with a remediation policy.
+func remediationTargets(kinkCP *controlplanev1alpha1.KinkControlPlane) []remediationTarget {
+	remediation := kinkCP.Spec.Remediation
+	if remediation == nil {
+		return nil
+	}
+
+	candidates := []struct {
+		component  string
+		deployment string
+		policy     *controlplanev1alpha1.RemediationPolicy
+	}{
+		{controlplane.ComponentKine, naming.Kine(kinkCP.Name), remediation.Kine},
+		{controlplane.ComponentAPIServer, naming.APIServer(kinkCP.Name), remediation.APIServer},
+		{controlplane.ComponentControllerManager, naming.ControllerManager(kinkCP.Name), remediation.ControllerManager},
+		{controlplane.ComponentScheduler, naming.Scheduler(kinkCP.Name), remediation.Scheduler},
+	}
+
+	targets := []remediationTarget{}
+	for _, c := range candidates {
+		if c.policy == nil {
+			continue
+		}
+
+		policy := *c.policy
+		if policy.MaxUnhealthyDuration.Duration <= 0 {
+			policy.MaxUnhealthyDuration = metav1.Duration{Duration: defaultMaxUnhealthyDuration}
+		}
+		if policy.Action == "" {
+			policy.Action = controlplanev1alpha1.RemediationActionDeletePod
+		}
+		if policy.MaxRetries <= 0 {
+			policy.MaxRetries = defaultMaxRetries
+		}
+
+		targets = append(targets, remediationTarget{
+			component:  c.component,
+			deployment: c.deployment,
+			policy:     policy,
+		})
+	}
+	return targets
+}
+
+// unhealthyPods returns the pods which have not been ready for longer than maxUnhealthy, and
+// the time left until the next not ready pod exceeds maxUnhealthy, or zero if there is none.
+// Pods that are being deleted are ignored.
+func unhealthyPods(pods []corev1.Pod, maxUnhealthy time.Duration, now time.Time) ([]corev1.Pod, time.Duration) {
+	unhealthy := []corev1.Pod{}
+	var next time.Duration
+	for _, pod := range pods {
+		if !pod.DeletionTimestamp.IsZero() {
+			continue
+		}
+
+		since := pod.CreationTimestamp.Time
+		ready := false
+		for _, cond := range pod.Status.Conditions {
+			if cond.Type != corev1.PodReady {
+				continue
+			}
+			ready = cond.Status == corev1.ConditionTrue
+			if !cond.LastTransitionTime.IsZero() {
+				since = cond.LastTransitionTime.Time
+			}
+		}
+
+		if ready {
+			continue
+		}
+		if left := maxUnhealthy - now.Sub(since); left <= 0 {
+			unhealthy = append(unhealthy, pod)
+		} else if next == 0 || left < next {
+			next = left
+		}
+	}
+	return unhealthy, next
+}
+
+// reconcileRemediation takes the configured remediation action against components whose pods
+// have been unhealthy for longer than allowed. Every attempt is recorded in the status and as an Event.
+// The returned duration is the time left until the next remediation may be due, or zero if there is none.
+func (r *KinkControlPlaneReconciler) reconcileRemediation(
+	ctx context.Context,
+	kinkCP *controlplanev1alpha1.KinkControlPlane,
+) (time.Duration, error) {
+	log := log.FromContext(ctx)
+
+	targets := remediationTargets(kinkCP)
+	if len(targets) == 0 {
+		kinkCP.Status.Remediations = nil
+		meta.RemoveStatusCondition(&kinkCP.Status.Conditions, controlplanev1alpha1.RemediationAllowedCondition)
+		return 0, nil
+	}
+
+	now := time.Now()
+	exhausted := []string{}
+
+	// Pod events are not emitted when a pod exceeds the allowed duration, so the control plane
+	// is requeued at the earliest deadline.
+	var requeueAfter time.Duration
+	requeueAt := func(after time.Duration) {
+		if after > 0 && (requeueAfter == 0 || after < requeueAfter) {
+			requeueAfter = after
+		}
+	}
+
+	var errs error
+	for _, target := range targets {
+		l := log.WithValues("component", target.component)
+
+		pods := &corev1.PodList{}
+		if err := r.List(ctx, pods,
+			client.InNamespace(kinkCP.Namespace),
+			client.MatchingLabels(manifestutils.SelectorLabels(
+				kinkCP.ObjectMeta,
+				target.component, controlplane.ConceptControlPlane,
+			)),
+		); err != nil {
+			errs = errors.Join(errs, fmt.Errorf("failed to list pods of %s: %w", target.component, err))
+			continue
+		}
+
+		unhealthy, next := unhealthyPods(pods.Items, target.policy.MaxUnhealthyDuration.Duration, now)
+		requeueAt(next)
+		if len(unhealthy) == 0 {
+			if idx := remediationStatusIndex(kinkCP.Status.Remediations, target.component); idx >= 0 {
+				l.V(2).Info("Component is healthy again, resetting remediation budget")
+				r.Recorder.Eventf(kinkCP, corev1.EventTypeNormal, "Remediated",
+					"Component %s is healthy after %d remediation attempt(s)",
+					target.component, kinkCP.Status.Remediations[idx].Attempts)
+				kinkCP.Status.Remediations = slices.Delete(kinkCP.Status.Remediations, idx, idx+1)
+			}
+			continue
+		}
+
+		idx := remediationStatusIndex(kinkCP.Status.Remediations, target.component)
+		if idx < 0 {
+			kinkCP.Status.Remediations = append(kinkCP.Status.Remediations, controlplanev1alpha1.RemediationStatus{
+				Component: target.component,
+			})
+			idx = len(kinkCP.Status.Remediations) - 1
+		}
+		status := &kinkCP.Status.Remediations[idx]
+
+		if status.Attempts >= target.policy.MaxRetries {
+			l.V(2).Info("Remediation budget exhausted", "attempts", status.Attempts)
+			exhausted = append(exhausted, target.component)
+			continue
+		}
+
+		if status.LastAttemptTime != nil {
+			if left := target.policy.MaxUnhealthyDuration.Duration - now.Sub(status.LastAttemptTime.Time); left > 0 {
+				l.V(3).Info("Waiting for the previous remediation attempt to take effect")
+				requeueAt(left)
+				continue
+			}
+		}
+
+		status.Attempts++
+		status.LastAction = target.policy.Action
+		status.LastAttemptTime = &metav1.Time{Time: now}
+		requeueAt(target.policy.MaxUnhealthyDuration.Duration)
+
+		l.Info("Remediating unhealthy component",
+			"action", target.policy.Action,
+			"attempt", status.Attempts,
+			"unhealthy_pods", len(unhealthy),
+		)
+		if err := r.remediate(ctx, kinkCP, target, unhealthy, now); err != nil {
+			r.Recorder.Eventf(kinkCP, corev1.EventTypeWarning, "RemediationFailed",
+				"Failed to remediate component %s with %s (attempt %d of %d): %v",
+				target.component, target.policy.Action, status.Attempts, target.policy.MaxRetries, err)
+			errs = errors.Join(errs, fmt.Errorf("failed to remediate %s: %w", target.component, err))
+			continue
+		}
+		r.Recorder.Eventf(kinkCP, corev1.EventTypeNormal, "Remediating",
+			"Remediated component %s with %s (attempt %d of %d)",
+			target.component, target.policy.Action, status.Attempts, target.policy.MaxRetries)
+	}
+
+	if len(exhausted) > 0 {
+		r.Recorder.Eventf(kinkCP, corev1.EventTypeWarning, "RemediationBudgetExhausted",
+			"Remediation budget exhausted for: %s", strings.Join(exhausted, ", "))
+		meta.SetStatusCondition(&kinkCP.Status.Conditions, metav1.Condition{
+			Type:               controlplanev1alpha1.RemediationAllowedCondition,
+			Status:             metav1.ConditionFalse,
+			Reason:             controlplanev1alpha1.RemediationBudgetExhaustedReason,
+			Message:            fmt.Sprintf("Remediation budget exhausted for: %s", strings.Join(exhausted, ", ")),
+			ObservedGeneration: kinkCP.Generation,
+		})
+	} else {
+		meta.SetStatusCondition(&kinkCP.Status.Conditions, metav1.Condition{
+			Type:               controlplanev1alpha1.RemediationAllowedCondition,
+			Status:             metav1.ConditionTrue,
+			Reason:             controlplanev1alpha1.RemediationBudgetAvailableReason,
+			ObservedGeneration: kinkCP.Generation,
+		})
+	}
+
+	return requeueAfter, errs
+}
+
+// remediate executes the remediation action of the target.
+func (r *KinkControlPlaneReconciler) remediate(
+	ctx context.Context,
+	kinkCP *controlplanev1alpha1.KinkControlPlane,
+	target remediationTarget,
+	unhealthy []corev1.Pod,
+	now time.Time,
+) error {
+	switch target.policy.Action {
+	case controlplanev1alpha1.RemediationActionDeletePod:
+		var errs error
+		for i := range unhealthy {
+			if err := r.Delete(ctx, &unhealthy[i]); client.IgnoreNotFound(err) != nil {
+				errs = errors.Join(errs, err)
+			}
+		}
+		return errs
+
+	case controlplanev1alpha1.RemediationActionRestartRollout:
+		return r.restartDeployment(ctx, types.NamespacedName{
+			Name:      target.deployment,
+			Namespace: kinkCP.Namespace,
+		}, now)
+
+	case controlplanev1alpha1.RemediationActionRestartKine:
+		return r.restartDeployment(ctx, types.NamespacedName{
+			Name:      naming.Kine(kinkCP.Name),
+			Namespace: kinkCP.Namespace,
+		}, now)
+
+	default:
+		return fmt.Errorf("unsupported remediation action: %s", target.policy.Action)
+	}
+}
+
+// restartDeployment triggers a new rollout of the Deployment by updating the pod template annotation.
+func (r *KinkControlPlaneReconciler) restartDeployment(
+	ctx context.Context,
+	key types.NamespacedName,
+	now time.Time,
+) error {
+	depl := &appsv1.Deployment{}
+	if err := r.Get(ctx, key, depl); err != nil {
+		return fmt.Errorf("failed to get deployment %s: %w", key, err)
+	}
+
+	patch := client.MergeFrom(depl.DeepCopy())
+	if depl.Spec.Template.Annotations == nil {
+		depl.Spec.Template.Annotations = map[string]string{}
+	}
+	depl.Spec.Template.Annotations[restartedAtAnnotation] = now.Format(time.RFC3339)
+
+	return r.Patch(ctx, depl, patch, client.FieldOwner(remediationFieldManager))
+}
+
+// remediationStatusIndex returns the index of the component in the remediation status, or -1.
+func remediationStatusIndex(statuses []controlplanev1alpha1.RemediationStatus, component string) int {
+	return slices.IndexFunc(statuses, func(s controlplanev1alpha1.RemediationStatus) bool {
+		return s.Component == component
+	})
+}
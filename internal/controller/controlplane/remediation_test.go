@@ -0,0 +1,174 @@
+// Copyright 2025 anza-labs contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controlplane
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	controlplanev1alpha1 "github.com/anza-labs/kink/api/controlplane/v1alpha1"
+	"github.com/anza-labs/kink/internal/manifests/controlplane"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+func TestRemediationTargets(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		remediation *controlplanev1alpha1.Remediation
+		expected    []remediationTarget
+	}{
+		"disabled": {
+			remediation: nil,
+			expected:    nil,
+		},
+		"defaults": {
+			remediation: &controlplanev1alpha1.Remediation{
+				APIServer: &controlplanev1alpha1.RemediationPolicy{},
+			},
+			expected: []remediationTarget{
+				{
+					component:  controlplane.ComponentAPIServer,
+					deployment: "test-api-server",
+					policy: controlplanev1alpha1.RemediationPolicy{
+						MaxUnhealthyDuration: metav1.Duration{Duration: defaultMaxUnhealthyDuration},
+						Action:               controlplanev1alpha1.RemediationActionDeletePod,
+						MaxRetries:           defaultMaxRetries,
+					},
+				},
+			},
+		},
+		"custom": {
+			remediation: &controlplanev1alpha1.Remediation{
+				Kine: &controlplanev1alpha1.RemediationPolicy{
+					MaxUnhealthyDuration: metav1.Duration{Duration: time.Minute},
+					Action:               controlplanev1alpha1.RemediationActionRestartRollout,
+					MaxRetries:           1,
+				},
+			},
+			expected: []remediationTarget{
+				{
+					component:  controlplane.ComponentKine,
+					deployment: "test-kine",
+					policy: controlplanev1alpha1.RemediationPolicy{
+						MaxUnhealthyDuration: metav1.Duration{Duration: time.Minute},
+						Action:               controlplanev1alpha1.RemediationActionRestartRollout,
+						MaxRetries:           1,
+					},
+				},
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			// prepare
+			kcp := &controlplanev1alpha1.KinkControlPlane{
+				ObjectMeta: metav1.ObjectMeta{Name: "test"},
+				Spec: controlplanev1alpha1.KinkControlPlaneSpec{
+					Remediation: tc.remediation,
+				},
+			}
+
+			// test
+			actual := remediationTargets(kcp)
+
+			// validate
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
+func TestUnhealthyPods(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	pod := func(name string, ready corev1.ConditionStatus, since time.Time, deleted bool) corev1.Pod {
+		p := corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				CreationTimestamp: metav1.NewTime(since),
+			},
+			Status: corev1.PodStatus{
+				Conditions: []corev1.PodCondition{
+					{
+						Type:               corev1.PodReady,
+						Status:             ready,
+						LastTransitionTime: metav1.NewTime(since),
+					},
+				},
+			},
+		}
+		if deleted {
+			p.DeletionTimestamp = ptr.To(metav1.NewTime(now))
+		}
+		return p
+	}
+
+	for name, tc := range map[string]struct {
+		pods         []corev1.Pod
+		expected     []string
+		expectedNext time.Duration
+	}{
+		"all_ready": {
+			pods: []corev1.Pod{
+				pod("a", corev1.ConditionTrue, now.Add(-time.Hour), false),
+			},
+			expected: []string{},
+		},
+		"recently_unready": {
+			pods: []corev1.Pod{
+				pod("a", corev1.ConditionFalse, now.Add(-time.Minute), false),
+				pod("b", corev1.ConditionFalse, now.Add(-2*time.Minute), false),
+			},
+			expected:     []string{},
+			expectedNext: 3 * time.Minute,
+		},
+		"unready_for_too_long": {
+			pods: []corev1.Pod{
+				pod("a", corev1.ConditionFalse, now.Add(-time.Hour), false),
+				pod("b", corev1.ConditionTrue, now.Add(-time.Hour), false),
+			},
+			expected: []string{"a"},
+		},
+		"terminating": {
+			pods: []corev1.Pod{
+				pod("a", corev1.ConditionFalse, now.Add(-time.Hour), true),
+			},
+			expected: []string{},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			// test
+			actual, next := unhealthyPods(tc.pods, 5*time.Minute, now)
+
+			// validate
+			names := []string{}
+			for _, p := range actual {
+				names = append(names, p.Name)
+			}
+			assert.Equal(t, tc.expected, names)
+			assert.Equal(t, tc.expectedNext, next)
+		})
+	}
+}
@@ -0,0 +1,263 @@
+// Copyright 2025 anza-labs contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controlplane
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"maps"
+	"slices"
+
+	controlplanev1alpha1 "github.com/anza-labs/kink/api/controlplane/v1alpha1"
+	"github.com/anza-labs/kink/internal/manifests/controlplane"
+	"github.com/anza-labs/kink/internal/manifests/manifestutils"
+	"github.com/anza-labs/kink/internal/naming"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// certificatesHashAnnotation is set on the pod template to the hash of the certificates in
+// the Secrets mounted by the pods. A changed hash rolls out the Deployment, so that the
+// components pick up rotated certificates and kubeconfigs.
+const certificatesHashAnnotation = "control-plane.kink.anza-labs.dev/certificates-hash"
+
+// certificateKeys are the keys of the mounted Secrets which are hashed. Kubeconfigs embed the
+// client certificate and the CA, so they are hashed as well. The other keys, as well as the
+// metadata of the Secrets, do not restart the components.
+var certificateKeys = []string{corev1.TLSCertKey, "ca.crt", "value"}
+
+// certificatesRolloutAnnotation is set on a Deployment to the certificates hash it is being
+// restarted with, until the restart is rolled out. Only these restarts hold back the components
+// following it in the rollout order, so a component failing for other reasons does not keep
+// the others on expiring certificates.
+const certificatesRolloutAnnotation = "control-plane.kink.anza-labs.dev/certificates-rollout"
+
+// rolloutOrder is the order in which the components are restarted after a rotation. Every
+// component waits for the restart of the previous ones to be rolled out.
+var rolloutOrder = []string{
+	controlplane.ComponentKine,
+	controlplane.ComponentAPIServer,
+	controlplane.ComponentControllerManager,
+	controlplane.ComponentScheduler,
+}
+
+// annotateCertificatesHash sets the certificates hash annotation on the desired Deployments.
+// A component is only restarted with a new hash after the restarts of the components preceding
+// it in the rollout order have been rolled out, or have exceeded their progress deadline; until
+// then, it keeps its current hash.
+func (r *KinkControlPlaneReconciler) annotateCertificatesHash(
+	ctx context.Context,
+	kinkCP *controlplanev1alpha1.KinkControlPlane,
+	objects []client.Object,
+) error {
+	log := log.FromContext(ctx)
+
+	deployments := map[string]*appsv1.Deployment{}
+	for _, obj := range objects {
+		if depl, ok := obj.(*appsv1.Deployment); ok {
+			deployments[depl.Labels[manifestutils.LabelComponent]] = depl
+		}
+	}
+
+	// blocker is the preceding component whose restart is still rolling out.
+	blocker := ""
+	for _, component := range rolloutOrder {
+		depl, ok := deployments[component]
+		if !ok {
+			continue
+		}
+
+		certs, err := r.secretCertificates(ctx, kinkCP.Namespace, depl.Spec.Template.Spec.Volumes)
+		if err != nil {
+			return fmt.Errorf("failed to get secrets of %s: %w", component, err)
+		}
+		hash := certificatesHash(certs)
+
+		live := &appsv1.Deployment{}
+		if err := r.Get(ctx, client.ObjectKeyFromObject(depl), live); apierrors.IsNotFound(err) {
+			live = nil
+		} else if err != nil {
+			return fmt.Errorf("failed to get deployment of %s: %w", component, err)
+		}
+
+		if live != nil {
+			current := live.Spec.Template.Annotations[certificatesHashAnnotation]
+			restarting := false
+			switch {
+			case current != hash && blocker != "":
+				log.V(2).Info("Deferring restart until the preceding components are rolled out",
+					"component", component, "blocker", blocker)
+				r.Recorder.Eventf(kinkCP, corev1.EventTypeNormal, "RestartDeferred",
+					"Deferring the restart of %s to reload rotated certificates until %s is rolled out",
+					component, blocker)
+				hash = current
+
+			case current != hash:
+				log.Info("Restarting component to reload rotated certificates", "component", component)
+				restarting = true
+
+			case live.Annotations[certificatesRolloutAnnotation] == current && !rolledOut(live):
+				restarting = true
+				if progressDeadlineExceeded(live) {
+					log.Info("Restart did not complete within the progress deadline, no longer waiting for it",
+						"component", component)
+					r.Recorder.Eventf(kinkCP, corev1.EventTypeWarning, "RestartStalled",
+						"Restart of %s to reload rotated certificates exceeded its progress deadline", component)
+					restarting = false
+				}
+			}
+
+			if restarting && hash != "" {
+				blocker = component
+				if depl.Annotations == nil {
+					depl.Annotations = map[string]string{}
+				}
+				depl.Annotations[certificatesRolloutAnnotation] = hash
+			}
+		}
+
+		if hash == "" {
+			continue
+		}
+		if depl.Spec.Template.Annotations == nil {
+			depl.Spec.Template.Annotations = map[string]string{}
+		}
+		depl.Spec.Template.Annotations[certificatesHashAnnotation] = hash
+	}
+
+	return nil
+}
+
+// secretCertificates returns the certificate keys of the Secrets mounted by the volumes.
+// Secrets which do not exist yet are reported without data.
+func (r *KinkControlPlaneReconciler) secretCertificates(
+	ctx context.Context,
+	namespace string,
+	volumes []corev1.Volume,
+) (map[string]map[string][]byte, error) {
+	certs := map[string]map[string][]byte{}
+	for _, name := range secretNames(volumes) {
+		secret := &corev1.Secret{}
+		err := r.Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, secret)
+		if client.IgnoreNotFound(err) != nil {
+			return nil, err
+		}
+		data := map[string][]byte{}
+		for _, key := range certificateKeys {
+			if value, ok := secret.Data[key]; ok {
+				data[key] = value
+			}
+		}
+		certs[name] = data
+	}
+	return certs, nil
+}
+
+// secretNames returns the names of the Secrets mounted by the volumes.
+func secretNames(volumes []corev1.Volume) []string {
+	names := []string{}
+	for _, vol := range volumes {
+		switch {
+		case vol.Secret != nil:
+			names = append(names, vol.Secret.SecretName)
+		case vol.Projected != nil:
+			for _, src := range vol.Projected.Sources {
+				if src.Secret != nil {
+					names = append(names, src.Secret.Name)
+				}
+			}
+		}
+	}
+	slices.Sort(names)
+	return slices.Compact(names)
+}
+
+// certificatesHash computes a stable hash of the certificates, keyed by the Secret name and
+// the key within the Secret.
+func certificatesHash(certs map[string]map[string][]byte) string {
+	if len(certs) == 0 {
+		return ""
+	}
+
+	h := sha256.New()
+	for _, name := range slices.Sorted(maps.Keys(certs)) {
+		data := certs[name]
+		h.Write([]byte(name))
+		h.Write([]byte{0})
+		for _, key := range slices.Sorted(maps.Keys(data)) {
+			h.Write([]byte(key))
+			h.Write([]byte{0})
+			h.Write(data[key])
+			h.Write([]byte{0})
+		}
+		// Separates the Secrets, so that the keys of a Secret cannot be read as another Secret.
+		h.Write([]byte{1})
+	}
+
+	return fmt.Sprintf("%x", h.Sum(nil))
+}
+
+// progressDeadlineExceeded reports whether the rollout of the current pod template of the
+// Deployment has exceeded its progress deadline.
+func progressDeadlineExceeded(depl *appsv1.Deployment) bool {
+	if depl.Status.ObservedGeneration < depl.Generation {
+		return false
+	}
+	for _, cond := range depl.Status.Conditions {
+		if cond.Type == appsv1.DeploymentProgressing {
+			return cond.Status == corev1.ConditionFalse && cond.Reason == "ProgressDeadlineExceeded"
+		}
+	}
+	return false
+}
+
+// rolledOut reports whether all replicas of the Deployment run the latest pod template.
+func rolledOut(depl *appsv1.Deployment) bool {
+	desired := int32(1)
+	if depl.Spec.Replicas != nil {
+		desired = *depl.Spec.Replicas
+	}
+	return depl.Status.ObservedGeneration >= depl.Generation &&
+		depl.Status.UpdatedReplicas >= desired &&
+		depl.Status.AvailableReplicas >= desired &&
+		depl.Status.Replicas == depl.Status.UpdatedReplicas
+}
+
+// verifyingServiceAccountKeys returns the IDs of the public keys verifying the service account
+// tokens on all the API server replicas, or none while the API server is rolling out.
+func (r *KinkControlPlaneReconciler) verifyingServiceAccountKeys(
+	ctx context.Context,
+	kinkCP *controlplanev1alpha1.KinkControlPlane,
+) (sets.Set[string], error) {
+	depl := &appsv1.Deployment{}
+	key := types.NamespacedName{Name: naming.APIServer(kinkCP.Name), Namespace: kinkCP.Namespace}
+	if err := r.Get(ctx, key, depl); apierrors.IsNotFound(err) {
+		return sets.New[string](), nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to get deployment of %s: %w", controlplane.ComponentAPIServer, err)
+	}
+
+	if !rolledOut(depl) {
+		return sets.New[string](), nil
+	}
+	return controlplane.VerifyingServiceAccountKeys(depl), nil
+}
@@ -0,0 +1,435 @@
+// Copyright 2025 anza-labs contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controlplane
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	controlplanev1alpha1 "github.com/anza-labs/kink/api/controlplane/v1alpha1"
+	"github.com/anza-labs/kink/internal/manifests/controlplane"
+	"github.com/anza-labs/kink/internal/manifests/manifestutils"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestSecretNames(t *testing.T) {
+	t.Parallel()
+
+	// prepare
+	volumes := []corev1.Volume{
+		{Name: "cert", VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{SecretName: "b"},
+		}},
+		{Name: "config", VolumeSource: corev1.VolumeSource{
+			ConfigMap: &corev1.ConfigMapVolumeSource{},
+		}},
+		{Name: "projected", VolumeSource: corev1.VolumeSource{
+			Projected: &corev1.ProjectedVolumeSource{Sources: []corev1.VolumeProjection{
+				{Secret: &corev1.SecretProjection{LocalObjectReference: corev1.LocalObjectReference{Name: "a"}}},
+				{Secret: &corev1.SecretProjection{LocalObjectReference: corev1.LocalObjectReference{Name: "b"}}},
+			}},
+		}},
+	}
+
+	// test
+	actual := secretNames(volumes)
+
+	// validate
+	assert.Equal(t, []string{"a", "b"}, actual)
+}
+
+func TestCertificatesHash(t *testing.T) {
+	t.Parallel()
+
+	base := certificatesHash(map[string]map[string][]byte{
+		"a": {"tls.crt": []byte("a1"), "ca.crt": []byte("ca1")},
+		"b": {"value": []byte("b1")},
+	})
+
+	for name, tc := range map[string]struct {
+		certs   map[string]map[string][]byte
+		changed bool
+	}{
+		"Same": {
+			certs: map[string]map[string][]byte{
+				"b": {"value": []byte("b1")},
+				"a": {"ca.crt": []byte("ca1"), "tls.crt": []byte("a1")},
+			},
+			changed: false,
+		},
+		"CertificateChanged": {
+			certs: map[string]map[string][]byte{
+				"a": {"tls.crt": []byte("a2"), "ca.crt": []byte("ca1")},
+				"b": {"value": []byte("b1")},
+			},
+			changed: true,
+		},
+		"CAChanged": {
+			certs: map[string]map[string][]byte{
+				"a": {"tls.crt": []byte("a1"), "ca.crt": []byte("ca2")},
+				"b": {"value": []byte("b1")},
+			},
+			changed: true,
+		},
+		"KeyMoved": {
+			certs: map[string]map[string][]byte{
+				"a": {"tls.crt": []byte("a1")},
+				"b": {"ca.crt": []byte("ca1"), "value": []byte("b1")},
+			},
+			changed: true,
+		},
+		"SecretMissing": {
+			certs: map[string]map[string][]byte{
+				"a": {"tls.crt": []byte("a1"), "ca.crt": []byte("ca1")},
+				"b": {},
+			},
+			changed: true,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			// test
+			actual := certificatesHash(tc.certs)
+
+			// validate
+			assert.Equal(t, tc.changed, actual != base)
+		})
+	}
+
+	t.Run("Empty", func(t *testing.T) {
+		t.Parallel()
+
+		// test
+		actual := certificatesHash(nil)
+
+		// validate
+		assert.Empty(t, actual)
+	})
+}
+
+func TestRolledOut(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		deployment *appsv1.Deployment
+		expected   bool
+	}{
+		"RolledOut": {
+			deployment: &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{Generation: 2},
+				Spec:       appsv1.DeploymentSpec{Replicas: ptr.To[int32](2)},
+				Status: appsv1.DeploymentStatus{
+					ObservedGeneration: 2,
+					Replicas:           2,
+					UpdatedReplicas:    2,
+					AvailableReplicas:  2,
+				},
+			},
+			expected: true,
+		},
+		"NotObserved": {
+			deployment: &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{Generation: 2},
+				Status: appsv1.DeploymentStatus{
+					ObservedGeneration: 1,
+					Replicas:           1,
+					UpdatedReplicas:    1,
+					AvailableReplicas:  1,
+				},
+			},
+			expected: false,
+		},
+		"OldReplicasRemaining": {
+			deployment: &appsv1.Deployment{
+				Status: appsv1.DeploymentStatus{
+					Replicas:          2,
+					UpdatedReplicas:   1,
+					AvailableReplicas: 2,
+				},
+			},
+			expected: false,
+		},
+		"Unavailable": {
+			deployment: &appsv1.Deployment{
+				Status: appsv1.DeploymentStatus{
+					Replicas:        1,
+					UpdatedReplicas: 1,
+				},
+			},
+			expected: false,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			// test
+			actual := rolledOut(tc.deployment)
+
+			// validate
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
+func TestAnnotateCertificatesHash(t *testing.T) {
+	t.Parallel()
+
+	deployment := func(component, secret, hash string, rolledOut bool) *appsv1.Deployment {
+		depl := &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       "test-" + component,
+				Namespace:  "default",
+				Labels:     map[string]string{manifestutils.LabelComponent: component},
+				Generation: 1,
+			},
+			Spec: appsv1.DeploymentSpec{
+				Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{
+						Volumes: []corev1.Volume{{Name: "cert", VolumeSource: corev1.VolumeSource{
+							Secret: &corev1.SecretVolumeSource{SecretName: secret},
+						}}},
+					},
+				},
+			},
+		}
+		if hash != "" {
+			depl.Spec.Template.Annotations = map[string]string{certificatesHashAnnotation: hash}
+		}
+		if rolledOut {
+			depl.Status = appsv1.DeploymentStatus{
+				ObservedGeneration: 1,
+				Replicas:           1,
+				UpdatedReplicas:    1,
+				AvailableReplicas:  1,
+			}
+		}
+		return depl
+	}
+	secret := func(name, cert string) *corev1.Secret {
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Data: map[string][]byte{
+				"tls.crt": []byte(cert),
+				"tls.key": []byte("key"),
+			},
+		}
+	}
+	hash := func(name, cert string) string {
+		return certificatesHash(map[string]map[string][]byte{name: {"tls.crt": []byte(cert)}})
+	}
+	restarting := func(depl *appsv1.Deployment, hash string, deadlineExceeded bool) *appsv1.Deployment {
+		depl.Annotations = map[string]string{certificatesRolloutAnnotation: hash}
+		if deadlineExceeded {
+			depl.Status.ObservedGeneration = 1
+			depl.Status.Conditions = []appsv1.DeploymentCondition{{
+				Type:   appsv1.DeploymentProgressing,
+				Status: corev1.ConditionFalse,
+				Reason: "ProgressDeadlineExceeded",
+			}}
+		}
+		return depl
+	}
+	desired := func() []client.Object {
+		return []client.Object{
+			deployment(controlplane.ComponentScheduler, "scheduler", "", false),
+			deployment(controlplane.ComponentAPIServer, "apiserver", "", false),
+			deployment(controlplane.ComponentKine, "kine", "", false),
+		}
+	}
+
+	kineHash := hash("kine", "1")
+	apiServerHash := hash("apiserver", "1")
+	schedulerHash := hash("scheduler", "1")
+	rotatedKineHash := hash("kine", "2")
+	rotatedAPIServerHash := hash("apiserver", "2")
+
+	for name, tc := range map[string]struct {
+		objects          []client.Object
+		expected         map[string]string
+		expectedRollouts map[string]string
+	}{
+		"Create": {
+			objects: []client.Object{
+				secret("kine", "1"), secret("apiserver", "1"), secret("scheduler", "1"),
+			},
+			expected: map[string]string{
+				controlplane.ComponentKine:      kineHash,
+				controlplane.ComponentAPIServer: apiServerHash,
+				controlplane.ComponentScheduler: schedulerHash,
+			},
+		},
+		"Unchanged": {
+			objects: []client.Object{
+				secret("kine", "1"), secret("apiserver", "1"), secret("scheduler", "1"),
+				deployment(controlplane.ComponentKine, "kine", kineHash, true),
+				deployment(controlplane.ComponentAPIServer, "apiserver", apiServerHash, true),
+				deployment(controlplane.ComponentScheduler, "scheduler", schedulerHash, true),
+			},
+			expected: map[string]string{
+				controlplane.ComponentKine:      kineHash,
+				controlplane.ComponentAPIServer: apiServerHash,
+				controlplane.ComponentScheduler: schedulerHash,
+			},
+		},
+		"OnlyMetadataChanged": {
+			objects: []client.Object{
+				func() client.Object {
+					s := secret("kine", "1")
+					s.Labels = map[string]string{"changed": "true"}
+					s.Data["tls.key"] = []byte("other")
+					return s
+				}(),
+				secret("apiserver", "1"), secret("scheduler", "1"),
+				deployment(controlplane.ComponentKine, "kine", kineHash, true),
+				deployment(controlplane.ComponentAPIServer, "apiserver", apiServerHash, true),
+				deployment(controlplane.ComponentScheduler, "scheduler", schedulerHash, true),
+			},
+			expected: map[string]string{
+				controlplane.ComponentKine:      kineHash,
+				controlplane.ComponentAPIServer: apiServerHash,
+				controlplane.ComponentScheduler: schedulerHash,
+			},
+		},
+		"RotatedInOrder": {
+			objects: []client.Object{
+				secret("kine", "2"), secret("apiserver", "2"), secret("scheduler", "1"),
+				deployment(controlplane.ComponentKine, "kine", kineHash, true),
+				deployment(controlplane.ComponentAPIServer, "apiserver", apiServerHash, true),
+				deployment(controlplane.ComponentScheduler, "scheduler", schedulerHash, true),
+			},
+			expected: map[string]string{
+				controlplane.ComponentKine:      rotatedKineHash,
+				controlplane.ComponentAPIServer: apiServerHash,
+				controlplane.ComponentScheduler: schedulerHash,
+			},
+			expectedRollouts: map[string]string{
+				controlplane.ComponentKine: rotatedKineHash,
+			},
+		},
+		"PrecedingRolledOut": {
+			objects: []client.Object{
+				secret("kine", "2"), secret("apiserver", "2"), secret("scheduler", "1"),
+				deployment(controlplane.ComponentKine, "kine", rotatedKineHash, true),
+				deployment(controlplane.ComponentAPIServer, "apiserver", apiServerHash, true),
+				deployment(controlplane.ComponentScheduler, "scheduler", schedulerHash, true),
+			},
+			expected: map[string]string{
+				controlplane.ComponentKine:      rotatedKineHash,
+				controlplane.ComponentAPIServer: rotatedAPIServerHash,
+				controlplane.ComponentScheduler: schedulerHash,
+			},
+			expectedRollouts: map[string]string{
+				controlplane.ComponentAPIServer: rotatedAPIServerHash,
+			},
+		},
+		"PrecedingRollingOut": {
+			objects: []client.Object{
+				secret("kine", "2"), secret("apiserver", "2"), secret("scheduler", "1"),
+				restarting(deployment(controlplane.ComponentKine, "kine", rotatedKineHash, false), rotatedKineHash, false),
+				deployment(controlplane.ComponentAPIServer, "apiserver", apiServerHash, true),
+				deployment(controlplane.ComponentScheduler, "scheduler", schedulerHash, true),
+			},
+			expected: map[string]string{
+				controlplane.ComponentKine:      rotatedKineHash,
+				controlplane.ComponentAPIServer: apiServerHash,
+				controlplane.ComponentScheduler: schedulerHash,
+			},
+			expectedRollouts: map[string]string{
+				controlplane.ComponentKine: rotatedKineHash,
+			},
+		},
+		"PrecedingProgressDeadlineExceeded": {
+			objects: []client.Object{
+				secret("kine", "2"), secret("apiserver", "2"), secret("scheduler", "1"),
+				restarting(deployment(controlplane.ComponentKine, "kine", rotatedKineHash, false), rotatedKineHash, true),
+				deployment(controlplane.ComponentAPIServer, "apiserver", apiServerHash, true),
+				deployment(controlplane.ComponentScheduler, "scheduler", schedulerHash, true),
+			},
+			expected: map[string]string{
+				controlplane.ComponentKine:      rotatedKineHash,
+				controlplane.ComponentAPIServer: rotatedAPIServerHash,
+				controlplane.ComponentScheduler: schedulerHash,
+			},
+			expectedRollouts: map[string]string{
+				controlplane.ComponentAPIServer: rotatedAPIServerHash,
+			},
+		},
+		"KineNeverAvailable": {
+			objects: []client.Object{
+				secret("kine", "1"), secret("apiserver", "2"), secret("scheduler", "1"),
+				deployment(controlplane.ComponentKine, "kine", kineHash, false),
+				deployment(controlplane.ComponentAPIServer, "apiserver", apiServerHash, true),
+				deployment(controlplane.ComponentScheduler, "scheduler", schedulerHash, true),
+			},
+			expected: map[string]string{
+				controlplane.ComponentKine:      kineHash,
+				controlplane.ComponentAPIServer: rotatedAPIServerHash,
+				controlplane.ComponentScheduler: schedulerHash,
+			},
+			expectedRollouts: map[string]string{
+				controlplane.ComponentAPIServer: rotatedAPIServerHash,
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			// prepare
+			r := &KinkControlPlaneReconciler{
+				Client: fake.NewClientBuilder().
+					WithScheme(scheme.Scheme).
+					WithObjects(tc.objects...).
+					Build(),
+				Recorder: record.NewFakeRecorder(10),
+			}
+			kcp := &controlplanev1alpha1.KinkControlPlane{
+				ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+			}
+			objects := desired()
+
+			// test
+			err := r.annotateCertificatesHash(t.Context(), kcp, objects)
+
+			// validate
+			require.NoError(t, err)
+			actual := map[string]string{}
+			var rollouts map[string]string
+			for _, obj := range objects {
+				depl := obj.(*appsv1.Deployment)
+				component := depl.Labels[manifestutils.LabelComponent]
+				actual[component] = depl.Spec.Template.Annotations[certificatesHashAnnotation]
+				if rollout, ok := depl.Annotations[certificatesRolloutAnnotation]; ok {
+					if rollouts == nil {
+						rollouts = map[string]string{}
+					}
+					rollouts[component] = rollout
+				}
+			}
+			assert.Equal(t, tc.expected, actual)
+			assert.Equal(t, tc.expectedRollouts, rollouts)
+		})
+	}
+}
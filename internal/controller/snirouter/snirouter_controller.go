@@ -0,0 +1,138 @@
+// Copyright 2025 anza-labs contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snirouter
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	controlplanev1alpha1 "github.com/anza-labs/kink/api/controlplane/v1alpha1"
+	"github.com/anza-labs/kink/internal/controller/util"
+	"github.com/anza-labs/kink/internal/manifests/snirouter"
+	"github.com/anza-labs/kink/internal/naming"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// SNIRouterReconciler maintains the SNI router shared between the control planes exposed through
+// it, along with its routing table built from all the KinkControlPlane objects.
+type SNIRouterReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+
+	// Namespace is the namespace of the SNI router.
+	Namespace string
+
+	// BaseDomain is the domain under which the hosts of the control planes are assigned.
+	BaseDomain string
+
+	// Image is the image of HAProxy.
+	Image string
+
+	// ServiceType is the type of the Service exposing the SNI router.
+	ServiceType corev1.ServiceType
+}
+
+//nolint:lll // kubebuilder directives cannot be split into lines
+// +kubebuilder:rbac:groups=controlplane.cluster.x-k8s.io,resources=kinkcontrolplanes,verbs=get;list;watch
+// +kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=core,resources=configmaps,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=core,resources=services,verbs=get;list;watch;create;update;patch;delete
+
+// Reconcile renders the routing table of the SNI router, and deploys the router as long as at
+// least one control plane is exposed through it.
+func (r *SNIRouterReconciler) Reconcile(ctx context.Context, _ ctrl.Request) (ctrl.Result, error) {
+	log := log.FromContext(ctx)
+
+	kinkCPs := &controlplanev1alpha1.KinkControlPlaneList{}
+	if err := r.List(ctx, kinkCPs); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to list KinkControlPlanes: %w", err)
+	}
+
+	routes := Routes(kinkCPs.Items, r.BaseDomain)
+	objects, err := (&snirouter.SNIRouter{
+		Namespace:   r.Namespace,
+		Image:       r.Image,
+		ServiceType: r.ServiceType,
+		Routes:      routes,
+	}).Build()
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to build SNI router: %w", err)
+	}
+
+	if len(routes) == 0 {
+		log.V(2).Info("No control plane is exposed through the SNI router, removing it")
+		for _, obj := range objects {
+			if err := r.Delete(ctx, obj); client.IgnoreNotFound(err) != nil {
+				return ctrl.Result{}, fmt.Errorf("failed to delete %s: %w", obj.GetName(), err)
+			}
+		}
+		return ctrl.Result{}, nil
+	}
+
+	log.V(2).Info("Applying SNI router", "routes", len(routes))
+	if err := util.ApplyObjects(ctx, r.Client, r.Scheme, objects); err != nil {
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{}, nil
+}
+
+// Routes returns the routes of the control planes exposed through the SNI router, sorted by host.
+// When several control planes claim the same host, it is routed to the first one created.
+func Routes(kinkCPs []controlplanev1alpha1.KinkControlPlane, baseDomain string) []snirouter.Route {
+	routes := []snirouter.Route{}
+	for host, kinkCP := range snirouter.HostOwners(kinkCPs, baseDomain) {
+		routes = append(routes, snirouter.Route{
+			Host:      host,
+			Service:   naming.APIServer(kinkCP.Name),
+			Namespace: kinkCP.Namespace,
+		})
+	}
+	slices.SortFunc(routes, func(a, b snirouter.Route) int {
+		return strings.Compare(a.Host, b.Host)
+	})
+	return routes
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *SNIRouterReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	key := types.NamespacedName{Name: naming.SNIRouter(), Namespace: r.Namespace}
+	enqueueRouter := handler.EnqueueRequestsFromMapFunc(func(context.Context, client.Object) []reconcile.Request {
+		return []reconcile.Request{{NamespacedName: key}}
+	})
+	isRouter := builder.WithPredicates(predicate.NewPredicateFuncs(func(obj client.Object) bool {
+		return client.ObjectKeyFromObject(obj) == key
+	}))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("snirouter").
+		Watches(&controlplanev1alpha1.KinkControlPlane{}, enqueueRouter).
+		Watches(&corev1.ConfigMap{}, enqueueRouter, isRouter).
+		Watches(&corev1.Service{}, enqueueRouter, isRouter).
+		Watches(&appsv1.Deployment{}, enqueueRouter, isRouter).
+		Complete(r)
+}
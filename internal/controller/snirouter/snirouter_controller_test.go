@@ -0,0 +1,135 @@
+// Copyright 2025 anza-labs contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snirouter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	controlplanev1alpha1 "github.com/anza-labs/kink/api/controlplane/v1alpha1"
+	"github.com/anza-labs/kink/internal/manifests/snirouter"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestRoutes(t *testing.T) {
+	t.Parallel()
+
+	kcp := func(namespace, name string, endpoint controlplanev1alpha1.APIEndpoint) controlplanev1alpha1.KinkControlPlane {
+		return controlplanev1alpha1.KinkControlPlane{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Spec:       controlplanev1alpha1.KinkControlPlaneSpec{ControlPlaneEndpoint: endpoint},
+		}
+	}
+	created := func(kinkCP controlplanev1alpha1.KinkControlPlane, hour int) controlplanev1alpha1.KinkControlPlane {
+		kinkCP.CreationTimestamp = metav1.NewTime(time.Date(2025, 1, 1, hour, 0, 0, 0, time.UTC))
+		return kinkCP
+	}
+	sniRouter := &controlplanev1alpha1.SNIRouter{}
+
+	for name, tc := range map[string]struct {
+		kinkCPs  []controlplanev1alpha1.KinkControlPlane
+		expected []snirouter.Route
+	}{
+		"Empty": {
+			expected: []snirouter.Route{},
+		},
+		"AssignedHosts": {
+			kinkCPs: []controlplanev1alpha1.KinkControlPlane{
+				kcp("tenant", "b", controlplanev1alpha1.APIEndpoint{SNIRouter: sniRouter}),
+				kcp("tenant", "lb", controlplanev1alpha1.APIEndpoint{ServiceType: corev1.ServiceTypeLoadBalancer}),
+				kcp("tenant", "a", controlplanev1alpha1.APIEndpoint{SNIRouter: sniRouter, Host: "a.example.org"}),
+			},
+			expected: []snirouter.Route{
+				{Host: "a.example.org", Service: "a-api-server", Namespace: "tenant"},
+				{Host: "b-tenant.example.com", Service: "b-api-server", Namespace: "tenant"},
+			},
+		},
+		"ConflictingHosts": {
+			kinkCPs: []controlplanev1alpha1.KinkControlPlane{
+				created(kcp("tenant-b", "test", controlplanev1alpha1.APIEndpoint{
+					SNIRouter: &controlplanev1alpha1.SNIRouter{Subdomain: "prod"},
+				}), 1),
+				created(kcp("tenant-a", "test", controlplanev1alpha1.APIEndpoint{
+					SNIRouter: &controlplanev1alpha1.SNIRouter{Subdomain: "prod"},
+				}), 2),
+			},
+			expected: []snirouter.Route{
+				{Host: "prod.example.com", Service: "test-api-server", Namespace: "tenant-b"},
+			},
+		},
+		"ConflictingExplicitHost": {
+			kinkCPs: []controlplanev1alpha1.KinkControlPlane{
+				created(kcp("tenant-b", "prod", controlplanev1alpha1.APIEndpoint{SNIRouter: sniRouter}), 1),
+				created(kcp("tenant-a", "attacker", controlplanev1alpha1.APIEndpoint{
+					SNIRouter: sniRouter,
+					Host:      "prod-tenant-b.example.com",
+				}), 2),
+			},
+			expected: []snirouter.Route{
+				{Host: "prod-tenant-b.example.com", Service: "prod-api-server", Namespace: "tenant-b"},
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			// test
+			actual := Routes(tc.kinkCPs, "example.com")
+
+			// validate
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
+func TestReconcileRemovesUnusedRouter(t *testing.T) {
+	t.Parallel()
+
+	// prepare
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(controlplanev1alpha1.AddToScheme(scheme))
+
+	meta := metav1.ObjectMeta{Name: "kink-sni-router", Namespace: "kink-system"}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&corev1.ConfigMap{ObjectMeta: meta},
+		&corev1.Service{ObjectMeta: meta},
+		&appsv1.Deployment{ObjectMeta: meta},
+	).Build()
+	r := &SNIRouterReconciler{Client: c, Scheme: scheme, Namespace: "kink-system", BaseDomain: "example.com"}
+
+	// test
+	_, err := r.Reconcile(t.Context(), ctrl.Request{})
+
+	// validate
+	require.NoError(t, err)
+	for _, obj := range []client.Object{&corev1.ConfigMap{}, &corev1.Service{}, &appsv1.Deployment{}} {
+		err := c.Get(t.Context(), client.ObjectKey{Name: meta.Name, Namespace: meta.Namespace}, obj)
+		assert.True(t, apierrors.IsNotFound(err))
+	}
+}
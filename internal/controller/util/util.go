@@ -19,20 +19,31 @@ package util
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"maps"
+	"strings"
 
 	cmv1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 
 	"github.com/anza-labs/kink/internal/manifests"
+	"github.com/anza-labs/kink/internal/metrics"
 
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/validation"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
-	"k8s.io/client-go/util/retry"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/util/csaupgrade"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
@@ -40,6 +51,27 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/log"
 )
 
+const (
+	// FieldManager is the name of the field manager used by the operator for server-side apply.
+	FieldManager = "kink"
+
+	// ScaleTargetIndex is the name of the field index of the Deployments scaled by
+	// HorizontalPodAutoscalers, keyed by the name of their scale target.
+	ScaleTargetIndex = "spec.scaleTargetRef.deploymentName"
+
+	// desiredHashAnnotation records the hash of the desired state last applied to the object, to
+	// tell the updates correcting a drift from the ones applying a change of the desired state.
+	desiredHashAnnotation = "kink.anza-labs.dev/desired-hash"
+
+	// operationResultDriftCorrected means the live object drifted from a desired state, which is
+	// unchanged since the last apply, and was updated back to it.
+	operationResultDriftCorrected controllerutil.OperationResult = "driftCorrected"
+
+	// legacyFieldManager is the name of the field manager recorded by the API server for the
+	// client-side updates issued by the operator before it switched to server-side apply.
+	legacyFieldManager = "manager"
+)
+
 func ShouldGVK(obj client.Object, scheme *runtime.Scheme) schema.GroupVersionKind {
 	gvk, _ := apiutil.GVKForObject(obj, scheme)
 	return gvk
@@ -119,6 +151,19 @@ func FindOwnedObjects(
 	return ownedObjects, nil
 }
 
+// HasKind reports whether the kind is served by the API server, e.g. whether the CRD is installed.
+func HasKind(mapper apimeta.RESTMapper, gvk schema.GroupVersionKind) (bool, error) {
+	_, err := mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	switch {
+	case apimeta.IsNoMatchError(err):
+		return false, nil
+	case err != nil:
+		return false, err
+	default:
+		return true, nil
+	}
+}
+
 func isNamespaceScoped(obj client.Object) bool {
 	switch obj.(type) {
 	case *rbacv1.ClusterRole, *rbacv1.ClusterRoleBinding:
@@ -128,7 +173,8 @@ func isNamespaceScoped(obj client.Object) bool {
 	}
 }
 
-// ReconcileDesiredObjects runs the reconcile process using the mutateFn over the given list of objects.
+// ReconcileDesiredObjects applies the given list of objects using server-side apply and prunes
+// the owned objects which are no longer desired.
 func ReconcileDesiredObjects(
 	ctx context.Context,
 	kubeClient client.Client,
@@ -152,34 +198,28 @@ func ReconcileDesiredObjects(
 				continue
 			}
 		}
-		// existing is an object the controller runtime will hydrate for us
-		// we obtain the existing object by deep copying the desired object because it's the most convenient way
-		existing := desired.DeepCopyObject().(client.Object)
-		mutateFn := manifests.MutateFuncFor(existing, desired)
-
-		var op controllerutil.OperationResult
-		crudErr := retry.RetryOnConflict(retry.DefaultRetry, func() error {
-			result, createOrUpdateErr := ctrl.CreateOrUpdate(ctx, kubeClient, existing, mutateFn)
-			op = result
-			return createOrUpdateErr
-		})
-		if crudErr != nil && errors.As(crudErr, &manifests.ImmutableChangeErr) {
-			l.Error(crudErr, "Detected immutable field change, trying to delete, new object will be created on next reconcile",
-				"existing", existing.GetName())
-			delErr := kubeClient.Delete(ctx, existing)
-			if delErr != nil {
+
+		op, applyErr := applyObject(ctx, kubeClient, scheme, desired)
+		if applyErr != nil && errors.As(applyErr, &manifests.ImmutableChangeErr) {
+			l.Error(applyErr, "Detected immutable field change, trying to delete, new object will be created on next reconcile",
+				"existing", desired.GetName())
+			delErr := kubeClient.Delete(ctx, desired)
+			if client.IgnoreNotFound(delErr) != nil {
 				return delErr
 			}
 			continue
-		} else if crudErr != nil {
-			l.Error(crudErr, "Failed to configure desired")
-			errs = append(errs, crudErr)
+		} else if applyErr != nil {
+			l.Error(applyErr, "Failed to configure desired")
+			errs = append(errs, applyErr)
 			continue
 		}
 
-		l.V(3).Info("Desired object reconicled", "result", op)
+		l.V(3).Info("Desired object reconciled", "result", op)
+		if op == operationResultDriftCorrected {
+			metrics.DriftCorrections.WithLabelValues(ShouldGVK(desired, scheme).Kind).Inc()
+		}
 		// This object is still managed by the operator, remove it from the list of objects to prune
-		delete(ownedObjects, existing.GetUID())
+		delete(ownedObjects, desired.GetUID())
 	}
 
 	if len(errs) > 0 {
@@ -195,6 +235,199 @@ func ReconcileDesiredObjects(
 	return nil
 }
 
+// ApplyObjects applies the given list of objects using server-side apply, without owner
+// references, for objects shared between control planes and thus owned by none of them.
+func ApplyObjects(
+	ctx context.Context,
+	kubeClient client.Client,
+	scheme *runtime.Scheme,
+	desiredObjects []client.Object,
+) error {
+	var errs []error
+	for _, desired := range desiredObjects {
+		if _, err := applyObject(ctx, kubeClient, scheme, desired); err != nil {
+			errs = append(errs, fmt.Errorf("failed to apply %s %s: %w",
+				ShouldGVK(desired, scheme).Kind, desired.GetName(), err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// applyObject applies the desired object with the operator field manager. Fields owned by
+// other managers (e.g. HPA or cert-manager) are left untouched. The returned result reports
+// whether the object was created, updated to a changed desired state, corrected (the live
+// object drifted from an unchanged desired state) or left unchanged. On success, desired is
+// hydrated with the state returned by the API server.
+func applyObject(
+	ctx context.Context,
+	kubeClient client.Client,
+	scheme *runtime.Scheme,
+	desired client.Object,
+) (controllerutil.OperationResult, error) {
+	gvk, err := apiutil.GVKForObject(desired, scheme)
+	if err != nil {
+		return controllerutil.OperationResultNone, err
+	}
+	desired.GetObjectKind().SetGroupVersionKind(gvk)
+	desired.SetResourceVersion("")
+	desired.SetManagedFields(nil)
+
+	hash, err := desiredHash(desired)
+	if err != nil {
+		return controllerutil.OperationResultNone, err
+	}
+
+	// existing is an object the controller runtime will hydrate for us
+	// we obtain the existing object by deep copying the desired object because it's the most convenient way
+	existing := desired.DeepCopyObject().(client.Object)
+	err = kubeClient.Get(ctx, client.ObjectKeyFromObject(desired), existing)
+	switch {
+	case apierrors.IsNotFound(err):
+		existing = nil
+	case err != nil:
+		return controllerutil.OperationResultNone, err
+	default:
+		if err := manifests.CheckImmutableFields(existing, desired); err != nil {
+			return controllerutil.OperationResultNone, err
+		}
+		if err := upgradeManagedFields(ctx, kubeClient, existing); err != nil {
+			return controllerutil.OperationResultNone, err
+		}
+		if err := preserveReplicas(ctx, kubeClient, existing, desired); err != nil {
+			return controllerutil.OperationResultNone, err
+		}
+	}
+
+	annotations := maps.Clone(desired.GetAnnotations())
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[desiredHashAnnotation] = hash
+	desired.SetAnnotations(annotations)
+
+	err = kubeClient.Patch(ctx, desired, client.Apply, client.FieldOwner(FieldManager), client.ForceOwnership)
+	if field, ok := immutableField(err); ok {
+		return controllerutil.OperationResultNone, &manifests.ImmutableFieldChangeErr{Field: field}
+	} else if err != nil {
+		return controllerutil.OperationResultNone, err
+	}
+
+	return applyResult(existing, desired), nil
+}
+
+// applyResult compares the object before and after it is applied with its desired state.
+func applyResult(existing, applied client.Object) controllerutil.OperationResult {
+	switch {
+	case existing == nil:
+		return controllerutil.OperationResultCreated
+	case existing.GetResourceVersion() == applied.GetResourceVersion():
+		return controllerutil.OperationResultNone
+	case existing.GetAnnotations()[desiredHashAnnotation] == applied.GetAnnotations()[desiredHashAnnotation]:
+		return operationResultDriftCorrected
+	default:
+		return controllerutil.OperationResultUpdated
+	}
+}
+
+// desiredHash returns the hash of the desired state of the object, before it is applied.
+func desiredHash(desired client.Object) (string, error) {
+	data, err := json.Marshal(desired)
+	if err != nil {
+		return "", fmt.Errorf("failed to hash the desired state: %w", err)
+	}
+	return fmt.Sprintf("%x", sha256.Sum256(data)), nil
+}
+
+// immutableField returns the path of the field which the API server refused to change because
+// it is immutable, as reported in the causes of the returned status error.
+func immutableField(err error) (string, bool) {
+	var statusErr *apierrors.StatusError
+	if !errors.As(err, &statusErr) || !apierrors.IsInvalid(err) || statusErr.ErrStatus.Details == nil {
+		return "", false
+	}
+	for _, cause := range statusErr.ErrStatus.Details.Causes {
+		if cause.Type == metav1.CauseTypeFieldValueInvalid &&
+			strings.HasSuffix(cause.Message, validation.FieldImmutableErrorMsg) {
+			return cause.Field, true
+		}
+	}
+	return "", false
+}
+
+// preserveReplicas keeps the live replica count of a Deployment scaled by someone else, e.g.
+// a HorizontalPodAutoscaler, so the forced apply does not take spec.replicas back. Scaling to
+// and from zero, e.g. on hibernation, is always applied.
+func preserveReplicas(ctx context.Context, kubeClient client.Client, existing, desired client.Object) error {
+	live, ok := existing.(*appsv1.Deployment)
+	if !ok {
+		return nil
+	}
+	deployment := desired.(*appsv1.Deployment)
+	if ptr.Deref(live.Spec.Replicas, 1) == 0 || ptr.Deref(deployment.Spec.Replicas, 1) == 0 {
+		return nil
+	}
+
+	scaled, err := scaledByOthers(ctx, kubeClient, live)
+	if err != nil {
+		return err
+	}
+	if scaled {
+		deployment.Spec.Replicas = live.Spec.Replicas
+	}
+	return nil
+}
+
+// scaledByOthers reports whether the replicas of the Deployment are managed by a field manager
+// other than the operator, or the Deployment is the scale target of a HorizontalPodAutoscaler.
+func scaledByOthers(ctx context.Context, kubeClient client.Client, deployment *appsv1.Deployment) (bool, error) {
+	for _, entry := range deployment.GetManagedFields() {
+		if entry.Manager == FieldManager || entry.Manager == legacyFieldManager || entry.FieldsV1 == nil {
+			continue
+		}
+		var fields struct {
+			Spec map[string]json.RawMessage `json:"f:spec"`
+		}
+		if err := json.Unmarshal(entry.FieldsV1.Raw, &fields); err != nil {
+			return false, fmt.Errorf("failed to decode managed fields of %s: %w", entry.Manager, err)
+		}
+		if _, ok := fields.Spec["f:replicas"]; ok {
+			return true, nil
+		}
+	}
+
+	hpas := &autoscalingv2.HorizontalPodAutoscalerList{}
+	if err := kubeClient.List(ctx, hpas,
+		client.InNamespace(deployment.Namespace),
+		client.MatchingFields{ScaleTargetIndex: deployment.Name},
+	); err != nil {
+		return false, fmt.Errorf("failed to list horizontal pod autoscalers: %w", err)
+	}
+	return len(hpas.Items) > 0, nil
+}
+
+// IndexScaleTarget returns the name of the Deployment scaled by the HorizontalPodAutoscaler,
+// for the ScaleTargetIndex field index.
+func IndexScaleTarget(obj client.Object) []string {
+	hpa, ok := obj.(*autoscalingv2.HorizontalPodAutoscaler)
+	if !ok || hpa.Spec.ScaleTargetRef.Kind != "Deployment" {
+		return nil
+	}
+	return []string{hpa.Spec.ScaleTargetRef.Name}
+}
+
+// upgradeManagedFields hands the fields owned by the legacy client-side manager over to the
+// server-side apply field manager, so fields removed from the desired state are pruned.
+func upgradeManagedFields(ctx context.Context, kubeClient client.Client, existing client.Object) error {
+	patch, err := csaupgrade.UpgradeManagedFieldsPatch(existing, sets.New(legacyFieldManager), FieldManager)
+	if err != nil {
+		return fmt.Errorf("failed to compute managed fields upgrade: %w", err)
+	}
+	if patch == nil {
+		return nil
+	}
+	return kubeClient.Patch(ctx, existing, client.RawPatch(types.JSONPatchType, patch))
+}
+
 func DeleteObjects(
 	ctx context.Context,
 	kubeClient client.Client,
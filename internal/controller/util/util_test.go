@@ -0,0 +1,518 @@
+// Copyright 2025 anza-labs contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/managedfields/managedfieldstest"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/client-go/applyconfigurations"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+func newFieldManager(t *testing.T, gvk schema.GroupVersionKind) managedfieldstest.TestFieldManager {
+	t.Helper()
+
+	return managedfieldstest.NewTestFieldManager(applyconfigurations.NewTypeConverter(clientgoscheme.Scheme), gvk)
+}
+
+// live converts the live object held by the field manager into the typed object.
+func live[T runtime.Object](t *testing.T, fm managedfieldstest.TestFieldManager, obj T) T {
+	t.Helper()
+
+	u, err := runtime.DefaultUnstructuredConverter.ToUnstructured(fm.Live())
+	require.NoError(t, err)
+	require.NoError(t, runtime.DefaultUnstructuredConverter.FromUnstructured(u, obj))
+	return obj
+}
+
+func testDeployment(replicas int32, spec corev1.PodSpec) *appsv1.Deployment {
+	return &appsv1.Deployment{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "apps/v1", Kind: "Deployment"},
+		ObjectMeta: metav1.ObjectMeta{Name: "workload", Namespace: "default"},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: ptr.To(replicas),
+			Template: corev1.PodTemplateSpec{Spec: spec},
+		},
+	}
+}
+
+func testStatefulSet(spec corev1.PodSpec) *appsv1.StatefulSet {
+	return &appsv1.StatefulSet{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "apps/v1", Kind: "StatefulSet"},
+		ObjectMeta: metav1.ObjectMeta{Name: "workload", Namespace: "default"},
+		Spec: appsv1.StatefulSetSpec{
+			Template: corev1.PodTemplateSpec{Spec: spec},
+		},
+	}
+}
+
+func testAffinity(key string, values ...string) *corev1.Affinity {
+	return &corev1.Affinity{
+		NodeAffinity: &corev1.NodeAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+				NodeSelectorTerms: []corev1.NodeSelectorTerm{{
+					MatchExpressions: []corev1.NodeSelectorRequirement{{
+						Key:      key,
+						Operator: corev1.NodeSelectorOpIn,
+						Values:   values,
+					}},
+				}},
+			},
+		},
+	}
+}
+
+func TestApplyPodTemplate(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		existing corev1.PodSpec
+		desired  corev1.PodSpec
+	}{
+		"AddContainer": {
+			existing: corev1.PodSpec{Containers: []corev1.Container{
+				{Name: "test", Image: "test-image:latest"},
+			}},
+			desired: corev1.PodSpec{Containers: []corev1.Container{
+				{Name: "test", Image: "test-image:latest"},
+				{Name: "alpine", Image: "alpine:latest"},
+			}},
+		},
+		"RemoveContainer": {
+			existing: corev1.PodSpec{Containers: []corev1.Container{
+				{Name: "test", Image: "test-image:latest"},
+				{Name: "alpine", Image: "alpine:latest"},
+			}},
+			desired: corev1.PodSpec{Containers: []corev1.Container{
+				{Name: "test", Image: "test-image:latest"},
+			}},
+		},
+		"ModifyContainer": {
+			existing: corev1.PodSpec{Containers: []corev1.Container{
+				{Name: "test", Image: "test-image:latest"},
+				{Name: "alpine", Image: "alpine:latest"},
+			}},
+			desired: corev1.PodSpec{Containers: []corev1.Container{
+				{Name: "test", Image: "test-image:latest"},
+				{Name: "alpine", Image: "alpine:1.0"},
+			}},
+		},
+		"ReorderContainers": {
+			existing: corev1.PodSpec{Containers: []corev1.Container{
+				{Name: "test", Image: "test-image:latest"},
+				{Name: "alpine", Image: "alpine:latest"},
+			}},
+			desired: corev1.PodSpec{Containers: []corev1.Container{
+				{Name: "alpine", Image: "alpine:latest"},
+				{Name: "test", Image: "test-image:latest"},
+			}},
+		},
+		"AddAffinity": {
+			existing: corev1.PodSpec{Containers: []corev1.Container{
+				{Name: "test", Image: "test-image:latest"},
+			}},
+			desired: corev1.PodSpec{
+				Containers: []corev1.Container{{Name: "test", Image: "test-image:latest"}},
+				Affinity:   testAffinity("kubernetes.io/os", "linux"),
+			},
+		},
+		"RemoveAffinity": {
+			existing: corev1.PodSpec{
+				Containers: []corev1.Container{{Name: "test", Image: "test-image:latest"}},
+				Affinity:   testAffinity("kubernetes.io/os", "linux"),
+			},
+			desired: corev1.PodSpec{Containers: []corev1.Container{
+				{Name: "test", Image: "test-image:latest"},
+			}},
+		},
+		"ModifyAffinity": {
+			existing: corev1.PodSpec{
+				Containers: []corev1.Container{{Name: "test", Image: "test-image:latest"}},
+				Affinity:   testAffinity("kubernetes.io/os", "linux"),
+			},
+			desired: corev1.PodSpec{
+				Containers: []corev1.Container{{Name: "test", Image: "test-image:latest"}},
+				Affinity:   testAffinity("kubernetes.io/arch", "amd64", "arm64"),
+			},
+		},
+		"AddArg": {
+			existing: corev1.PodSpec{Containers: []corev1.Container{
+				{Name: "test", Image: "test-image:latest", Args: []string{"--default-arg=true"}},
+			}},
+			desired: corev1.PodSpec{Containers: []corev1.Container{
+				{Name: "test", Image: "test-image:latest", Args: []string{"--default-arg=true", "--extra-arg=true"}},
+			}},
+		},
+		"RemoveArg": {
+			existing: corev1.PodSpec{Containers: []corev1.Container{
+				{Name: "test", Image: "test-image:latest", Args: []string{"--default-arg=true", "--extra-arg=true"}},
+			}},
+			desired: corev1.PodSpec{Containers: []corev1.Container{
+				{Name: "test", Image: "test-image:latest", Args: []string{"--default-arg=true"}},
+			}},
+		},
+		"ModifyArg": {
+			existing: corev1.PodSpec{Containers: []corev1.Container{
+				{Name: "test", Image: "test-image:latest", Args: []string{"--default-arg=true", "--extra-arg=true"}},
+			}},
+			desired: corev1.PodSpec{Containers: []corev1.Container{
+				{Name: "test", Image: "test-image:latest", Args: []string{"--default-arg=true", "--extra-arg=false"}},
+			}},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			t.Run("Deployment", func(t *testing.T) {
+				t.Parallel()
+
+				// prepare
+				fm := newFieldManager(t, appsv1.SchemeGroupVersion.WithKind("Deployment"))
+				require.NoError(t, fm.Apply(testDeployment(1, tc.existing), FieldManager, true))
+
+				// test
+				err := fm.Apply(testDeployment(1, tc.desired), FieldManager, true)
+
+				// validate
+				require.NoError(t, err)
+				assert.Equal(t, tc.desired, live(t, fm, &appsv1.Deployment{}).Spec.Template.Spec)
+			})
+
+			t.Run("StatefulSet", func(t *testing.T) {
+				t.Parallel()
+
+				// prepare
+				fm := newFieldManager(t, appsv1.SchemeGroupVersion.WithKind("StatefulSet"))
+				require.NoError(t, fm.Apply(testStatefulSet(tc.existing), FieldManager, true))
+
+				// test
+				err := fm.Apply(testStatefulSet(tc.desired), FieldManager, true)
+
+				// validate
+				require.NoError(t, err)
+				assert.Equal(t, tc.desired, live(t, fm, &appsv1.StatefulSet{}).Spec.Template.Spec)
+			})
+		})
+	}
+}
+
+func TestApplyKeepsFieldsOfOtherManagers(t *testing.T) {
+	t.Parallel()
+
+	// prepare
+	fm := newFieldManager(t, appsv1.SchemeGroupVersion.WithKind("Deployment"))
+	spec := corev1.PodSpec{Containers: []corev1.Container{{Name: "test", Image: "test-image:latest"}}}
+	require.NoError(t, fm.Apply(testDeployment(1, spec), FieldManager, true))
+
+	injected := testDeployment(1, corev1.PodSpec{Containers: []corev1.Container{
+		{Name: "test", Image: "test-image:latest"},
+		{Name: "sidecar", Image: "sidecar:latest"},
+	}})
+	require.NoError(t, fm.Update(injected, "sidecar-injector"))
+
+	// test
+	spec.Containers[0].Image = "test-image:1.0"
+	err := fm.Apply(testDeployment(1, spec), FieldManager, true)
+
+	// validate
+	require.NoError(t, err)
+	assert.Equal(t, []corev1.Container{
+		{Name: "test", Image: "test-image:1.0"},
+		{Name: "sidecar", Image: "sidecar:latest"},
+	}, live(t, fm, &appsv1.Deployment{}).Spec.Template.Spec.Containers)
+}
+
+func TestApplyPreservesAutoscaledReplicas(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		desired  int32
+		expected int32
+	}{
+		"Autoscaled": {
+			desired:  1,
+			expected: 5,
+		},
+		"Hibernated": {
+			desired:  0,
+			expected: 0,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			// prepare
+			fm := newFieldManager(t, appsv1.SchemeGroupVersion.WithKind("Deployment"))
+			spec := corev1.PodSpec{Containers: []corev1.Container{{Name: "test", Image: "test-image:latest"}}}
+			require.NoError(t, fm.Apply(testDeployment(1, spec), FieldManager, true))
+			require.NoError(t, fm.Update(testDeployment(5, spec), "kube-controller-manager"))
+			cl := fake.NewClientBuilder().
+				WithScheme(clientgoscheme.Scheme).
+				WithIndex(&autoscalingv2.HorizontalPodAutoscaler{}, ScaleTargetIndex, IndexScaleTarget).
+				Build()
+
+			// test
+			for range 2 {
+				desired := testDeployment(tc.desired, spec)
+				existing := live(t, fm, &appsv1.Deployment{})
+				require.NoError(t, preserveReplicas(t.Context(), cl, existing, desired))
+				require.NoError(t, fm.Apply(desired, FieldManager, true))
+			}
+
+			// validate
+			assert.Equal(t, ptr.To(tc.expected), live(t, fm, &appsv1.Deployment{}).Spec.Replicas)
+		})
+	}
+}
+
+func TestPreserveReplicas(t *testing.T) {
+	t.Parallel()
+
+	scaledFields := metav1.ManagedFieldsEntry{
+		Manager:    "kube-controller-manager",
+		Operation:  metav1.ManagedFieldsOperationUpdate,
+		FieldsType: "FieldsV1",
+		FieldsV1:   &metav1.FieldsV1{Raw: []byte(`{"f:spec":{"f:replicas":{}}}`)},
+	}
+	ownFields := metav1.ManagedFieldsEntry{
+		Manager:    FieldManager,
+		Operation:  metav1.ManagedFieldsOperationApply,
+		FieldsType: "FieldsV1",
+		FieldsV1:   &metav1.FieldsV1{Raw: []byte(`{"f:spec":{"f:replicas":{}}}`)},
+	}
+	hpa := &autoscalingv2.HorizontalPodAutoscaler{
+		ObjectMeta: metav1.ObjectMeta{Name: "workload", Namespace: "default"},
+		Spec: autoscalingv2.HorizontalPodAutoscalerSpec{
+			ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{
+				APIVersion: "apps/v1",
+				Kind:       "Deployment",
+				Name:       "workload",
+			},
+			MaxReplicas: 5,
+		},
+	}
+	otherHPA := hpa.DeepCopy()
+	otherHPA.Name = "other"
+	otherHPA.Spec.ScaleTargetRef.Name = "other"
+
+	for name, tc := range map[string]struct {
+		existing      client.Object
+		managedFields []metav1.ManagedFieldsEntry
+		objects       []client.Object
+		desired       int32
+		expected      *int32
+	}{
+		"ManagedByOthers": {
+			existing:      testDeployment(3, corev1.PodSpec{}),
+			managedFields: []metav1.ManagedFieldsEntry{ownFields, scaledFields},
+			desired:       1,
+			expected:      ptr.To[int32](3),
+		},
+		"TargetedByAutoscaler": {
+			existing:      testDeployment(3, corev1.PodSpec{}),
+			managedFields: []metav1.ManagedFieldsEntry{ownFields},
+			objects:       []client.Object{hpa},
+			desired:       1,
+			expected:      ptr.To[int32](3),
+		},
+		"ManagedByOperator": {
+			existing:      testDeployment(3, corev1.PodSpec{}),
+			managedFields: []metav1.ManagedFieldsEntry{ownFields},
+			desired:       1,
+			expected:      ptr.To[int32](1),
+		},
+		"AutoscalerTargetsOther": {
+			existing:      testDeployment(3, corev1.PodSpec{}),
+			managedFields: []metav1.ManagedFieldsEntry{ownFields},
+			objects:       []client.Object{otherHPA},
+			desired:       1,
+			expected:      ptr.To[int32](1),
+		},
+		"ScaleToZero": {
+			existing:      testDeployment(3, corev1.PodSpec{}),
+			managedFields: []metav1.ManagedFieldsEntry{ownFields, scaledFields},
+			desired:       0,
+			expected:      ptr.To[int32](0),
+		},
+		"ScaleFromZero": {
+			existing:      testDeployment(0, corev1.PodSpec{}),
+			managedFields: []metav1.ManagedFieldsEntry{ownFields, scaledFields},
+			desired:       1,
+			expected:      ptr.To[int32](1),
+		},
+		"NotDeployment": {
+			existing: testStatefulSet(corev1.PodSpec{}),
+			desired:  1,
+			expected: ptr.To[int32](1),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			// prepare
+			cl := fake.NewClientBuilder().
+				WithScheme(clientgoscheme.Scheme).
+				WithIndex(&autoscalingv2.HorizontalPodAutoscaler{}, ScaleTargetIndex, IndexScaleTarget).
+				WithObjects(tc.objects...).
+				Build()
+			tc.existing.SetManagedFields(tc.managedFields)
+			desired := testDeployment(tc.desired, corev1.PodSpec{})
+
+			// test
+			err := preserveReplicas(t.Context(), cl, tc.existing, desired)
+
+			// validate
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, desired.Spec.Replicas)
+		})
+	}
+}
+
+func TestImmutableField(t *testing.T) {
+	t.Parallel()
+
+	gk := schema.GroupKind{Group: "apps", Kind: "Deployment"}
+
+	for name, tc := range map[string]struct {
+		err           error
+		expectedField string
+		expectedOK    bool
+	}{
+		"Immutable": {
+			err: apierrors.NewInvalid(gk, "workload", field.ErrorList{
+				field.Invalid(field.NewPath("spec", "selector"), nil, "field is immutable"),
+			}),
+			expectedField: "spec.selector",
+			expectedOK:    true,
+		},
+		"Invalid": {
+			err: apierrors.NewInvalid(gk, "workload", field.ErrorList{
+				field.Invalid(field.NewPath("spec", "replicas"), -1, "must be greater than or equal to 0"),
+			}),
+		},
+		"Required": {
+			err: apierrors.NewInvalid(gk, "workload", field.ErrorList{
+				field.Required(field.NewPath("spec", "selector"), "field is immutable"),
+			}),
+		},
+		"NotInvalid": {
+			err: apierrors.NewConflict(schema.GroupResource{Group: "apps", Resource: "deployments"},
+				"workload", errors.New("field is immutable")),
+		},
+		"NoError": {},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			// test
+			field, ok := immutableField(tc.err)
+
+			// validate
+			assert.Equal(t, tc.expectedOK, ok)
+			assert.Equal(t, tc.expectedField, field)
+		})
+	}
+}
+
+func TestApplyResult(t *testing.T) {
+	t.Parallel()
+
+	object := func(resourceVersion, hash string) client.Object {
+		return &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{
+			Name:            "config",
+			Namespace:       "default",
+			ResourceVersion: resourceVersion,
+			Annotations:     map[string]string{desiredHashAnnotation: hash},
+		}}
+	}
+
+	for name, tc := range map[string]struct {
+		existing client.Object
+		applied  client.Object
+		expected controllerutil.OperationResult
+	}{
+		"Created": {
+			applied:  object("1", "a"),
+			expected: controllerutil.OperationResultCreated,
+		},
+		"Unchanged": {
+			existing: object("1", "a"),
+			applied:  object("1", "a"),
+			expected: controllerutil.OperationResultNone,
+		},
+		"DriftCorrected": {
+			existing: object("1", "a"),
+			applied:  object("2", "a"),
+			expected: operationResultDriftCorrected,
+		},
+		"DesiredStateChanged": {
+			existing: object("1", "a"),
+			applied:  object("2", "b"),
+			expected: controllerutil.OperationResultUpdated,
+		},
+		"NotHashed": {
+			existing: &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "config", ResourceVersion: "1"}},
+			applied:  object("2", "a"),
+			expected: controllerutil.OperationResultUpdated,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			// test
+			result := applyResult(tc.existing, tc.applied)
+
+			// validate
+			assert.Equal(t, tc.expected, result)
+		})
+	}
+}
+
+func TestDesiredHash(t *testing.T) {
+	t.Parallel()
+
+	// prepare
+	spec := corev1.PodSpec{Containers: []corev1.Container{{Name: "test", Image: "test-image:latest"}}}
+	changed := corev1.PodSpec{Containers: []corev1.Container{{Name: "test", Image: "test-image:1.0"}}}
+
+	// test
+	hash, err := desiredHash(testDeployment(1, spec))
+	require.NoError(t, err)
+	same, err := desiredHash(testDeployment(1, spec))
+	require.NoError(t, err)
+	other, err := desiredHash(testDeployment(1, changed))
+	require.NoError(t, err)
+
+	// validate
+	assert.Equal(t, hash, same)
+	assert.NotEqual(t, hash, other)
+}
@@ -17,7 +17,10 @@ package controlplane
 import (
 	"fmt"
 	"maps"
+	"net"
 	"path"
+	"slices"
+	"strings"
 
 	controlplanev1alpha1 "github.com/anza-labs/kink/api/controlplane/v1alpha1"
 	"github.com/anza-labs/kink/internal/manifests/manifestutils"
@@ -27,26 +30,48 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	netv1 "k8s.io/api/networking/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	gatewayapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayapiv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
 )
 
+// DefaultRoutedPort is the port on which the API server is exposed by the Gateway or the
+// Ingress, when no port is configured.
+const DefaultRoutedPort int32 = 443
+
 const (
 	apiServerPKIPath         = "/etc/pki/kube-apiserver"
 	apiServerCertificateFile = "tls.crt"
 	apiServerKeyFile         = "tls.key"
 
+	kubeletClientPKIPath         = "/etc/pki/kubelet-client"
+	kubeletClientCertificateFile = "tls.crt"
+	kubeletClientKeyFile         = "tls.key"
+
 	etcdPKIPath         = "/etc/pki/etcd"
-	etcdCAFile          = "ca.crt"
 	etcdCertificateFile = "tls.crt"
 	etcdKeyFile         = "tls.key"
+
+	defaultServiceAccountIssuer = "https://kubernetes.default.svc.cluster.local"
+
+	issuerDiscoveryClusterRoleBinding = "kink:service-account-issuer-discovery"
 )
 
 type APIServer struct {
 	KinkControlPlane *controlplanev1alpha1.KinkControlPlane
+	Hibernated       bool
+
+	// TLSRoutes enables the generation of the TLSRoute exposing the API server through a Gateway,
+	// which requires the experimental Gateway API CRDs to be installed.
+	TLSRoutes bool
+
+	// ServiceAccountKeys is the Secret holding the keys verifying the service account tokens.
+	// Until it is built, the tokens are verified with the signing key only.
+	ServiceAccountKeys *corev1.Secret
 }
 
 func (b *APIServer) Build() ([]client.Object, error) {
@@ -64,18 +89,23 @@ func (b *APIServer) Build() ([]client.Object, error) {
 	}
 	objects = append(objects, depl)
 
-	if b.KinkControlPlane.Spec.ControlPlaneEndpoint.Gateway != nil {
-		gtw, err := b.Gateway()
-		if err != nil {
-			return nil, fmt.Errorf("failed to build Gateway: %w", err)
+	if gateway := b.KinkControlPlane.Spec.ControlPlaneEndpoint.Gateway; gateway != nil {
+		// A shared Gateway is managed outside of the control plane.
+		if len(gateway.ParentRefs) == 0 {
+			gtw, err := b.Gateway()
+			if err != nil {
+				return nil, fmt.Errorf("failed to build Gateway: %w", err)
+			}
+			objects = append(objects, gtw)
 		}
-		objects = append(objects, gtw)
 
-		rte, err := b.HTTPRoute()
-		if err != nil {
-			return nil, fmt.Errorf("failed to build HTTPRoute: %w", err)
+		if b.TLSRoutes {
+			rte, err := b.TLSRoute()
+			if err != nil {
+				return nil, fmt.Errorf("failed to build TLSRoute: %w", err)
+			}
+			objects = append(objects, rte)
 		}
-		objects = append(objects, rte)
 	}
 
 	if b.KinkControlPlane.Spec.ControlPlaneEndpoint.Ingress != nil {
@@ -114,13 +144,18 @@ func (b *APIServer) Deployment() (*appsv1.Deployment, error) {
 	if replicas == nil {
 		replicas = ptr.To[int32](1)
 	}
+	if b.Hibernated {
+		replicas = ptr.To[int32](0)
+	}
 
 	podSpec := corev1.PodSpec{
 		Affinity:         manifestutils.Affinity(b.KinkControlPlane),
 		Containers:       []corev1.Container{b.container(image)},
 		Volumes:          b.volumes(),
 		ImagePullSecrets: b.KinkControlPlane.Spec.ImagePullSecrets,
+		SecurityContext:  manifestutils.PodSecurityContext(b.KinkControlPlane.Spec.APIServer.Container),
 	}
+	podSpec = withExtras(podSpec, b.KinkControlPlane.Spec.APIServer.PodExtras)
 
 	return &appsv1.Deployment{
 		ObjectMeta: metav1.ObjectMeta{
@@ -165,7 +200,18 @@ func (b *APIServer) Service() (*corev1.Service, error) {
 	selectorLabels := manifestutils.SelectorLabels(b.KinkControlPlane.ObjectMeta, ComponentAPIServer, ConceptControlPlane)
 	annotations := manifestutils.Annotations(b.KinkControlPlane, nil)
 
-	return &corev1.Service{
+	endpoint := b.KinkControlPlane.Spec.ControlPlaneEndpoint
+	serviceType := endpoint.ServiceType
+	var nodePort int32
+	switch {
+	case endpoint.SNIRouter != nil:
+		// The shared SNI router reaches the API server through the cluster network.
+		serviceType = corev1.ServiceTypeClusterIP
+	case serviceType == corev1.ServiceTypeNodePort && endpoint.Gateway == nil && endpoint.Ingress == nil:
+		nodePort = endpoint.Port
+	}
+
+	svc := &corev1.Service{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:        name,
 			Namespace:   b.KinkControlPlane.Namespace,
@@ -174,22 +220,44 @@ func (b *APIServer) Service() (*corev1.Service, error) {
 		},
 		Spec: corev1.ServiceSpec{
 			Selector: selectorLabels,
-			Type:     b.KinkControlPlane.Spec.ControlPlaneEndpoint.ServiceType,
+			Type:     serviceType,
 			Ports: []corev1.ServicePort{
 				{
 					Name:       "server",
 					Port:       6443,
+					NodePort:   nodePort,
 					TargetPort: intstr.FromString("server"),
 					Protocol:   corev1.ProtocolTCP,
 				},
 			},
 		},
-	}, nil
+	}
+
+	if spec := endpoint.Service; spec != nil {
+		// The labels managed by the operator take precedence, as the selectors rely on them.
+		svc.Labels = maps.Clone(spec.Labels)
+		if svc.Labels == nil {
+			svc.Labels = map[string]string{}
+		}
+		maps.Copy(svc.Labels, labels)
+		maps.Copy(svc.Annotations, spec.Annotations)
+
+		// The fields below are rejected by the API server for the Service types not supporting them.
+		if serviceType == corev1.ServiceTypeLoadBalancer {
+			svc.Spec.LoadBalancerClass = spec.LoadBalancerClass
+			svc.Spec.LoadBalancerSourceRanges = spec.LoadBalancerSourceRanges
+			svc.Spec.LoadBalancerIP = spec.LoadBalancerIP
+		}
+		if serviceType == corev1.ServiceTypeLoadBalancer || serviceType == corev1.ServiceTypeNodePort {
+			svc.Spec.ExternalTrafficPolicy = spec.ExternalTrafficPolicy
+		}
+	}
+
+	return svc, nil
 }
 
 func (b *APIServer) Gateway() (*gatewayapiv1.Gateway, error) {
 	name := naming.APIServer(b.KinkControlPlane.Name)
-	certName := naming.APIServerCertificate(b.KinkControlPlane.Name)
 
 	image, err := manifestutils.Image(
 		b.KinkControlPlane.Spec.APIServer.Image,
@@ -207,10 +275,14 @@ func (b *APIServer) Gateway() (*gatewayapiv1.Gateway, error) {
 	)
 	annotations := manifestutils.Annotations(b.KinkControlPlane, nil)
 
-	host := gatewayapiv1.Hostname(b.KinkControlPlane.Spec.ControlPlaneEndpoint.Host)
-	port := gatewayapiv1.PortNumber(b.KinkControlPlane.Spec.ControlPlaneEndpoint.Port)
+	port := gatewayapiv1.PortNumber(RoutedPort(b.KinkControlPlane))
 	gatewayClassName := gatewayapiv1.ObjectName(b.KinkControlPlane.Spec.ControlPlaneEndpoint.Gateway.GatewayClassName)
 
+	var hostname *gatewayapiv1.Hostname
+	if host := routedHost(b.KinkControlPlane); host != "" {
+		hostname = ptr.To(gatewayapiv1.Hostname(host))
+	}
+
 	return &gatewayapiv1.Gateway{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:        name,
@@ -223,14 +295,18 @@ func (b *APIServer) Gateway() (*gatewayapiv1.Gateway, error) {
 			Listeners: []gatewayapiv1.Listener{
 				{
 					Name:     gatewayapiv1.SectionName(name),
-					Hostname: &host,
+					Hostname: hostname,
 					Port:     port,
 					Protocol: gatewayapiv1.TLSProtocolType,
+					// The API server terminates TLS itself, thus no certificate is referenced.
 					TLS: &gatewayapiv1.GatewayTLSConfig{
 						Mode: ptr.To(gatewayapiv1.TLSModePassthrough),
-						CertificateRefs: []gatewayapiv1.SecretObjectReference{
+					},
+					AllowedRoutes: &gatewayapiv1.AllowedRoutes{
+						Kinds: []gatewayapiv1.RouteGroupKind{
 							{
-								Name: gatewayapiv1.ObjectName(certName),
+								Group: ptr.To(gatewayapiv1.Group(gatewayapiv1alpha2.GroupName)),
+								Kind:  "TLSRoute",
 							},
 						},
 					},
@@ -240,7 +316,7 @@ func (b *APIServer) Gateway() (*gatewayapiv1.Gateway, error) {
 	}, nil
 }
 
-func (b *APIServer) HTTPRoute() (*gatewayapiv1.HTTPRoute, error) {
+func (b *APIServer) TLSRoute() (*gatewayapiv1alpha2.TLSRoute, error) {
 	name := naming.APIServer(b.KinkControlPlane.Name)
 
 	image, err := manifestutils.Image(
@@ -259,33 +335,30 @@ func (b *APIServer) HTTPRoute() (*gatewayapiv1.HTTPRoute, error) {
 	)
 	annotations := manifestutils.Annotations(b.KinkControlPlane, nil)
 
-	host := gatewayapiv1.Hostname(b.KinkControlPlane.Spec.ControlPlaneEndpoint.Host)
+	var hostnames []gatewayapiv1alpha2.Hostname
+	if host := routedHost(b.KinkControlPlane); host != "" {
+		hostnames = []gatewayapiv1alpha2.Hostname{gatewayapiv1alpha2.Hostname(host)}
+	}
 
-	return &gatewayapiv1.HTTPRoute{
+	return &gatewayapiv1alpha2.TLSRoute{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:        name,
 			Namespace:   b.KinkControlPlane.Namespace,
 			Labels:      labels,
 			Annotations: annotations,
 		},
-		Spec: gatewayapiv1.HTTPRouteSpec{
-			CommonRouteSpec: gatewayapiv1.CommonRouteSpec{
-				ParentRefs: []gatewayapiv1.ParentReference{
-					{
-						Name: gatewayapiv1.ObjectName(name),
-					},
-				},
+		Spec: gatewayapiv1alpha2.TLSRouteSpec{
+			CommonRouteSpec: gatewayapiv1alpha2.CommonRouteSpec{
+				ParentRefs: b.parentRefs(),
 			},
-			Hostnames: []gatewayapiv1.Hostname{host},
-			Rules: []gatewayapiv1.HTTPRouteRule{
+			Hostnames: hostnames,
+			Rules: []gatewayapiv1alpha2.TLSRouteRule{
 				{
-					BackendRefs: []gatewayapiv1.HTTPBackendRef{
+					BackendRefs: []gatewayapiv1alpha2.BackendRef{
 						{
-							BackendRef: gatewayapiv1.BackendRef{
-								BackendObjectReference: gatewayapiv1.BackendObjectReference{
-									Name: gatewayapiv1.ObjectName(name),
-									Port: ptr.To(gatewayapiv1.PortNumber(6443)),
-								},
+							BackendObjectReference: gatewayapiv1alpha2.BackendObjectReference{
+								Name: gatewayapiv1alpha2.ObjectName(name),
+								Port: ptr.To(gatewayapiv1alpha2.PortNumber(6443)),
 							},
 						},
 					},
@@ -295,6 +368,52 @@ func (b *APIServer) HTTPRoute() (*gatewayapiv1.HTTPRoute, error) {
 	}, nil
 }
 
+// parentRefs returns the Gateways the TLSRoute is attached to, which are either the shared
+// Gateways or the Gateway created for the control plane.
+func (b *APIServer) parentRefs() []gatewayapiv1alpha2.ParentReference {
+	gateway := b.KinkControlPlane.Spec.ControlPlaneEndpoint.Gateway
+	if len(gateway.ParentRefs) == 0 {
+		return []gatewayapiv1alpha2.ParentReference{
+			{
+				Name: gatewayapiv1alpha2.ObjectName(naming.APIServer(b.KinkControlPlane.Name)),
+			},
+		}
+	}
+
+	refs := make([]gatewayapiv1alpha2.ParentReference, 0, len(gateway.ParentRefs))
+	for _, parent := range gateway.ParentRefs {
+		ref := gatewayapiv1alpha2.ParentReference{
+			Name: gatewayapiv1alpha2.ObjectName(parent.Name),
+		}
+		if parent.Namespace != "" {
+			ref.Namespace = ptr.To(gatewayapiv1alpha2.Namespace(parent.Namespace))
+		}
+		if parent.SectionName != "" {
+			ref.SectionName = ptr.To(gatewayapiv1alpha2.SectionName(parent.SectionName))
+		}
+		refs = append(refs, ref)
+	}
+	return refs
+}
+
+// RoutedPort returns the port on which the API server is exposed by the Gateway or the Ingress.
+func RoutedPort(kcp *controlplanev1alpha1.KinkControlPlane) int32 {
+	if port := kcp.Spec.ControlPlaneEndpoint.Port; port != 0 {
+		return port
+	}
+	return DefaultRoutedPort
+}
+
+// routedHost returns the hostname matched by the Gateway or the Ingress. IP addresses are not
+// sent through SNI, thus all hosts are matched until the endpoint is a hostname.
+func routedHost(kcp *controlplanev1alpha1.KinkControlPlane) string {
+	host := string(kcp.Spec.ControlPlaneEndpoint.Host)
+	if net.ParseIP(host) != nil {
+		return ""
+	}
+	return host
+}
+
 func (b *APIServer) Ingress() (*netv1.Ingress, error) {
 	name := naming.APIServer(b.KinkControlPlane.Name)
 
@@ -315,6 +434,12 @@ func (b *APIServer) Ingress() (*netv1.Ingress, error) {
 	annotations := manifestutils.Annotations(b.KinkControlPlane, nil)
 	maps.Insert(annotations, maps.All(b.KinkControlPlane.Spec.ControlPlaneEndpoint.Ingress.Annotations))
 
+	host := routedHost(b.KinkControlPlane)
+	var hosts []string
+	if host != "" {
+		hosts = []string{host}
+	}
+
 	return &netv1.Ingress{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:        name,
@@ -326,15 +451,13 @@ func (b *APIServer) Ingress() (*netv1.Ingress, error) {
 			IngressClassName: &b.KinkControlPlane.Spec.ControlPlaneEndpoint.Ingress.IngressClassName,
 			TLS: []netv1.IngressTLS{
 				{
-					Hosts: []string{
-						string(b.KinkControlPlane.Spec.ControlPlaneEndpoint.Host),
-					},
+					Hosts:      hosts,
 					SecretName: naming.APIServerCertificate(b.KinkControlPlane.Name),
 				},
 			},
 			Rules: []netv1.IngressRule{
 				{
-					Host: string(b.KinkControlPlane.Spec.ControlPlaneEndpoint.Host),
+					Host: host,
 					IngressRuleValue: netv1.IngressRuleValue{
 						HTTP: &netv1.HTTPIngressRuleValue{
 							Paths: []netv1.HTTPIngressPath{
@@ -360,7 +483,7 @@ func (b *APIServer) Ingress() (*netv1.Ingress, error) {
 }
 
 func (b *APIServer) volumes() []corev1.Volume {
-	return []corev1.Volume{
+	volumes := []corev1.Volume{
 		{
 			Name: "etcd",
 			VolumeSource: corev1.VolumeSource{
@@ -380,28 +503,34 @@ func (b *APIServer) volumes() []corev1.Volume {
 			},
 		},
 		{
-			Name: "root-ca",
+			Name: "kubelet-client",
 			VolumeSource: corev1.VolumeSource{
 				Secret: &corev1.SecretVolumeSource{
-					SecretName:  naming.ClusterCA(b.KinkControlPlane.Name),
+					SecretName:  naming.KubeletClientCertificate(b.KinkControlPlane.Name),
 					DefaultMode: ptr.To[int32](420),
 				},
 			},
 		},
+		trustBundleVolume(b.KinkControlPlane),
 		{
 			Name: "service-accounts-cert",
 			VolumeSource: corev1.VolumeSource{
 				Secret: &corev1.SecretVolumeSource{
-					SecretName:  naming.ServiceAccountCertificate(b.KinkControlPlane.Name),
+					SecretName:  naming.ServiceAccountSigningKey(b.KinkControlPlane.Name),
 					DefaultMode: ptr.To[int32](420),
 				},
 			},
 		},
 	}
+	if b.ServiceAccountKeys != nil {
+		volumes = append(volumes, serviceAccountKeysVolume(b.KinkControlPlane))
+	}
+	volumes = append(volumes, manifestutils.TmpVolume())
+	return volumes
 }
 
 func (b *APIServer) volumeMounts() []corev1.VolumeMount {
-	return []corev1.VolumeMount{
+	mounts := []corev1.VolumeMount{
 		{
 			Name:      "etcd",
 			ReadOnly:  true,
@@ -413,9 +542,14 @@ func (b *APIServer) volumeMounts() []corev1.VolumeMount {
 			MountPath: apiServerPKIPath,
 		},
 		{
-			Name:      "root-ca",
+			Name:      "kubelet-client",
 			ReadOnly:  true,
-			MountPath: rootPKIPath,
+			MountPath: kubeletClientPKIPath,
+		},
+		{
+			Name:      "trust-bundle",
+			ReadOnly:  true,
+			MountPath: trustBundlePKIPath,
 		},
 		{
 			Name:      "service-accounts-cert",
@@ -423,6 +557,60 @@ func (b *APIServer) volumeMounts() []corev1.VolumeMount {
 			MountPath: serviceAccountsPKIPath,
 		},
 	}
+	if b.ServiceAccountKeys != nil {
+		mounts = append(mounts, corev1.VolumeMount{
+			Name:      "service-account-keys",
+			ReadOnly:  true,
+			MountPath: serviceAccountKeysPKIPath,
+		})
+	}
+	mounts = append(mounts, manifestutils.TmpVolumeMount())
+	return mounts
+}
+
+// ServiceAccountIssuer returns the identifier of the service account token issuer.
+func ServiceAccountIssuer(kcp *controlplanev1alpha1.KinkControlPlane) string {
+	sa := kcp.Spec.APIServer.ServiceAccount
+	switch {
+	case sa == nil:
+		return defaultServiceAccountIssuer
+	case sa.Issuer != "":
+		return sa.Issuer
+	case sa.PublicDiscovery && kcp.Spec.ControlPlaneEndpoint.Host != "":
+		return naming.PublicAPIServerEndpoint(kcp.Spec.ControlPlaneEndpoint.Host, kcp.Spec.ControlPlaneEndpoint.Port)
+	default:
+		return defaultServiceAccountIssuer
+	}
+}
+
+// IssuerDiscoveryClusterRoleBinding returns the ClusterRoleBinding, created in the workload
+// cluster, allowing anonymous requests to the service account issuer discovery endpoints.
+func (b *APIServer) IssuerDiscoveryClusterRoleBinding() *rbacv1.ClusterRoleBinding {
+	return &rbacv1.ClusterRoleBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: issuerDiscoveryClusterRoleBinding,
+		},
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: rbacv1.GroupName,
+			Kind:     "ClusterRole",
+			Name:     "system:service-account-issuer-discovery",
+		},
+		Subjects: []rbacv1.Subject{
+			{
+				APIGroup: rbacv1.GroupName,
+				Kind:     rbacv1.GroupKind,
+				Name:     "system:unauthenticated",
+			},
+		},
+	}
+}
+
+// serviceAccountKeyFiles returns the files holding the keys verifying the service account tokens.
+func (b *APIServer) serviceAccountKeyFiles() []string {
+	if b.ServiceAccountKeys == nil {
+		return []string{path.Join(serviceAccountsPKIPath, serviceAccountsKeyFile)}
+	}
+	return serviceAccountKeyFiles(b.ServiceAccountKeys)
 }
 
 func (b *APIServer) container(image string) corev1.Container {
@@ -432,30 +620,63 @@ func (b *APIServer) container(image string) corev1.Container {
 
 	args := map[string]string{ // TODO: add default flags
 		"v":                                fmt.Sprint(verbosity),
-		"client-ca-file":                   path.Join(rootPKIPath, rootCAFile),
+		"client-ca-file":                   path.Join(trustBundlePKIPath, ClusterCABundleKey),
 		"tls-cert-file":                    path.Join(apiServerPKIPath, apiServerCertificateFile),
 		"tls-private-key-file":             path.Join(apiServerPKIPath, apiServerKeyFile),
-		"service-account-key-file":         path.Join(serviceAccountsPKIPath, serviceAccountsCertificateFile),
 		"service-account-signing-key-file": path.Join(serviceAccountsPKIPath, serviceAccountsKeyFile),
-		"service-account-issuer":           "https://kubernetes.default.svc.cluster.local",
-		"etcd-cafile":                      path.Join(etcdPKIPath, etcdCAFile),
+		"etcd-cafile":                      path.Join(trustBundlePKIPath, KineCABundleKey),
 		"etcd-certfile":                    path.Join(etcdPKIPath, etcdCertificateFile),
 		"etcd-keyfile":                     path.Join(etcdPKIPath, etcdKeyFile),
 		"etcd-servers":                     naming.KineEndpoint(b.KinkControlPlane.Name, b.KinkControlPlane.Namespace),
 		"authorization-mode":               "Node,RBAC",
+		"kubelet-client-certificate":       path.Join(kubeletClientPKIPath, kubeletClientCertificateFile),
+		"kubelet-client-key":               path.Join(kubeletClientPKIPath, kubeletClientKeyFile),
+		"kubelet-preferred-address-types":  "InternalIP,ExternalIP,Hostname",
 		"service-cluster-ip-range":         "10.32.0.0/24",
 	}
+	if kubelet := cfg.Kubelet; kubelet != nil {
+		if len(kubelet.PreferredAddressTypes) > 0 {
+			addressTypes := []string{}
+			for _, t := range kubelet.PreferredAddressTypes {
+				addressTypes = append(addressTypes, string(t))
+			}
+			args["kubelet-preferred-address-types"] = strings.Join(addressTypes, ",")
+		}
+		if kubelet.VerifyServingCertificates {
+			args["kubelet-certificate-authority"] = path.Join(trustBundlePKIPath, ClusterCABundleKey)
+		}
+	}
+	if sa := cfg.ServiceAccount; sa != nil {
+		if len(sa.APIAudiences) > 0 {
+			args["api-audiences"] = strings.Join(sa.APIAudiences, ",")
+		}
+		if sa.JWKSURI != "" {
+			args["service-account-jwks-uri"] = sa.JWKSURI
+		}
+	}
 	for arg, value := range cfg.ExtraArgs {
-		if _, ok := args[arg]; !ok {
-			args[arg] = value
+		if _, ok := args[arg]; ok || arg == "service-account-key-file" || arg == "service-account-issuer" {
+			continue
 		}
+		args[arg] = value
+	}
+
+	// The flags are repeated, so that the tokens signed by the previous keys or issued by the
+	// default issuer remain valid. The first issuer is used to issue new tokens.
+	cmd := buildArgs(args)
+	for _, file := range b.serviceAccountKeyFiles() {
+		cmd = append(cmd, "--service-account-key-file="+file)
+	}
+	issuers := []string{ServiceAccountIssuer(b.KinkControlPlane), defaultServiceAccountIssuer}
+	for _, issuer := range slices.Compact(issuers) {
+		cmd = append(cmd, "--service-account-issuer="+issuer)
 	}
 
 	return corev1.Container{
 		Name:      naming.APIServerContainer(),
 		Image:     image,
 		Command:   []string{"kube-apiserver"},
-		Args:      buildArgs(args),
+		Args:      cmd,
 		Resources: resources,
 		Ports: []corev1.ContainerPort{
 			{
@@ -466,7 +687,7 @@ func (b *APIServer) container(image string) corev1.Container {
 		},
 		VolumeMounts:    b.volumeMounts(),
 		ImagePullPolicy: b.KinkControlPlane.Spec.APIServer.ImagePullPolicy,
-		SecurityContext: nil,
+		SecurityContext: manifestutils.SecurityContext(b.KinkControlPlane.Spec.APIServer.Container),
 		StartupProbe: &corev1.Probe{
 			ProbeHandler: corev1.ProbeHandler{
 				HTTPGet: &corev1.HTTPGetAction{
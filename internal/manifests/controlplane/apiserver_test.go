@@ -15,14 +15,20 @@
 package controlplane
 
 import (
+	"slices"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 
 	controlplanev1alpha1 "github.com/anza-labs/kink/api/controlplane/v1alpha1"
+	kinkcorev1alpha1 "github.com/anza-labs/kink/api/core/v1alpha1"
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	gatewayapiv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
 )
 
 func TestAPIServer(t *testing.T) {
@@ -43,6 +49,394 @@ func TestAPIServer(t *testing.T) {
 		assert.Len(t, actual, 2)
 	})
 
+	t.Run("Hibernated", func(t *testing.T) {
+		t.Parallel()
+
+		// prepare
+		apiServer := (&APIServer{
+			KinkControlPlane: &controlplanev1alpha1.KinkControlPlane{
+				Spec: controlplanev1alpha1.KinkControlPlaneSpec{Replicas: ptr.To[int32](3)},
+			},
+			Hibernated: true,
+		})
+
+		// test
+		actual, err := apiServer.Deployment()
+
+		// validate
+		assert.NoError(t, err)
+		assert.Equal(t, int32(0), *actual.Spec.Replicas)
+	})
+
+	t.Run("ServiceAccountKeys", func(t *testing.T) {
+		t.Parallel()
+
+		// prepare
+		apiServer := (&APIServer{
+			KinkControlPlane: &controlplanev1alpha1.KinkControlPlane{},
+			ServiceAccountKeys: &corev1.Secret{
+				Data: map[string][]byte{"b.pub": nil, "a.pub": nil},
+			},
+		})
+
+		// test
+		actual, err := apiServer.Deployment()
+
+		// validate
+		assert.NoError(t, err)
+		assert.Subset(t, actual.Spec.Template.Spec.Containers[0].Args, []string{
+			"--service-account-key-file=/etc/pki/service-account-keys/a.pub",
+			"--service-account-key-file=/etc/pki/service-account-keys/b.pub",
+		})
+		assert.Contains(t, actual.Spec.Template.Spec.Volumes, serviceAccountKeysVolume(apiServer.KinkControlPlane))
+	})
+
+	t.Run("PodExtras", func(t *testing.T) {
+		t.Parallel()
+
+		// prepare
+		extras := kinkcorev1alpha1.PodExtras{
+			ExtraVolumes:        []corev1.Volume{{Name: "kms-socket"}, {Name: "ca-bundle"}},
+			ExtraVolumeMounts:   []corev1.VolumeMount{{Name: "kms-socket", MountPath: "/var/run/kms"}},
+			ExtraEnv:            []corev1.EnvVar{{Name: "GODEBUG", Value: "x509sha1=1"}},
+			ExtraContainers:     []corev1.Container{{Name: "kms-plugin"}, {Name: "log-shipper"}},
+			ExtraInitContainers: []corev1.Container{{Name: "fetch-ca"}},
+		}
+		apiServer := (&APIServer{
+			KinkControlPlane: &controlplanev1alpha1.KinkControlPlane{
+				Spec: controlplanev1alpha1.KinkControlPlaneSpec{
+					APIServer: controlplanev1alpha1.APIServer{
+						KubeComponent: controlplanev1alpha1.KubeComponent{PodExtras: extras},
+					},
+				},
+			},
+		})
+
+		// test
+		actual, err := apiServer.Deployment()
+
+		// validate
+		assert.NoError(t, err)
+		podSpec := actual.Spec.Template.Spec
+		names := []string{}
+		for _, c := range podSpec.Containers {
+			names = append(names, c.Name)
+		}
+		assert.Equal(t, []string{"api-server", "kms-plugin", "log-shipper"}, names)
+		assert.Equal(t, extras.ExtraInitContainers, podSpec.InitContainers)
+		assert.Equal(t, extras.ExtraVolumes, podSpec.Volumes[len(podSpec.Volumes)-2:])
+		mounts := podSpec.Containers[0].VolumeMounts
+		assert.Equal(t, extras.ExtraVolumeMounts[0], mounts[len(mounts)-1])
+		assert.Equal(t, extras.ExtraEnv, podSpec.Containers[0].Env)
+	})
+
+	t.Run("Kubelet", func(t *testing.T) {
+		t.Parallel()
+
+		for name, tc := range map[string]struct {
+			kubelet    *controlplanev1alpha1.KubeletConnection
+			expected   []string
+			unexpected []string
+		}{
+			"Default": {
+				expected: []string{
+					"--kubelet-client-certificate=/etc/pki/kubelet-client/tls.crt",
+					"--kubelet-client-key=/etc/pki/kubelet-client/tls.key",
+					"--kubelet-preferred-address-types=InternalIP,ExternalIP,Hostname",
+				},
+				unexpected: []string{
+					"--kubelet-certificate-authority=/etc/pki/trust-bundle/cluster-ca.crt",
+				},
+			},
+			"Verified": {
+				kubelet: &controlplanev1alpha1.KubeletConnection{
+					PreferredAddressTypes:     []corev1.NodeAddressType{corev1.NodeHostName, corev1.NodeInternalIP},
+					VerifyServingCertificates: true,
+				},
+				expected: []string{
+					"--kubelet-certificate-authority=/etc/pki/trust-bundle/cluster-ca.crt",
+					"--kubelet-preferred-address-types=Hostname,InternalIP",
+				},
+			},
+		} {
+			t.Run(name, func(t *testing.T) {
+				t.Parallel()
+
+				// prepare
+				apiServer := (&APIServer{
+					KinkControlPlane: &controlplanev1alpha1.KinkControlPlane{
+						Spec: controlplanev1alpha1.KinkControlPlaneSpec{
+							APIServer: controlplanev1alpha1.APIServer{Kubelet: tc.kubelet},
+						},
+					},
+				})
+
+				// test
+				actual, err := apiServer.Deployment()
+
+				// validate
+				assert.NoError(t, err)
+				args := actual.Spec.Template.Spec.Containers[0].Args
+				assert.Subset(t, args, tc.expected)
+				for _, arg := range tc.unexpected {
+					assert.NotContains(t, args, arg)
+				}
+			})
+		}
+	})
+
+	t.Run("ServiceAccount", func(t *testing.T) {
+		t.Parallel()
+
+		for name, tc := range map[string]struct {
+			sa       *controlplanev1alpha1.ServiceAccount
+			issuer   string
+			expected []string
+		}{
+			"Default": {
+				issuer: "https://kubernetes.default.svc.cluster.local",
+				expected: []string{
+					"--service-account-issuer=https://kubernetes.default.svc.cluster.local",
+				},
+			},
+			"Issuer": {
+				sa: &controlplanev1alpha1.ServiceAccount{
+					Issuer:       "https://issuer.example.com",
+					APIAudiences: []string{"vault", "sts.amazonaws.com"},
+					JWKSURI:      "https://keys.example.com/jwks",
+				},
+				issuer: "https://issuer.example.com",
+				expected: []string{
+					"--api-audiences=vault,sts.amazonaws.com",
+					"--service-account-jwks-uri=https://keys.example.com/jwks",
+					"--service-account-issuer=https://issuer.example.com",
+					"--service-account-issuer=https://kubernetes.default.svc.cluster.local",
+				},
+			},
+			"PublicDiscovery": {
+				sa:     &controlplanev1alpha1.ServiceAccount{PublicDiscovery: true},
+				issuer: "https://example.com:443",
+				expected: []string{
+					"--service-account-issuer=https://example.com:443",
+					"--service-account-issuer=https://kubernetes.default.svc.cluster.local",
+				},
+			},
+		} {
+			t.Run(name, func(t *testing.T) {
+				t.Parallel()
+
+				// prepare
+				apiServer := (&APIServer{
+					KinkControlPlane: &controlplanev1alpha1.KinkControlPlane{
+						Spec: controlplanev1alpha1.KinkControlPlaneSpec{
+							ControlPlaneEndpoint: controlplanev1alpha1.APIEndpoint{Host: "example.com", Port: 443},
+							APIServer: controlplanev1alpha1.APIServer{
+								ServiceAccount: tc.sa,
+								KubeComponent: controlplanev1alpha1.KubeComponent{
+									ExtraArgs: map[string]string{"service-account-issuer": "https://ignored.example.com"},
+								},
+							},
+						},
+					},
+				})
+
+				// test
+				actual, err := apiServer.Deployment()
+
+				// validate
+				assert.NoError(t, err)
+				args := actual.Spec.Template.Spec.Containers[0].Args
+				assert.Subset(t, args, tc.expected)
+				assert.NotContains(t, args, "--service-account-issuer=https://ignored.example.com")
+
+				// The first issuer is used to issue the tokens.
+				idx := slices.IndexFunc(args, func(arg string) bool {
+					return strings.HasPrefix(arg, "--service-account-issuer=")
+				})
+				assert.Equal(t, "--service-account-issuer="+tc.issuer, args[idx])
+			})
+		}
+	})
+
+	t.Run("Gateway", func(t *testing.T) {
+		t.Parallel()
+
+		for name, tc := range map[string]struct {
+			gateway         *controlplanev1alpha1.Gateway
+			tlsRoutes       bool
+			expectedObjects int
+			expectedParents []gatewayapiv1alpha2.ParentReference
+		}{
+			"Dedicated": {
+				gateway:         &controlplanev1alpha1.Gateway{GatewayClassName: "test"},
+				tlsRoutes:       true,
+				expectedObjects: 4,
+				expectedParents: []gatewayapiv1alpha2.ParentReference{{Name: "test-api-server"}},
+			},
+			"Shared": {
+				gateway: &controlplanev1alpha1.Gateway{
+					ParentRefs: []controlplanev1alpha1.GatewayParentReference{
+						{Name: "shared", Namespace: "gateways", SectionName: "tls"},
+					},
+				},
+				tlsRoutes:       true,
+				expectedObjects: 3,
+				expectedParents: []gatewayapiv1alpha2.ParentReference{
+					{
+						Name:        "shared",
+						Namespace:   ptr.To(gatewayapiv1alpha2.Namespace("gateways")),
+						SectionName: ptr.To(gatewayapiv1alpha2.SectionName("tls")),
+					},
+				},
+			},
+			"WithoutTLSRoutes": {
+				gateway:         &controlplanev1alpha1.Gateway{GatewayClassName: "test"},
+				expectedObjects: 3,
+				expectedParents: []gatewayapiv1alpha2.ParentReference{{Name: "test-api-server"}},
+			},
+		} {
+			t.Run(name, func(t *testing.T) {
+				t.Parallel()
+
+				// prepare
+				apiServer := (&APIServer{TLSRoutes: tc.tlsRoutes, KinkControlPlane: &controlplanev1alpha1.KinkControlPlane{
+					ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+					Spec: controlplanev1alpha1.KinkControlPlaneSpec{
+						ControlPlaneEndpoint: controlplanev1alpha1.APIEndpoint{
+							Host:    "test.example.com",
+							Port:    443,
+							Gateway: tc.gateway,
+						},
+					},
+				}})
+
+				// test
+				objects, err := apiServer.Build()
+				route, routeErr := apiServer.TLSRoute()
+
+				// validate
+				assert.NoError(t, err)
+				assert.NoError(t, routeErr)
+				assert.Len(t, objects, tc.expectedObjects)
+				assert.Equal(t, tc.expectedParents, route.Spec.ParentRefs)
+				assert.Equal(t, []gatewayapiv1alpha2.Hostname{"test.example.com"}, route.Spec.Hostnames)
+				assert.Equal(t, gatewayapiv1alpha2.ObjectName("test-api-server"), route.Spec.Rules[0].BackendRefs[0].Name)
+			})
+		}
+	})
+
+	t.Run("NodePort", func(t *testing.T) {
+		t.Parallel()
+
+		for name, tc := range map[string]struct {
+			endpoint controlplanev1alpha1.APIEndpoint
+			expected int32
+		}{
+			"Pinned": {
+				endpoint: controlplanev1alpha1.APIEndpoint{ServiceType: corev1.ServiceTypeNodePort, Port: 30443},
+				expected: 30443,
+			},
+			"Allocated": {
+				endpoint: controlplanev1alpha1.APIEndpoint{ServiceType: corev1.ServiceTypeNodePort},
+			},
+			"Gateway": {
+				endpoint: controlplanev1alpha1.APIEndpoint{
+					ServiceType: corev1.ServiceTypeNodePort,
+					Port:        443,
+					Gateway:     &controlplanev1alpha1.Gateway{GatewayClassName: "test"},
+				},
+			},
+		} {
+			t.Run(name, func(t *testing.T) {
+				t.Parallel()
+
+				// prepare
+				apiServer := (&APIServer{KinkControlPlane: &controlplanev1alpha1.KinkControlPlane{
+					Spec: controlplanev1alpha1.KinkControlPlaneSpec{ControlPlaneEndpoint: tc.endpoint},
+				}})
+
+				// test
+				actual, err := apiServer.Service()
+
+				// validate
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expected, actual.Spec.Ports[0].NodePort)
+			})
+		}
+	})
+
+	t.Run("ServiceSpec", func(t *testing.T) {
+		t.Parallel()
+
+		service := &controlplanev1alpha1.Service{
+			Annotations:              map[string]string{"metallb.io/address-pool": "internal"},
+			Labels:                   map[string]string{"team": "platform", "app.kubernetes.io/component": "other"},
+			LoadBalancerClass:        ptr.To("example.com/internal"),
+			LoadBalancerSourceRanges: []string{"10.0.0.0/8"},
+			LoadBalancerIP:           "10.0.0.1",
+			ExternalTrafficPolicy:    corev1.ServiceExternalTrafficPolicyLocal,
+		}
+
+		for name, tc := range map[string]struct {
+			endpoint     controlplanev1alpha1.APIEndpoint
+			loadBalancer bool
+			policy       corev1.ServiceExternalTrafficPolicy
+		}{
+			"LoadBalancer": {
+				endpoint:     controlplanev1alpha1.APIEndpoint{ServiceType: corev1.ServiceTypeLoadBalancer, Service: service},
+				loadBalancer: true,
+				policy:       corev1.ServiceExternalTrafficPolicyLocal,
+			},
+			"NodePort": {
+				endpoint: controlplanev1alpha1.APIEndpoint{ServiceType: corev1.ServiceTypeNodePort, Service: service},
+				policy:   corev1.ServiceExternalTrafficPolicyLocal,
+			},
+			"SNIRouter": {
+				endpoint: controlplanev1alpha1.APIEndpoint{
+					ServiceType: corev1.ServiceTypeLoadBalancer,
+					SNIRouter:   &controlplanev1alpha1.SNIRouter{},
+					Service:     service,
+				},
+			},
+		} {
+			t.Run(name, func(t *testing.T) {
+				t.Parallel()
+
+				// prepare
+				apiServer := (&APIServer{KinkControlPlane: &controlplanev1alpha1.KinkControlPlane{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        "test",
+						Namespace:   "test",
+						Annotations: map[string]string{"example.com/owner": "test"},
+					},
+					Spec: controlplanev1alpha1.KinkControlPlaneSpec{ControlPlaneEndpoint: tc.endpoint},
+				}})
+
+				// test
+				actual, err := apiServer.Service()
+
+				// validate
+				assert.NoError(t, err)
+				assert.Equal(t, map[string]string{
+					"example.com/owner":       "test",
+					"metallb.io/address-pool": "internal",
+				}, actual.Annotations)
+				assert.Equal(t, "platform", actual.Labels["team"])
+				assert.Equal(t, ComponentAPIServer, actual.Labels["app.kubernetes.io/component"])
+				assert.Equal(t, tc.policy, actual.Spec.ExternalTrafficPolicy)
+				if tc.loadBalancer {
+					assert.Equal(t, service.LoadBalancerClass, actual.Spec.LoadBalancerClass)
+					assert.Equal(t, service.LoadBalancerSourceRanges, actual.Spec.LoadBalancerSourceRanges)
+					assert.Equal(t, service.LoadBalancerIP, actual.Spec.LoadBalancerIP)
+				} else {
+					assert.Nil(t, actual.Spec.LoadBalancerClass)
+					assert.Empty(t, actual.Spec.LoadBalancerSourceRanges)
+					assert.Empty(t, actual.Spec.LoadBalancerIP)
+				}
+			})
+		}
+	})
+
 	t.Run("Deployment", func(t *testing.T) {
 		t.Parallel()
 
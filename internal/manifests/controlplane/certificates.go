@@ -25,12 +25,21 @@ import (
 	"github.com/anza-labs/kink/internal/naming"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 // IssuerKind defines the kind of issuer used for certificates.
 const IssuerKind = "Issuer"
 
+const (
+	// DefaultCADuration is the lifetime of the CA certificates, unless configured (10 years).
+	DefaultCADuration = time.Hour * 24 * 365 * 10
+
+	// DefaultLeafDuration is the lifetime of the leaf certificates, unless configured (1 year).
+	DefaultLeafDuration = time.Hour * 24 * 365
+)
+
 var (
 	// defaultRenewBefore specifies the default time before expiration
 	// when certificates should be renewed (30 days).
@@ -38,13 +47,20 @@ var (
 
 	// defaultCertResidualTime defines the default validity period
 	// for non-CA certificates (1 year).
-	defaultCertResidualTime = metav1.Duration{Duration: time.Hour * 24 * 365}
+	defaultCertResidualTime = metav1.Duration{Duration: DefaultLeafDuration}
 
 	// defaultCAResidualTime defines the default validity period
 	// for CA certificates (10 years).
-	defaultCAResidualTime = metav1.Duration{Duration: time.Hour * 24 * 365 * 10}
+	defaultCAResidualTime = metav1.Duration{Duration: DefaultCADuration}
 )
 
+// certificateProfile holds the lifetime and the private key applied to a group of certificates.
+type certificateProfile struct {
+	duration    *metav1.Duration
+	renewBefore *metav1.Duration
+	privateKey  *cmv1.CertificatePrivateKey
+}
+
 // Certificates manages the generation of control plane certificates.
 type Certificates struct {
 	KinkControlPlane *controlplanev1alpha1.KinkControlPlane
@@ -52,18 +68,110 @@ type Certificates struct {
 
 // Build constructs and returns a list of certificate-related runtime objects.
 func (b *Certificates) Build() []client.Object {
-	objects := []client.Object{
-		b.RootCA(),
-		b.ClusterCA(), b.ClusterCAIssuer(),
+	objects := []client.Object{}
+	// The self-signed root CA is only needed when the cluster CA is generated locally.
+	if b.localCA() {
+		objects = append(objects, b.RootCA())
+	}
+	objects = append(objects,
+		b.ClusterCAIssuer(),
 		b.APIServer(), b.ServiceAccountCertificate(),
 		b.AdminCertificate(), b.SchedulerCertificate(), b.ControllerManagerCertificate(),
+		b.KubeletClientCertificate(),
 		b.FrontProxyCA(),
 		b.KineCA(),
 		b.KineCAIssuer(), b.KineServer(), b.KineAPIServerClient(),
+	)
+	// An imported cluster CA is used as-is, so it is not issued by cert-manager.
+	if certs := b.KinkControlPlane.Spec.Certificates; certs == nil || certs.CASecretRef == nil {
+		objects = append(objects, b.ClusterCA())
+	}
+	if (&Monitoring{KinkControlPlane: b.KinkControlPlane}).Enabled() {
+		objects = append(objects, b.MetricsClientCertificate())
+	}
+	for _, kubeconfig := range b.KinkControlPlane.Spec.Kubeconfigs {
+		objects = append(objects, b.UserCertificate(kubeconfig))
 	}
 	return objects
 }
 
+// caProfile returns the profile of the CA certificates.
+func (b *Certificates) caProfile() certificateProfile {
+	var cfg *controlplanev1alpha1.CertificateProfile
+	if certs := b.KinkControlPlane.Spec.Certificates; certs != nil {
+		cfg = certs.CA
+	}
+
+	profile := newCertificateProfile(cfg, defaultCAResidualTime)
+	if profile.privateKey == nil {
+		profile.privateKey = &cmv1.CertificatePrivateKey{
+			Algorithm: cmv1.RSAKeyAlgorithm,
+			Size:      2048,
+		}
+	}
+	return b.withRotationPolicy(profile)
+}
+
+// leafProfile returns the profile of the leaf certificates.
+func (b *Certificates) leafProfile() certificateProfile {
+	var cfg *controlplanev1alpha1.CertificateProfile
+	if certs := b.KinkControlPlane.Spec.Certificates; certs != nil {
+		cfg = certs.Leaf
+	}
+
+	return b.withRotationPolicy(newCertificateProfile(cfg, defaultCertResidualTime))
+}
+
+// withRotationPolicy generates new private keys for the certificates re-issued while the
+// certificate authorities are rotated. Otherwise, the private keys are kept on renewal, so that
+// renewing a CA does not invalidate the certificates it issued.
+func (b *Certificates) withRotationPolicy(profile certificateProfile) certificateProfile {
+	status := b.KinkControlPlane.Status.CertificateRotation
+	if status == nil || status.Phase == controlplanev1alpha1.RotationPhaseCompleted {
+		return profile
+	}
+
+	if profile.privateKey == nil {
+		profile.privateKey = &cmv1.CertificatePrivateKey{}
+	}
+	profile.privateKey.RotationPolicy = cmv1.RotationPolicyAlways
+	return profile
+}
+
+// newCertificateProfile converts the configured profile, falling back to the defaults for unset fields.
+func newCertificateProfile(
+	cfg *controlplanev1alpha1.CertificateProfile,
+	defaultDuration metav1.Duration,
+) certificateProfile {
+	profile := certificateProfile{
+		duration:    ptr.To(defaultDuration),
+		renewBefore: ptr.To(defaultRenewBefore),
+	}
+	if cfg == nil {
+		return profile
+	}
+
+	if cfg.Duration != nil {
+		profile.duration = ptr.To(*cfg.Duration)
+	}
+	if cfg.RenewBefore != nil {
+		profile.renewBefore = ptr.To(*cfg.RenewBefore)
+	} else if profile.renewBefore.Duration >= profile.duration.Duration {
+		// Short-lived certificates are renewed after two thirds of their lifetime.
+		profile.renewBefore = &metav1.Duration{Duration: profile.duration.Duration / 3}
+	}
+	if cfg.PrivateKey != nil {
+		profile.privateKey = &cmv1.CertificatePrivateKey{
+			Algorithm: cmv1.RSAKeyAlgorithm,
+			Size:      int(cfg.PrivateKey.Size),
+		}
+		if cfg.PrivateKey.Algorithm == controlplanev1alpha1.ECDSAKeyAlgorithm {
+			profile.privateKey.Algorithm = cmv1.ECDSAKeyAlgorithm
+		}
+	}
+	return profile
+}
+
 // RootCA generates a self-signed root CA issuer.
 func (b *Certificates) RootCA() *cmv1.Issuer {
 	selectorLabels := manifestutils.SelectorLabels(
@@ -87,9 +195,11 @@ func (b *Certificates) RootCA() *cmv1.Issuer {
 	}
 }
 
-// ClusterCA creates a certificate to act as the cluster's CA.
+// ClusterCA creates a certificate to act as the cluster's CA. The CA is issued by the root CA,
+// or by the external issuer referenced in the spec.
 func (b *Certificates) ClusterCA() *cmv1.Certificate {
 	name := naming.ClusterCA(b.KinkControlPlane.Name)
+	ca := b.caProfile()
 
 	selectorLabels := manifestutils.SelectorLabels(
 		b.KinkControlPlane.ObjectMeta,
@@ -107,17 +217,11 @@ func (b *Certificates) ClusterCA() *cmv1.Certificate {
 		Spec: cmv1.CertificateSpec{
 			IsCA:        true,
 			CommonName:  "Kubernetes API",
-			Duration:    &defaultCAResidualTime,
-			RenewBefore: &defaultRenewBefore,
-			IssuerRef: cmmetav1.ObjectReference{
-				Name: naming.RootCA(b.KinkControlPlane.Name),
-				Kind: IssuerKind,
-			},
-			PrivateKey: &cmv1.CertificatePrivateKey{
-				Algorithm: cmv1.RSAKeyAlgorithm,
-				Size:      2048,
-			},
-			SecretName: name,
+			Duration:    ca.duration,
+			RenewBefore: ca.renewBefore,
+			IssuerRef:   b.clusterCAIssuerRef(),
+			PrivateKey:  ca.privateKey,
+			SecretName:  name,
 			SecretTemplate: &cmv1.CertificateSecretTemplate{
 				Labels: selectorLabels,
 			},
@@ -125,6 +229,54 @@ func (b *Certificates) ClusterCA() *cmv1.Certificate {
 	}
 }
 
+// localCA reports whether the cluster CA is generated locally, issued by the self-signed root CA.
+func (b *Certificates) localCA() bool {
+	certs := b.KinkControlPlane.Spec.Certificates
+	return certs == nil || (certs.IssuerRef == nil && certs.CASecretRef == nil)
+}
+
+// caIssuerRef returns the reference to the issuer of the Kine and front proxy CAs. They are
+// issued by the self-signed root CA, or by the cluster CA when it is chained to an external PKI.
+func (b *Certificates) caIssuerRef() cmmetav1.ObjectReference {
+	name := naming.RootCA(b.KinkControlPlane.Name)
+	if !b.localCA() {
+		name = naming.ClusterCA(b.KinkControlPlane.Name)
+	}
+	return cmmetav1.ObjectReference{
+		Name: name,
+		Kind: IssuerKind,
+	}
+}
+
+// clusterCAIssuerRef returns the reference to the issuer of the cluster CA.
+func (b *Certificates) clusterCAIssuerRef() cmmetav1.ObjectReference {
+	certs := b.KinkControlPlane.Spec.Certificates
+	if certs == nil || certs.IssuerRef == nil {
+		return cmmetav1.ObjectReference{
+			Name: naming.RootCA(b.KinkControlPlane.Name),
+			Kind: IssuerKind,
+		}
+	}
+
+	kind := certs.IssuerRef.Kind
+	if kind == "" {
+		kind = IssuerKind
+	}
+	return cmmetav1.ObjectReference{
+		Name:  certs.IssuerRef.Name,
+		Kind:  kind,
+		Group: certs.IssuerRef.Group,
+	}
+}
+
+// clusterCASecretName returns the name of the Secret holding the cluster CA key pair.
+func clusterCASecretName(kcp *controlplanev1alpha1.KinkControlPlane) string {
+	if certs := kcp.Spec.Certificates; certs != nil && certs.CASecretRef != nil {
+		return certs.CASecretRef.Name
+	}
+	return naming.ClusterCA(kcp.Name)
+}
+
 // ClusterCAIssuer defines an issuer that uses the Cluster CA.
 func (b *Certificates) ClusterCAIssuer() *cmv1.Issuer {
 	name := naming.ClusterCA(b.KinkControlPlane.Name)
@@ -145,7 +297,7 @@ func (b *Certificates) ClusterCAIssuer() *cmv1.Issuer {
 		Spec: cmv1.IssuerSpec{
 			IssuerConfig: cmv1.IssuerConfig{
 				CA: &cmv1.CAIssuer{
-					SecretName: name,
+					SecretName: clusterCASecretName(b.KinkControlPlane),
 				},
 			},
 		},
@@ -155,6 +307,7 @@ func (b *Certificates) ClusterCAIssuer() *cmv1.Issuer {
 // APIServer generates a server certificate for the Kubernetes API server.
 func (b *Certificates) APIServer() *cmv1.Certificate {
 	name := naming.APIServerCertificate(b.KinkControlPlane.Name)
+	leaf := b.leafProfile()
 
 	ipAddresses := []string{"127.0.0.1"}
 
@@ -181,8 +334,9 @@ func (b *Certificates) APIServer() *cmv1.Certificate {
 			Subject: &cmv1.X509Subject{
 				Organizations: []string{"system:masters"},
 			},
-			Duration:    &defaultCertResidualTime,
-			RenewBefore: &defaultRenewBefore,
+			Duration:    leaf.duration,
+			RenewBefore: leaf.renewBefore,
+			PrivateKey:  leaf.privateKey,
 			IssuerRef: cmmetav1.ObjectReference{
 				Name: naming.ClusterCA(b.KinkControlPlane.Name),
 				Kind: IssuerKind,
@@ -208,6 +362,7 @@ func (b *Certificates) APIServer() *cmv1.Certificate {
 
 func (b *Certificates) AdminCertificate() *cmv1.Certificate {
 	name := naming.AdminCertificate(b.KinkControlPlane.Name)
+	leaf := b.leafProfile()
 
 	selectorLabels := manifestutils.SelectorLabels(
 		b.KinkControlPlane.ObjectMeta,
@@ -227,8 +382,152 @@ func (b *Certificates) AdminCertificate() *cmv1.Certificate {
 			Subject: &cmv1.X509Subject{
 				Organizations: []string{"system:masters"},
 			},
-			Duration:    &defaultCertResidualTime,
-			RenewBefore: &defaultRenewBefore,
+			Duration:    leaf.duration,
+			RenewBefore: leaf.renewBefore,
+			PrivateKey:  leaf.privateKey,
+			IssuerRef: cmmetav1.ObjectReference{
+				Name: naming.ClusterCA(b.KinkControlPlane.Name),
+				Kind: IssuerKind,
+			},
+			SecretName: name,
+			SecretTemplate: &cmv1.CertificateSecretTemplate{
+				Labels: selectorLabels,
+			},
+			Usages: []cmv1.KeyUsage{
+				cmv1.UsageDigitalSignature,
+				cmv1.UsageKeyEncipherment,
+				cmv1.UsageClientAuth,
+			},
+		},
+	}
+}
+
+// KubeletClientCertificate generates a client certificate used by the API server to authenticate
+// to the kubelets, e.g. to stream logs or execute commands in the containers.
+func (b *Certificates) KubeletClientCertificate() *cmv1.Certificate {
+	name := naming.KubeletClientCertificate(b.KinkControlPlane.Name)
+	leaf := b.leafProfile()
+
+	selectorLabels := manifestutils.SelectorLabels(
+		b.KinkControlPlane.ObjectMeta,
+		ComponentCertificates, ConceptControlPlane,
+	)
+	annotations := manifestutils.Annotations(b.KinkControlPlane, nil)
+
+	return &cmv1.Certificate{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   b.KinkControlPlane.Namespace,
+			Labels:      selectorLabels,
+			Annotations: annotations,
+		},
+		Spec: cmv1.CertificateSpec{
+			CommonName: "kube-apiserver-kubelet-client",
+			Subject: &cmv1.X509Subject{
+				Organizations: []string{"system:masters"},
+			},
+			Duration:    leaf.duration,
+			RenewBefore: leaf.renewBefore,
+			PrivateKey:  leaf.privateKey,
+			IssuerRef: cmmetav1.ObjectReference{
+				Name: naming.ClusterCA(b.KinkControlPlane.Name),
+				Kind: IssuerKind,
+			},
+			SecretName: name,
+			SecretTemplate: &cmv1.CertificateSecretTemplate{
+				Labels: selectorLabels,
+			},
+			Usages: []cmv1.KeyUsage{
+				cmv1.UsageDigitalSignature,
+				cmv1.UsageKeyEncipherment,
+				cmv1.UsageClientAuth,
+			},
+		},
+	}
+}
+
+// UserCertificate generates the client certificate of an additional kubeconfig.
+func (b *Certificates) UserCertificate(kubeconfig controlplanev1alpha1.Kubeconfig) *cmv1.Certificate {
+	name := naming.UserCertificate(b.KinkControlPlane.Name, kubeconfig.SecretName)
+	leaf := b.leafProfile()
+
+	// cert-manager renews the certificate after two thirds of a custom lifetime.
+	if kubeconfig.Duration != nil {
+		leaf.duration = kubeconfig.Duration
+		leaf.renewBefore = nil
+	}
+
+	selectorLabels := manifestutils.SelectorLabels(
+		b.KinkControlPlane.ObjectMeta,
+		ComponentCertificates, ConceptControlPlane,
+	)
+	annotations := manifestutils.Annotations(b.KinkControlPlane, nil)
+
+	var subject *cmv1.X509Subject
+	if len(kubeconfig.Groups) > 0 {
+		subject = &cmv1.X509Subject{
+			Organizations: kubeconfig.Groups,
+		}
+	}
+
+	return &cmv1.Certificate{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   b.KinkControlPlane.Namespace,
+			Labels:      selectorLabels,
+			Annotations: annotations,
+		},
+		Spec: cmv1.CertificateSpec{
+			CommonName:  kubeconfig.Username,
+			Subject:     subject,
+			Duration:    leaf.duration,
+			RenewBefore: leaf.renewBefore,
+			PrivateKey:  leaf.privateKey,
+			IssuerRef: cmmetav1.ObjectReference{
+				Name: naming.ClusterCA(b.KinkControlPlane.Name),
+				Kind: IssuerKind,
+			},
+			SecretName: name,
+			SecretTemplate: &cmv1.CertificateSecretTemplate{
+				Labels: selectorLabels,
+			},
+			Usages: []cmv1.KeyUsage{
+				cmv1.UsageDigitalSignature,
+				cmv1.UsageKeyEncipherment,
+				cmv1.UsageClientAuth,
+			},
+		},
+	}
+}
+
+// MetricsClientCertificate generates a client certificate used to scrape the metrics of the
+// control plane components. The system:monitoring group grants read-only access to the
+// metrics and health endpoints.
+func (b *Certificates) MetricsClientCertificate() *cmv1.Certificate {
+	name := naming.MetricsClientCertificate(b.KinkControlPlane.Name)
+	leaf := b.leafProfile()
+
+	selectorLabels := manifestutils.SelectorLabels(
+		b.KinkControlPlane.ObjectMeta,
+		ComponentCertificates, ConceptControlPlane,
+	)
+	annotations := manifestutils.Annotations(b.KinkControlPlane, nil)
+
+	return &cmv1.Certificate{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   b.KinkControlPlane.Namespace,
+			Labels:      selectorLabels,
+			Annotations: annotations,
+		},
+		Spec: cmv1.CertificateSpec{
+			CommonName: "kink:metrics",
+			Subject: &cmv1.X509Subject{
+				Organizations: []string{"system:monitoring"},
+			},
+			Duration:    leaf.duration,
+			RenewBefore: leaf.renewBefore,
+			PrivateKey:  leaf.privateKey,
 			IssuerRef: cmmetav1.ObjectReference{
 				Name: naming.ClusterCA(b.KinkControlPlane.Name),
 				Kind: IssuerKind,
@@ -248,6 +547,7 @@ func (b *Certificates) AdminCertificate() *cmv1.Certificate {
 
 func (b *Certificates) SchedulerCertificate() *cmv1.Certificate {
 	name := naming.SchedulerCertificate(b.KinkControlPlane.Name)
+	leaf := b.leafProfile()
 
 	selectorLabels := manifestutils.SelectorLabels(
 		b.KinkControlPlane.ObjectMeta,
@@ -267,8 +567,9 @@ func (b *Certificates) SchedulerCertificate() *cmv1.Certificate {
 			Subject: &cmv1.X509Subject{
 				Organizations: []string{"system:kube-scheduler"},
 			},
-			Duration:    &defaultCertResidualTime,
-			RenewBefore: &defaultRenewBefore,
+			Duration:    leaf.duration,
+			RenewBefore: leaf.renewBefore,
+			PrivateKey:  leaf.privateKey,
 			IssuerRef: cmmetav1.ObjectReference{
 				Name: naming.ClusterCA(b.KinkControlPlane.Name),
 				Kind: IssuerKind,
@@ -288,6 +589,7 @@ func (b *Certificates) SchedulerCertificate() *cmv1.Certificate {
 
 func (b *Certificates) ControllerManagerCertificate() *cmv1.Certificate {
 	name := naming.ControllerManagerCertificate(b.KinkControlPlane.Name)
+	leaf := b.leafProfile()
 
 	selectorLabels := manifestutils.SelectorLabels(
 		b.KinkControlPlane.ObjectMeta,
@@ -307,8 +609,9 @@ func (b *Certificates) ControllerManagerCertificate() *cmv1.Certificate {
 			Subject: &cmv1.X509Subject{
 				Organizations: []string{"system:kube-controller-manager"},
 			},
-			Duration:    &defaultCertResidualTime,
-			RenewBefore: &defaultRenewBefore,
+			Duration:    leaf.duration,
+			RenewBefore: leaf.renewBefore,
+			PrivateKey:  leaf.privateKey,
 			IssuerRef: cmmetav1.ObjectReference{
 				Name: naming.ClusterCA(b.KinkControlPlane.Name),
 				Kind: IssuerKind,
@@ -329,6 +632,7 @@ func (b *Certificates) ControllerManagerCertificate() *cmv1.Certificate {
 // KineCA generates a CA certificate for Kine (etcd alternative).
 func (b *Certificates) KineCA() *cmv1.Certificate {
 	name := naming.KineCA(b.KinkControlPlane.Name)
+	ca := b.caProfile()
 
 	selectorLabels := manifestutils.SelectorLabels(
 		b.KinkControlPlane.ObjectMeta,
@@ -346,17 +650,11 @@ func (b *Certificates) KineCA() *cmv1.Certificate {
 		Spec: cmv1.CertificateSpec{
 			IsCA:        true,
 			CommonName:  "ETCD CA",
-			Duration:    &defaultCAResidualTime,
-			RenewBefore: &defaultRenewBefore,
-			IssuerRef: cmmetav1.ObjectReference{
-				Name: naming.RootCA(b.KinkControlPlane.Name),
-				Kind: IssuerKind,
-			},
-			PrivateKey: &cmv1.CertificatePrivateKey{
-				Algorithm: cmv1.RSAKeyAlgorithm,
-				Size:      2048,
-			},
-			SecretName: name,
+			Duration:    ca.duration,
+			RenewBefore: ca.renewBefore,
+			IssuerRef:   b.caIssuerRef(),
+			PrivateKey:  ca.privateKey,
+			SecretName:  name,
 			SecretTemplate: &cmv1.CertificateSecretTemplate{
 				Labels: selectorLabels,
 			},
@@ -394,6 +692,7 @@ func (b *Certificates) KineCAIssuer() *cmv1.Issuer {
 // KineServer generates a certificate for the Kine server.
 func (b *Certificates) KineServer() *cmv1.Certificate {
 	name := naming.KineServerCertificate(b.KinkControlPlane.Name)
+	leaf := b.leafProfile()
 
 	selectorLabels := manifestutils.SelectorLabels(
 		b.KinkControlPlane.ObjectMeta,
@@ -413,8 +712,9 @@ func (b *Certificates) KineServer() *cmv1.Certificate {
 			Subject: &cmv1.X509Subject{
 				Organizations: []string{"etcd"},
 			},
-			Duration:    &defaultCertResidualTime,
-			RenewBefore: &defaultRenewBefore,
+			Duration:    leaf.duration,
+			RenewBefore: leaf.renewBefore,
+			PrivateKey:  leaf.privateKey,
 			IssuerRef: cmmetav1.ObjectReference{
 				Name: naming.KineCA(b.KinkControlPlane.Name),
 				Kind: IssuerKind,
@@ -438,6 +738,7 @@ func (b *Certificates) KineServer() *cmv1.Certificate {
 // KineAPIServerClient generates a client certificate for the API server to authenticate with Kine.
 func (b *Certificates) KineAPIServerClient() *cmv1.Certificate {
 	name := naming.KineAPIServerClientCertificate(b.KinkControlPlane.Name)
+	leaf := b.leafProfile()
 
 	selectorLabels := manifestutils.SelectorLabels(
 		b.KinkControlPlane.ObjectMeta,
@@ -457,8 +758,9 @@ func (b *Certificates) KineAPIServerClient() *cmv1.Certificate {
 			Subject: &cmv1.X509Subject{
 				Organizations: []string{"apiserver"},
 			},
-			Duration:    &defaultCertResidualTime,
-			RenewBefore: &defaultRenewBefore,
+			Duration:    leaf.duration,
+			RenewBefore: leaf.renewBefore,
+			PrivateKey:  leaf.privateKey,
 			IssuerRef: cmmetav1.ObjectReference{
 				Name: naming.KineCA(b.KinkControlPlane.Name),
 				Kind: IssuerKind,
@@ -481,6 +783,7 @@ func (b *Certificates) KineAPIServerClient() *cmv1.Certificate {
 // FrontProxyCA generates a CA certificate for the front proxy.
 func (b *Certificates) FrontProxyCA() *cmv1.Certificate {
 	name := naming.FrontProxyCA(b.KinkControlPlane.Name)
+	ca := b.caProfile()
 
 	selectorLabels := manifestutils.SelectorLabels(
 		b.KinkControlPlane.ObjectMeta,
@@ -498,17 +801,11 @@ func (b *Certificates) FrontProxyCA() *cmv1.Certificate {
 		Spec: cmv1.CertificateSpec{
 			IsCA:        true,
 			CommonName:  "Front-End Proxy",
-			Duration:    &defaultCAResidualTime,
-			RenewBefore: &defaultRenewBefore,
-			IssuerRef: cmmetav1.ObjectReference{
-				Name: naming.RootCA(b.KinkControlPlane.Name),
-				Kind: IssuerKind,
-			},
-			PrivateKey: &cmv1.CertificatePrivateKey{
-				Algorithm: cmv1.RSAKeyAlgorithm,
-				Size:      2048,
-			},
-			SecretName: name,
+			Duration:    ca.duration,
+			RenewBefore: ca.renewBefore,
+			IssuerRef:   b.caIssuerRef(),
+			PrivateKey:  ca.privateKey,
+			SecretName:  name,
 			SecretTemplate: &cmv1.CertificateSecretTemplate{
 				Labels: selectorLabels,
 			},
@@ -519,6 +816,7 @@ func (b *Certificates) FrontProxyCA() *cmv1.Certificate {
 // ServiceAccountCertificate generates a certificate used for signing service account tokens.
 func (b *Certificates) ServiceAccountCertificate() *cmv1.Certificate {
 	name := naming.ServiceAccountCertificate(b.KinkControlPlane.Name)
+	leaf := b.leafProfile()
 
 	selectorLabels := manifestutils.SelectorLabels(
 		b.KinkControlPlane.ObjectMeta,
@@ -538,8 +836,9 @@ func (b *Certificates) ServiceAccountCertificate() *cmv1.Certificate {
 			Subject: &cmv1.X509Subject{
 				Organizations: []string{"system:serviceaccounts"},
 			},
-			Duration:    &defaultCertResidualTime,
-			RenewBefore: &defaultRenewBefore,
+			Duration:    leaf.duration,
+			RenewBefore: leaf.renewBefore,
+			PrivateKey:  leaf.privateKey,
 			SecretName:  name,
 			SecretTemplate: &cmv1.CertificateSecretTemplate{
 				Labels: selectorLabels,
@@ -13,3 +13,216 @@
 // limitations under the License.
 
 package controlplane
+
+import (
+	"testing"
+	"time"
+
+	cmv1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmetav1 "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/stretchr/testify/assert"
+
+	controlplanev1alpha1 "github.com/anza-labs/kink/api/controlplane/v1alpha1"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestCertificates(t *testing.T) {
+	t.Parallel()
+
+	t.Run("ClusterCA", func(t *testing.T) {
+		t.Parallel()
+
+		for name, tc := range map[string]struct {
+			certificates *controlplanev1alpha1.Certificates
+			expected     cmmetav1.ObjectReference
+		}{
+			"RootCA": {
+				certificates: nil,
+				expected:     cmmetav1.ObjectReference{Name: "test-root-ca", Kind: IssuerKind},
+			},
+			"ClusterIssuer": {
+				certificates: &controlplanev1alpha1.Certificates{
+					IssuerRef: &controlplanev1alpha1.IssuerReference{Name: "vault", Kind: "ClusterIssuer"},
+				},
+				expected: cmmetav1.ObjectReference{Name: "vault", Kind: "ClusterIssuer"},
+			},
+			"DefaultKind": {
+				certificates: &controlplanev1alpha1.Certificates{
+					IssuerRef: &controlplanev1alpha1.IssuerReference{Name: "corporate"},
+				},
+				expected: cmmetav1.ObjectReference{Name: "corporate", Kind: IssuerKind},
+			},
+		} {
+			t.Run(name, func(t *testing.T) {
+				t.Parallel()
+
+				// prepare
+				certs := &Certificates{KinkControlPlane: &controlplanev1alpha1.KinkControlPlane{
+					ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+					Spec:       controlplanev1alpha1.KinkControlPlaneSpec{Certificates: tc.certificates},
+				}}
+
+				// test
+				actual := certs.ClusterCA()
+
+				// validate
+				assert.Equal(t, tc.expected, actual.Spec.IssuerRef)
+			})
+		}
+	})
+
+	t.Run("RootCA", func(t *testing.T) {
+		t.Parallel()
+
+		for name, tc := range map[string]struct {
+			certificates     *controlplanev1alpha1.Certificates
+			expectedRootCA   bool
+			expectedCAIssuer string
+		}{
+			"Local": {
+				certificates:     nil,
+				expectedRootCA:   true,
+				expectedCAIssuer: "test-root-ca",
+			},
+			"IssuerRef": {
+				certificates: &controlplanev1alpha1.Certificates{
+					IssuerRef: &controlplanev1alpha1.IssuerReference{Name: "vault", Kind: "ClusterIssuer"},
+				},
+				expectedRootCA:   false,
+				expectedCAIssuer: "test-ca",
+			},
+			"CASecretRef": {
+				certificates: &controlplanev1alpha1.Certificates{
+					CASecretRef: &corev1.LocalObjectReference{Name: "kubeadm-ca"},
+				},
+				expectedRootCA:   false,
+				expectedCAIssuer: "test-ca",
+			},
+		} {
+			t.Run(name, func(t *testing.T) {
+				t.Parallel()
+
+				// prepare
+				certs := &Certificates{KinkControlPlane: &controlplanev1alpha1.KinkControlPlane{
+					ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+					Spec:       controlplanev1alpha1.KinkControlPlaneSpec{Certificates: tc.certificates},
+				}}
+
+				// test
+				actual := certs.Build()
+
+				// validate
+				rootCA := false
+				for _, obj := range actual {
+					switch obj := obj.(type) {
+					case *cmv1.Issuer:
+						if obj.Name == "test-root-ca" {
+							rootCA = true
+						}
+					case *cmv1.Certificate:
+						if obj.Name == certs.KineCA().Name || obj.Name == certs.FrontProxyCA().Name {
+							assert.Equal(t, tc.expectedCAIssuer, obj.Spec.IssuerRef.Name, obj.Name)
+						}
+					}
+				}
+				assert.Equal(t, tc.expectedRootCA, rootCA)
+			})
+		}
+	})
+
+	t.Run("ImportedCA", func(t *testing.T) {
+		t.Parallel()
+
+		// prepare
+		certs := &Certificates{KinkControlPlane: &controlplanev1alpha1.KinkControlPlane{
+			ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+			Spec: controlplanev1alpha1.KinkControlPlaneSpec{
+				Certificates: &controlplanev1alpha1.Certificates{
+					CASecretRef: &corev1.LocalObjectReference{Name: "kubeadm-ca"},
+				},
+			},
+		}}
+
+		// test
+		actual := certs.Build()
+
+		// validate
+		for _, obj := range actual {
+			if cert, ok := obj.(*cmv1.Certificate); ok {
+				assert.NotEqual(t, "test-ca", cert.Name)
+			}
+		}
+		assert.Equal(t, "kubeadm-ca", certs.ClusterCAIssuer().Spec.CA.SecretName)
+	})
+}
+
+func TestCertificateProfiles(t *testing.T) {
+	t.Parallel()
+
+	day := 24 * time.Hour
+
+	for name, tc := range map[string]struct {
+		certificates *controlplanev1alpha1.Certificates
+		expectedCA   certificateProfile
+		expectedLeaf certificateProfile
+	}{
+		"Defaults": {
+			certificates: nil,
+			expectedCA: certificateProfile{
+				duration:    &metav1.Duration{Duration: 3650 * day},
+				renewBefore: &metav1.Duration{Duration: 30 * day},
+				privateKey:  &cmv1.CertificatePrivateKey{Algorithm: cmv1.RSAKeyAlgorithm, Size: 2048},
+			},
+			expectedLeaf: certificateProfile{
+				duration:    &metav1.Duration{Duration: 365 * day},
+				renewBefore: &metav1.Duration{Duration: 30 * day},
+			},
+		},
+		"Configured": {
+			certificates: &controlplanev1alpha1.Certificates{
+				CA: &controlplanev1alpha1.CertificateProfile{
+					PrivateKey: &controlplanev1alpha1.PrivateKey{
+						Algorithm: controlplanev1alpha1.RSAKeyAlgorithm,
+						Size:      4096,
+					},
+				},
+				Leaf: &controlplanev1alpha1.CertificateProfile{
+					Duration: &metav1.Duration{Duration: 15 * day},
+					PrivateKey: &controlplanev1alpha1.PrivateKey{
+						Algorithm: controlplanev1alpha1.ECDSAKeyAlgorithm,
+						Size:      256,
+					},
+				},
+			},
+			expectedCA: certificateProfile{
+				duration:    &metav1.Duration{Duration: 3650 * day},
+				renewBefore: &metav1.Duration{Duration: 30 * day},
+				privateKey:  &cmv1.CertificatePrivateKey{Algorithm: cmv1.RSAKeyAlgorithm, Size: 4096},
+			},
+			expectedLeaf: certificateProfile{
+				duration:    &metav1.Duration{Duration: 15 * day},
+				renewBefore: &metav1.Duration{Duration: 5 * day},
+				privateKey:  &cmv1.CertificatePrivateKey{Algorithm: cmv1.ECDSAKeyAlgorithm, Size: 256},
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			// prepare
+			certs := &Certificates{KinkControlPlane: &controlplanev1alpha1.KinkControlPlane{
+				Spec: controlplanev1alpha1.KinkControlPlaneSpec{Certificates: tc.certificates},
+			}}
+
+			// test
+			ca := certs.caProfile()
+			leaf := certs.leafProfile()
+
+			// validate
+			assert.Equal(t, tc.expectedCA, ca)
+			assert.Equal(t, tc.expectedLeaf, leaf)
+		})
+	}
+}
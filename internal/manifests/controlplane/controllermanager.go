@@ -31,13 +31,9 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-const (
-	frontProxyPKIPath = "/etc/pki/front-proxy"
-	frontProxyCAFile  = "ca.crt"
-)
-
 type ControllerManager struct {
 	KinkControlPlane *controlplanev1alpha1.KinkControlPlane
+	Hibernated       bool
 }
 
 func (b *ControllerManager) Build() ([]client.Object, error) {
@@ -90,13 +86,18 @@ func (b *ControllerManager) Deployment() (*appsv1.Deployment, error) {
 	if *replicas > 1 {
 		ha = true
 	}
+	if b.Hibernated {
+		replicas = ptr.To[int32](0)
+	}
 
 	podSpec := corev1.PodSpec{
 		Affinity:         manifestutils.Affinity(b.KinkControlPlane),
 		Containers:       []corev1.Container{b.container(image, ha)},
 		Volumes:          b.volumes(),
 		ImagePullSecrets: b.KinkControlPlane.Spec.ImagePullSecrets,
+		SecurityContext:  manifestutils.PodSecurityContext(b.KinkControlPlane.Spec.ControllerManager.Container),
 	}
+	podSpec = withExtras(podSpec, b.KinkControlPlane.Spec.ControllerManager.PodExtras)
 
 	return &appsv1.Deployment{
 		ObjectMeta: metav1.ObjectMeta{
@@ -183,29 +184,22 @@ func (b *ControllerManager) volumes() []corev1.Volume {
 			Name: "root-ca",
 			VolumeSource: corev1.VolumeSource{
 				Secret: &corev1.SecretVolumeSource{
-					SecretName:  naming.ClusterCA(b.KinkControlPlane.Name),
-					DefaultMode: ptr.To[int32](420),
-				},
-			},
-		},
-		{
-			Name: "front-proxy-ca",
-			VolumeSource: corev1.VolumeSource{
-				Secret: &corev1.SecretVolumeSource{
-					SecretName:  naming.FrontProxyCA(b.KinkControlPlane.Name),
+					SecretName:  clusterCASecretName(b.KinkControlPlane),
 					DefaultMode: ptr.To[int32](420),
 				},
 			},
 		},
+		trustBundleVolume(b.KinkControlPlane),
 		{
 			Name: "service-accounts-cert",
 			VolumeSource: corev1.VolumeSource{
 				Secret: &corev1.SecretVolumeSource{
-					SecretName:  naming.ServiceAccountCertificate(b.KinkControlPlane.Name),
+					SecretName:  naming.ServiceAccountSigningKey(b.KinkControlPlane.Name),
 					DefaultMode: ptr.To[int32](420),
 				},
 			},
 		},
+		manifestutils.TmpVolume(),
 	}
 }
 
@@ -222,15 +216,16 @@ func (b *ControllerManager) volumeMounts() []corev1.VolumeMount {
 			MountPath: rootPKIPath,
 		},
 		{
-			Name:      "front-proxy-ca",
+			Name:      "trust-bundle",
 			ReadOnly:  true,
-			MountPath: frontProxyPKIPath,
+			MountPath: trustBundlePKIPath,
 		},
 		{
 			Name:      "service-accounts-cert",
 			ReadOnly:  true,
 			MountPath: serviceAccountsPKIPath,
 		},
+		manifestutils.TmpVolumeMount(),
 	}
 }
 
@@ -248,7 +243,7 @@ func (b *ControllerManager) container(image string, ha bool) corev1.Container {
 		"cluster-signing-cert-file":        path.Join(rootPKIPath, rootCertFile),
 		"cluster-signing-key-file":         path.Join(rootPKIPath, rootKeyFile),
 		"service-account-private-key-file": path.Join(serviceAccountsPKIPath, serviceAccountsKeyFile),
-		"requestheader-client-ca-file":     path.Join(frontProxyPKIPath, frontProxyCAFile),
+		"requestheader-client-ca-file":     path.Join(trustBundlePKIPath, FrontProxyCABundleKey),
 		"controllers":                      "*,bootstrapsigner,tokencleaner",
 		"use-service-account-credentials":  "true",
 		"cluster-cidr":                     "10.200.0.0/16", // TODO
@@ -275,7 +270,7 @@ func (b *ControllerManager) container(image string, ha bool) corev1.Container {
 		},
 		VolumeMounts:    b.volumeMounts(),
 		ImagePullPolicy: b.KinkControlPlane.Spec.APIServer.ImagePullPolicy,
-		SecurityContext: nil,
+		SecurityContext: manifestutils.SecurityContext(b.KinkControlPlane.Spec.ControllerManager.Container),
 		LivenessProbe: &corev1.Probe{
 			ProbeHandler: corev1.ProbeHandler{
 				HTTPGet: &corev1.HTTPGetAction{
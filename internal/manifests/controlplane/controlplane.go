@@ -19,7 +19,9 @@ import (
 	"slices"
 
 	controlplanev1alpha1 "github.com/anza-labs/kink/api/controlplane/v1alpha1"
+	kinkcorev1alpha1 "github.com/anza-labs/kink/api/core/v1alpha1"
 
+	corev1 "k8s.io/api/core/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -33,18 +35,55 @@ const (
 	ComponentScheduler         = "scheduler"
 
 	rootPKIPath  = "/etc/pki/kubernetes"
-	rootCAFile   = "ca.crt"
 	rootCertFile = "tls.crt"
 	rootKeyFile  = "tls.key"
 
 	kubeconfigPath = "/etc/kubernetes"
 	kubeconfigName = "value"
 
-	serviceAccountsPKIPath         = "/etc/pki/service-accounts"
-	serviceAccountsCertificateFile = "tls.crt"
-	serviceAccountsKeyFile         = "tls.key"
+	serviceAccountsPKIPath = "/etc/pki/service-accounts"
+	serviceAccountsKeyFile = "tls.key"
 )
 
+// withExtras appends the extra items of a component to its pod, after the ones managed by the
+// operator so that the order of both is stable. The component container is the first one.
+func withExtras(podSpec corev1.PodSpec, extras kinkcorev1alpha1.PodExtras) corev1.PodSpec {
+	podSpec.Volumes = append(podSpec.Volumes, extras.ExtraVolumes...)
+	podSpec.InitContainers = append(podSpec.InitContainers, extras.ExtraInitContainers...)
+
+	container := &podSpec.Containers[0]
+	container.VolumeMounts = append(container.VolumeMounts, extras.ExtraVolumeMounts...)
+	container.Env = append(container.Env, extras.ExtraEnv...)
+
+	podSpec.Containers = append(podSpec.Containers, extras.ExtraContainers...)
+	return podSpec
+}
+
+// ManagedVolumes returns the volumes of the pod of the component and the mounts of its container
+// managed by the operator, which the extra volumes and mounts must not collide with. The volume
+// of the service account keys is included even before the keys are issued.
+func ManagedVolumes(
+	kcp *controlplanev1alpha1.KinkControlPlane,
+	component string,
+) ([]corev1.Volume, []corev1.VolumeMount) {
+	switch component {
+	case ComponentAPIServer:
+		b := &APIServer{KinkControlPlane: kcp, ServiceAccountKeys: &corev1.Secret{}}
+		return b.volumes(), b.volumeMounts()
+	case ComponentControllerManager:
+		b := &ControllerManager{KinkControlPlane: kcp}
+		return b.volumes(), b.volumeMounts()
+	case ComponentScheduler:
+		b := &Scheduler{KinkControlPlane: kcp}
+		return b.volumes(), b.volumeMounts()
+	case ComponentKine:
+		b := &Kine{KinkControlPlane: kcp}
+		return b.volumes(), b.volumeMounts()
+	default:
+		return nil, nil
+	}
+}
+
 func buildArgs(args map[string]string) []string {
 	cmd := []string{}
 	for arg, val := range args {
@@ -54,31 +93,80 @@ func buildArgs(args map[string]string) []string {
 	return cmd
 }
 
-type Builder struct{}
+type Builder struct {
+	// Hibernated scales all the components to zero replicas.
+	Hibernated bool
+
+	// ServiceMonitors enables the generation of ServiceMonitors, which requires the
+	// Prometheus Operator CRDs to be installed.
+	ServiceMonitors bool
+
+	// TLSRoutes enables the generation of the TLSRoutes, which requires the experimental Gateway
+	// API CRDs to be installed.
+	TLSRoutes bool
+
+	// ServiceAccountKeys is the Secret holding the keys verifying the service account tokens,
+	// nil until the service account key is issued.
+	ServiceAccountKeys *corev1.Secret
+
+	// ServiceAccountSigningKey is the Secret holding the key signing the service account tokens,
+	// nil until the service account key is issued.
+	ServiceAccountSigningKey *corev1.Secret
+
+	// OperatorNamespace is the namespace of the operator, allowed to reach the API server by the
+	// NetworkPolicies.
+	OperatorNamespace string
+
+	// SNIRouterNamespace is the namespace of the shared SNI router, empty when it is disabled.
+	SNIRouterNamespace string
+}
 
 func (b *Builder) Build(kcp *controlplanev1alpha1.KinkControlPlane) ([]client.Object, error) {
 	objects := []client.Object{}
 
 	objects = append(objects, (&Certificates{KinkControlPlane: kcp}).Build()...)
-	objects = append(objects, (&Kine{KinkControlPlane: kcp}).Build()...)
+	objects = append(objects, (&Kine{KinkControlPlane: kcp, Hibernated: b.Hibernated}).Build()...)
+
+	// The keys are applied before the API Server, which verifies and signs the tokens with them.
+	if b.ServiceAccountKeys != nil {
+		objects = append(objects, b.ServiceAccountKeys)
+	}
+	if b.ServiceAccountSigningKey != nil {
+		objects = append(objects, b.ServiceAccountSigningKey)
+	}
 
-	kas, err := (&APIServer{KinkControlPlane: kcp}).Build()
+	kas, err := (&APIServer{
+		KinkControlPlane:   kcp,
+		Hibernated:         b.Hibernated,
+		TLSRoutes:          b.TLSRoutes,
+		ServiceAccountKeys: b.ServiceAccountKeys,
+	}).Build()
 	if err != nil {
 		return nil, fmt.Errorf("failed to build API Server components: %w", err)
 	}
 	objects = append(objects, kas...)
 
-	kcm, err := (&ControllerManager{KinkControlPlane: kcp}).Build()
+	kcm, err := (&ControllerManager{KinkControlPlane: kcp, Hibernated: b.Hibernated}).Build()
 	if err != nil {
 		return nil, fmt.Errorf("failed to build Controller Manager components: %w", err)
 	}
 	objects = append(objects, kcm...)
 
-	ks, err := (&Scheduler{KinkControlPlane: kcp}).Build()
+	ks, err := (&Scheduler{KinkControlPlane: kcp, Hibernated: b.Hibernated}).Build()
 	if err != nil {
 		return nil, fmt.Errorf("failed to build Scheduler components: %w", err)
 	}
 	objects = append(objects, ks...)
 
+	objects = append(objects, (&NetworkPolicies{
+		KinkControlPlane:   kcp,
+		OperatorNamespace:  b.OperatorNamespace,
+		SNIRouterNamespace: b.SNIRouterNamespace,
+	}).Build()...)
+
+	if b.ServiceMonitors {
+		objects = append(objects, (&Monitoring{KinkControlPlane: kcp}).Build()...)
+	}
+
 	return objects, nil
 }
@@ -39,6 +39,7 @@ const (
 
 type Kine struct {
 	KinkControlPlane *controlplanev1alpha1.KinkControlPlane
+	Hibernated       bool
 }
 
 func (b *Kine) Build() []client.Object {
@@ -70,12 +71,19 @@ func (b *Kine) Deployment() *appsv1.Deployment {
 	annotations := manifestutils.Annotations(b.KinkControlPlane, nil)
 	podAnnotations := manifestutils.PodAnnotations(b.KinkControlPlane, nil)
 
+	replicas := ptr.To[int32](1)
+	if b.Hibernated {
+		replicas = ptr.To[int32](0)
+	}
+
 	podSpec := corev1.PodSpec{
 		Affinity:         manifestutils.Affinity(b.KinkControlPlane),
 		Containers:       []corev1.Container{b.container(image)},
 		Volumes:          b.volumes(),
 		ImagePullSecrets: b.KinkControlPlane.Spec.ImagePullSecrets,
+		SecurityContext:  manifestutils.PodSecurityContext(b.KinkControlPlane.Spec.Kine.Container),
 	}
+	podSpec = withExtras(podSpec, b.KinkControlPlane.Spec.Kine.PodExtras)
 
 	return &appsv1.Deployment{
 		ObjectMeta: metav1.ObjectMeta{
@@ -88,7 +96,7 @@ func (b *Kine) Deployment() *appsv1.Deployment {
 			Selector: &metav1.LabelSelector{
 				MatchLabels: selectorLabels,
 			},
-			Replicas: ptr.To[int32](1),
+			Replicas: replicas,
 			Template: corev1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
 					Labels:      labels,
@@ -189,6 +197,7 @@ func (b *Kine) volumes() []corev1.Volume {
 				},
 			},
 		},
+		manifestutils.TmpVolume(),
 	}
 }
 
@@ -204,6 +213,7 @@ func (b *Kine) volumeMounts() []corev1.VolumeMount {
 			ReadOnly:  true,
 			MountPath: kineTLSMountPoint,
 		},
+		manifestutils.TmpVolumeMount(),
 	}
 }
 
@@ -244,7 +254,7 @@ func (b *Kine) container(image string) corev1.Container {
 		},
 		VolumeMounts:    b.volumeMounts(),
 		ImagePullPolicy: b.KinkControlPlane.Spec.Kine.ImagePullPolicy,
-		SecurityContext: nil,
+		SecurityContext: manifestutils.SecurityContext(b.KinkControlPlane.Spec.Kine.Container),
 		LivenessProbe: &corev1.Probe{
 			InitialDelaySeconds: 5,
 			ProbeHandler: corev1.ProbeHandler{
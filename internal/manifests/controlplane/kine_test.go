@@ -20,6 +20,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	controlplanev1alpha1 "github.com/anza-labs/kink/api/controlplane/v1alpha1"
+	"github.com/anza-labs/kink/internal/manifests/manifestutils"
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -42,6 +43,36 @@ func TestKine(t *testing.T) {
 		assert.Len(t, actual, 2)
 	})
 
+	t.Run("Hibernated", func(t *testing.T) {
+		t.Parallel()
+
+		// prepare
+		kine := (&Kine{KinkControlPlane: &controlplanev1alpha1.KinkControlPlane{}, Hibernated: true})
+
+		// test
+		actual := kine.Deployment()
+
+		// validate
+		assert.Equal(t, int32(0), *actual.Spec.Replicas)
+	})
+
+	t.Run("SecurityContext", func(t *testing.T) {
+		t.Parallel()
+
+		// prepare
+		kine := (&Kine{KinkControlPlane: &controlplanev1alpha1.KinkControlPlane{}})
+
+		// test
+		actual := kine.Deployment()
+
+		// validate
+		podSpec := actual.Spec.Template.Spec
+		assert.True(t, *podSpec.SecurityContext.RunAsNonRoot)
+		assert.True(t, *podSpec.Containers[0].SecurityContext.ReadOnlyRootFilesystem)
+		assert.Contains(t, podSpec.Volumes, manifestutils.TmpVolume())
+		assert.Contains(t, podSpec.Containers[0].VolumeMounts, manifestutils.TmpVolumeMount())
+	})
+
 	t.Run("Deployment", func(t *testing.T) {
 		t.Parallel()
 
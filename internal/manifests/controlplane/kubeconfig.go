@@ -41,6 +41,10 @@ var (
 type Kubeconfig struct {
 	client.Client
 	KinkControlPlane *controlplanev1alpha1.KinkControlPlane
+
+	// CABundle is trusted by the kubeconfigs instead of the CA of the client certificates,
+	// so that the API server is trusted while the cluster CA is rotated.
+	CABundle []byte
 }
 
 func (b *Kubeconfig) Build(ctx context.Context) ([]client.Object, error) {
@@ -76,6 +80,76 @@ func (b *Kubeconfig) Build(ctx context.Context) ([]client.Object, error) {
 	return obj, errs
 }
 
+// Users builds the additional kubeconfigs. The kubeconfigs which cannot be built yet, e.g.
+// because their client certificate is not issued, are returned as pending, keyed by their
+// Secret name, so that they do not hold back the kubeconfigs of the control plane.
+func (b *Kubeconfig) Users(ctx context.Context) ([]client.Object, map[string]error) {
+	log := log.FromContext(ctx)
+	obj := []client.Object{}
+	pending := map[string]error{}
+
+	for _, kubeconfig := range b.KinkControlPlane.Spec.Kubeconfigs {
+		log.V(4).Info("Building user kubeconfig", "secret", kubeconfig.SecretName)
+		if kcU, err := b.User(ctx, kubeconfig); err != nil {
+			pending[kubeconfig.SecretName] = err
+		} else {
+			log.V(4).Info("Created user kubeconfig", "secret", kubeconfig.SecretName)
+			obj = append(obj, kcU)
+		}
+	}
+
+	return obj, pending
+}
+
+// User builds an additional kubeconfig, authenticated with its dedicated client certificate.
+func (b *Kubeconfig) User(ctx context.Context, kubeconfig controlplanev1alpha1.Kubeconfig) (*corev1.Secret, error) {
+	var endpoint string
+	switch kubeconfig.Endpoint {
+	case controlplanev1alpha1.KubeconfigEndpointService:
+		endpoint = naming.LocalAPIServerEndpoint(b.KinkControlPlane.Name, b.KinkControlPlane.Namespace)
+	case controlplanev1alpha1.KubeconfigEndpointCustom:
+		endpoint = kubeconfig.Server
+	default:
+		endpoint = naming.PublicAPIServerEndpoint(
+			b.KinkControlPlane.Spec.ControlPlaneEndpoint.Host,
+			b.KinkControlPlane.Spec.ControlPlaneEndpoint.Port,
+		)
+	}
+
+	selectorLabels := manifestutils.SelectorLabels(
+		b.KinkControlPlane.ObjectMeta,
+		ComponentCertificates, ConceptControlPlane,
+	)
+	annotations := manifestutils.Annotations(b.KinkControlPlane, nil)
+
+	key := types.NamespacedName{
+		Name:      naming.UserCertificate(b.KinkControlPlane.Name, kubeconfig.SecretName),
+		Namespace: b.KinkControlPlane.Namespace,
+	}
+	config, err := b.newFor(ctx, b.KinkControlPlane.Name, endpoint, key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate kubeconfig: %w", err)
+	}
+
+	buf := new(bytes.Buffer)
+	if err := clientcmdapilatest.Codec.Encode(config, buf); err != nil {
+		return nil, fmt.Errorf("failed to serialize kubeconfig: %w", err)
+	}
+
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        kubeconfig.SecretName,
+			Namespace:   b.KinkControlPlane.Namespace,
+			Labels:      selectorLabels,
+			Annotations: annotations,
+		},
+		Type: corev1.SecretTypeOpaque,
+		Data: map[string][]byte{
+			kubeconfigName: buf.Bytes(),
+		},
+	}, nil
+}
+
 func (b *Kubeconfig) ClusterAPI(ctx context.Context) (*corev1.Secret, error) {
 	endpoint := naming.PublicAPIServerEndpoint(
 		b.KinkControlPlane.Spec.ControlPlaneEndpoint.Host,
@@ -207,6 +281,9 @@ func (b *Kubeconfig) newFor(
 	if !caExists || !certExists || !keyExists {
 		return nil, fmt.Errorf("%s: %w", secretRef, ErrRequiredFieldMissing)
 	}
+	if len(b.CABundle) > 0 {
+		caCert = b.CABundle
+	}
 
 	return &clientcmdapiv1.Config{
 		APIVersion: clientcmdapilatest.Version,
@@ -19,6 +19,9 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	controlplanev1alpha1 "github.com/anza-labs/kink/api/controlplane/v1alpha1"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -166,4 +169,102 @@ func TestKubeconfig(t *testing.T) {
 			})
 		}
 	})
+
+	t.Run("User", func(t *testing.T) {
+		t.Parallel()
+
+		for name, tc := range map[string]struct {
+			endpoint         controlplanev1alpha1.KubeconfigEndpoint
+			server           string
+			expectedEndpoint string
+		}{
+			"Public": {
+				expectedEndpoint: "https://test.example.com:443",
+			},
+			"Service": {
+				endpoint:         controlplanev1alpha1.KubeconfigEndpointService,
+				expectedEndpoint: "https://test-api-server.default.svc.cluster.local:6443",
+			},
+			"Custom": {
+				endpoint:         controlplanev1alpha1.KubeconfigEndpointCustom,
+				server:           "https://proxy.example.com",
+				expectedEndpoint: "https://proxy.example.com",
+			},
+		} {
+			t.Run(name, func(t *testing.T) {
+				t.Parallel()
+
+				// prepare
+				cli := fake.NewFakeClient(&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test-user-ci"},
+					Data: map[string][]byte{
+						"ca.crt":  []byte("ca"),
+						"tls.crt": []byte("crt"),
+						"tls.key": []byte("key"),
+					},
+				})
+				kc := &Kubeconfig{
+					Client: cli,
+					KinkControlPlane: &controlplanev1alpha1.KinkControlPlane{
+						ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test"},
+						Spec: controlplanev1alpha1.KinkControlPlaneSpec{
+							ControlPlaneEndpoint: controlplanev1alpha1.APIEndpoint{Host: "test.example.com", Port: 443},
+						},
+					},
+				}
+
+				// test
+				actual, err := kc.User(t.Context(), controlplanev1alpha1.Kubeconfig{
+					SecretName: "ci",
+					Username:   "ci",
+					Endpoint:   tc.endpoint,
+					Server:     tc.server,
+				})
+
+				// validate
+				require.NoError(t, err)
+				assert.Equal(t, "ci", actual.Name)
+				config := &clientcmdapiv1.Config{}
+				require.NoError(t, runtime.DecodeInto(clientcmdapilatest.Codec, actual.Data[kubeconfigName], config))
+				assert.Equal(t, tc.expectedEndpoint, config.Clusters[0].Cluster.Server)
+				assert.Equal(t, []byte("crt"), config.AuthInfos[0].AuthInfo.ClientCertificateData)
+			})
+		}
+	})
+
+	t.Run("Users", func(t *testing.T) {
+		t.Parallel()
+
+		// prepare
+		cli := fake.NewFakeClient(&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test-user-ci"},
+			Data: map[string][]byte{
+				"ca.crt":  []byte("ca"),
+				"tls.crt": []byte("crt"),
+				"tls.key": []byte("key"),
+			},
+		})
+		kc := &Kubeconfig{
+			Client: cli,
+			KinkControlPlane: &controlplanev1alpha1.KinkControlPlane{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test"},
+				Spec: controlplanev1alpha1.KinkControlPlaneSpec{
+					ControlPlaneEndpoint: controlplanev1alpha1.APIEndpoint{Host: "test.example.com", Port: 443},
+					Kubeconfigs: []controlplanev1alpha1.Kubeconfig{
+						{SecretName: "ci", Username: "ci"},
+						{SecretName: "not-issued", Username: "not-issued"},
+					},
+				},
+			},
+		}
+
+		// test
+		actual, pending := kc.Users(t.Context())
+
+		// validate
+		require.Len(t, actual, 1)
+		assert.Equal(t, "ci", actual[0].GetName())
+		require.Len(t, pending, 1)
+		assert.Error(t, pending["not-issued"])
+	})
 }
@@ -0,0 +1,173 @@
+// Copyright 2025 anza-labs contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controlplane
+
+import (
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+
+	controlplanev1alpha1 "github.com/anza-labs/kink/api/controlplane/v1alpha1"
+	"github.com/anza-labs/kink/internal/manifests/manifestutils"
+	"github.com/anza-labs/kink/internal/naming"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ComponentMonitoring is the component of the objects used to scrape the control plane.
+const ComponentMonitoring = "monitoring"
+
+// Monitoring manages the generation of Prometheus Operator ServiceMonitors.
+type Monitoring struct {
+	KinkControlPlane *controlplanev1alpha1.KinkControlPlane
+}
+
+// Enabled reports whether monitoring is enabled for the control plane.
+func (b *Monitoring) Enabled() bool {
+	return b.KinkControlPlane.Spec.Monitoring != nil && b.KinkControlPlane.Spec.Monitoring.Enabled
+}
+
+// Build constructs and returns the ServiceMonitors of all control plane components.
+func (b *Monitoring) Build() []client.Object {
+	if !b.Enabled() {
+		return nil
+	}
+
+	return []client.Object{
+		b.APIServer(),
+		b.ControllerManager(),
+		b.Scheduler(),
+		b.Kine(),
+	}
+}
+
+// APIServer returns the ServiceMonitor of the API server. The serving certificate is verified
+// against the cluster CA.
+func (b *Monitoring) APIServer() *monitoringv1.ServiceMonitor {
+	tlsConfig := b.clientTLSConfig()
+	tlsConfig.ServerName = ptr.To(naming.APIServer(b.KinkControlPlane.Name))
+
+	return b.serviceMonitor(naming.APIServer(b.KinkControlPlane.Name), ComponentAPIServer, monitoringv1.Endpoint{
+		Port:      "server",
+		Scheme:    "https",
+		Path:      "/metrics",
+		TLSConfig: tlsConfig,
+	})
+}
+
+// ControllerManager returns the ServiceMonitor of the controller manager. The controller manager
+// serves metrics with a self-signed certificate, so the serving certificate is not verified.
+func (b *Monitoring) ControllerManager() *monitoringv1.ServiceMonitor {
+	tlsConfig := b.clientTLSConfig()
+	tlsConfig.InsecureSkipVerify = ptr.To(true)
+
+	return b.serviceMonitor(naming.ControllerManager(b.KinkControlPlane.Name), ComponentControllerManager,
+		monitoringv1.Endpoint{
+			Port:      "self",
+			Scheme:    "https",
+			Path:      "/metrics",
+			TLSConfig: tlsConfig,
+		})
+}
+
+// Scheduler returns the ServiceMonitor of the scheduler. The scheduler serves metrics with
+// a self-signed certificate, so the serving certificate is not verified.
+func (b *Monitoring) Scheduler() *monitoringv1.ServiceMonitor {
+	tlsConfig := b.clientTLSConfig()
+	tlsConfig.InsecureSkipVerify = ptr.To(true)
+
+	return b.serviceMonitor(naming.Scheduler(b.KinkControlPlane.Name), ComponentScheduler, monitoringv1.Endpoint{
+		Port:      "self",
+		Scheme:    "https",
+		Path:      "/metrics",
+		TLSConfig: tlsConfig,
+	})
+}
+
+// Kine returns the ServiceMonitor of Kine, which serves metrics over plain HTTP.
+func (b *Monitoring) Kine() *monitoringv1.ServiceMonitor {
+	return b.serviceMonitor(naming.Kine(b.KinkControlPlane.Name), ComponentKine, monitoringv1.Endpoint{
+		Port:   "metrics",
+		Scheme: "http",
+		Path:   "/metrics",
+	})
+}
+
+func (b *Monitoring) serviceMonitor(
+	name, component string,
+	endpoint monitoringv1.Endpoint,
+) *monitoringv1.ServiceMonitor {
+	cfg := b.KinkControlPlane.Spec.Monitoring
+
+	labels := manifestutils.SelectorLabels(
+		b.KinkControlPlane.ObjectMeta,
+		ComponentMonitoring, ConceptControlPlane,
+	)
+	for k, v := range cfg.Labels {
+		if _, ok := labels[k]; !ok {
+			labels[k] = v
+		}
+	}
+	annotations := manifestutils.Annotations(b.KinkControlPlane, nil)
+
+	if cfg.Interval != nil {
+		endpoint.Interval = monitoringv1.Duration(cfg.Interval.Duration.String())
+	}
+
+	return &monitoringv1.ServiceMonitor{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   b.KinkControlPlane.Namespace,
+			Labels:      labels,
+			Annotations: annotations,
+		},
+		Spec: monitoringv1.ServiceMonitorSpec{
+			Selector: metav1.LabelSelector{
+				MatchLabels: manifestutils.SelectorLabels(
+					b.KinkControlPlane.ObjectMeta,
+					component, ConceptControlPlane,
+				),
+			},
+			Endpoints: []monitoringv1.Endpoint{endpoint},
+		},
+	}
+}
+
+// clientTLSConfig returns the TLS configuration authenticating with the metrics client certificate.
+func (b *Monitoring) clientTLSConfig() *monitoringv1.TLSConfig {
+	secretName := naming.MetricsClientCertificate(b.KinkControlPlane.Name)
+
+	return &monitoringv1.TLSConfig{
+		SafeTLSConfig: monitoringv1.SafeTLSConfig{
+			CA: monitoringv1.SecretOrConfigMap{
+				Secret: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: secretName},
+					Key:                  "ca.crt",
+				},
+			},
+			Cert: monitoringv1.SecretOrConfigMap{
+				Secret: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: secretName},
+					Key:                  "tls.crt",
+				},
+			},
+			KeySecret: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: secretName},
+				Key:                  "tls.key",
+			},
+		},
+	}
+}
@@ -0,0 +1,88 @@
+// Copyright 2025 anza-labs contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controlplane
+
+import (
+	"testing"
+	"time"
+
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	"github.com/stretchr/testify/assert"
+
+	controlplanev1alpha1 "github.com/anza-labs/kink/api/controlplane/v1alpha1"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestMonitoring(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Build", func(t *testing.T) {
+		t.Parallel()
+
+		for name, tc := range map[string]struct {
+			monitoring *controlplanev1alpha1.Monitoring
+			expected   int
+		}{
+			"Unset":    {monitoring: nil, expected: 0},
+			"Disabled": {monitoring: &controlplanev1alpha1.Monitoring{}, expected: 0},
+			"Enabled":  {monitoring: &controlplanev1alpha1.Monitoring{Enabled: true}, expected: 4},
+		} {
+			t.Run(name, func(t *testing.T) {
+				t.Parallel()
+
+				// prepare
+				monitoring := &Monitoring{KinkControlPlane: &controlplanev1alpha1.KinkControlPlane{
+					Spec: controlplanev1alpha1.KinkControlPlaneSpec{Monitoring: tc.monitoring},
+				}}
+
+				// test
+				actual := monitoring.Build()
+
+				// validate
+				assert.Len(t, actual, tc.expected)
+			})
+		}
+	})
+
+	t.Run("APIServer", func(t *testing.T) {
+		t.Parallel()
+
+		// prepare
+		monitoring := &Monitoring{KinkControlPlane: &controlplanev1alpha1.KinkControlPlane{
+			ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+			Spec: controlplanev1alpha1.KinkControlPlaneSpec{
+				Monitoring: &controlplanev1alpha1.Monitoring{
+					Enabled:  true,
+					Interval: &metav1.Duration{Duration: 30 * time.Second},
+					Labels:   map[string]string{"release": "prometheus"},
+				},
+			},
+		}}
+
+		// test
+		actual := monitoring.APIServer()
+
+		// validate
+		assert.Equal(t, "prometheus", actual.Labels["release"])
+		assert.Len(t, actual.Spec.Endpoints, 1)
+		endpoint := actual.Spec.Endpoints[0]
+		assert.Equal(t, monitoringv1.Duration("30s"), endpoint.Interval)
+		assert.Equal(t, "test-api-server", *endpoint.TLSConfig.ServerName)
+		assert.Equal(t, "test-metrics-cert", endpoint.TLSConfig.Cert.Secret.Name)
+		assert.Equal(t, "test-metrics-cert", endpoint.TLSConfig.KeySecret.Name)
+		assert.Nil(t, endpoint.TLSConfig.InsecureSkipVerify)
+	})
+}
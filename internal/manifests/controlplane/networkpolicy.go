@@ -0,0 +1,230 @@
+// Copyright 2025 anza-labs contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controlplane
+
+import (
+	controlplanev1alpha1 "github.com/anza-labs/kink/api/controlplane/v1alpha1"
+	"github.com/anza-labs/kink/internal/manifests/manifestutils"
+	"github.com/anza-labs/kink/internal/manifests/snirouter"
+	"github.com/anza-labs/kink/internal/naming"
+
+	corev1 "k8s.io/api/core/v1"
+	netv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// MetricsNamespaceLabel labels the namespaces whose pods are allowed to scrape the metrics of the
+// components, unless the peers are configured explicitly.
+const MetricsNamespaceLabel = "metrics"
+
+// NetworkPolicies manages the generation of the NetworkPolicies isolating the control plane.
+type NetworkPolicies struct {
+	KinkControlPlane *controlplanev1alpha1.KinkControlPlane
+
+	// OperatorNamespace is the namespace of the operator, which connects to the API server through
+	// its Service. The operator is not allowed when empty.
+	OperatorNamespace string
+
+	// SNIRouterNamespace is the namespace of the shared SNI router, empty when it is disabled.
+	SNIRouterNamespace string
+}
+
+// Enabled reports whether the NetworkPolicies are enabled for the control plane.
+func (b *NetworkPolicies) Enabled() bool {
+	return b.KinkControlPlane.Spec.NetworkPolicy != nil && b.KinkControlPlane.Spec.NetworkPolicy.Enabled
+}
+
+// Build constructs and returns the NetworkPolicies of all control plane components.
+func (b *NetworkPolicies) Build() []client.Object {
+	if !b.Enabled() {
+		return nil
+	}
+
+	return []client.Object{
+		b.Kine(),
+		b.APIServer(),
+		b.ControllerManager(),
+		b.Scheduler(),
+	}
+}
+
+// Kine returns the NetworkPolicy of Kine, which accepts connections from the API server of the
+// same control plane only.
+func (b *NetworkPolicies) Kine() *netv1.NetworkPolicy {
+	return b.networkPolicy(naming.Kine(b.KinkControlPlane.Name), ComponentKine,
+		netv1.NetworkPolicyIngressRule{
+			From:  []netv1.NetworkPolicyPeer{b.componentPeer(ComponentAPIServer)},
+			Ports: ports("kine"),
+		},
+		b.metricsRule("metrics"),
+	)
+}
+
+// APIServer returns the NetworkPolicy of the API server, which accepts connections on its port
+// from the other components, the operator, the exposure path and the configured CIDRs.
+func (b *NetworkPolicies) APIServer() *netv1.NetworkPolicy {
+	cfg := b.KinkControlPlane.Spec.NetworkPolicy
+
+	from := []netv1.NetworkPolicyPeer{
+		b.componentPeer(ComponentControllerManager),
+		b.componentPeer(ComponentScheduler),
+	}
+	if b.OperatorNamespace != "" {
+		from = append(from, namespacePeer(b.OperatorNamespace, nil))
+	}
+	from = append(from, b.exposurePeers()...)
+	for _, cidr := range cfg.APIServerCIDRs {
+		from = append(from, netv1.NetworkPolicyPeer{IPBlock: &netv1.IPBlock{CIDR: cidr}})
+	}
+	from = append(from, cfg.APIServerFrom...)
+	// The metrics of the API server are served on the same port.
+	from = append(from, b.metricsPeers()...)
+
+	return b.networkPolicy(naming.APIServer(b.KinkControlPlane.Name), ComponentAPIServer,
+		netv1.NetworkPolicyIngressRule{
+			From:  from,
+			Ports: ports("server"),
+		},
+	)
+}
+
+// ControllerManager returns the NetworkPolicy of the controller manager, which only accepts
+// connections from metrics scrapers.
+func (b *NetworkPolicies) ControllerManager() *netv1.NetworkPolicy {
+	return b.networkPolicy(naming.ControllerManager(b.KinkControlPlane.Name), ComponentControllerManager,
+		b.metricsRule("self"),
+	)
+}
+
+// Scheduler returns the NetworkPolicy of the scheduler, which only accepts connections from
+// metrics scrapers.
+func (b *NetworkPolicies) Scheduler() *netv1.NetworkPolicy {
+	return b.networkPolicy(naming.Scheduler(b.KinkControlPlane.Name), ComponentScheduler,
+		b.metricsRule("self"),
+	)
+}
+
+// exposurePeers returns the peers through which the API server is exposed, besides the
+// configured ones.
+func (b *NetworkPolicies) exposurePeers() []netv1.NetworkPolicyPeer {
+	cfg := b.KinkControlPlane.Spec.NetworkPolicy
+	endpoint := b.KinkControlPlane.Spec.ControlPlaneEndpoint
+
+	switch {
+	case endpoint.SNIRouter != nil:
+		if b.SNIRouterNamespace == "" {
+			return nil
+		}
+		return []netv1.NetworkPolicyPeer{
+			namespacePeer(b.SNIRouterNamespace, snirouter.SelectorLabels(b.SNIRouterNamespace)),
+		}
+
+	case endpoint.Gateway != nil || endpoint.Ingress != nil:
+		if len(cfg.APIServerFrom) > 0 {
+			return nil
+		}
+		// The proxies of the Gateway and Ingress controllers may run in any namespace.
+		return []netv1.NetworkPolicyPeer{{NamespaceSelector: &metav1.LabelSelector{}}}
+
+	default:
+		if len(cfg.APIServerCIDRs) > 0 {
+			return nil
+		}
+		return []netv1.NetworkPolicyPeer{
+			{IPBlock: &netv1.IPBlock{CIDR: "0.0.0.0/0"}},
+			{IPBlock: &netv1.IPBlock{CIDR: "::/0"}},
+		}
+	}
+}
+
+func (b *NetworkPolicies) metricsRule(port string) netv1.NetworkPolicyIngressRule {
+	return netv1.NetworkPolicyIngressRule{
+		From:  b.metricsPeers(),
+		Ports: ports(port),
+	}
+}
+
+func (b *NetworkPolicies) metricsPeers() []netv1.NetworkPolicyPeer {
+	if from := b.KinkControlPlane.Spec.NetworkPolicy.MetricsFrom; len(from) > 0 {
+		return from
+	}
+	return []netv1.NetworkPolicyPeer{
+		{
+			NamespaceSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{MetricsNamespaceLabel: "enabled"},
+			},
+		},
+	}
+}
+
+// componentPeer selects the pods of a component of the same control plane.
+func (b *NetworkPolicies) componentPeer(component string) netv1.NetworkPolicyPeer {
+	return netv1.NetworkPolicyPeer{
+		PodSelector: &metav1.LabelSelector{
+			MatchLabels: manifestutils.SelectorLabels(b.KinkControlPlane.ObjectMeta, component, ConceptControlPlane),
+		},
+	}
+}
+
+func (b *NetworkPolicies) networkPolicy(
+	name, component string,
+	ingress ...netv1.NetworkPolicyIngressRule,
+) *netv1.NetworkPolicy {
+	labels := manifestutils.SelectorLabels(b.KinkControlPlane.ObjectMeta, component, ConceptControlPlane)
+	annotations := manifestutils.Annotations(b.KinkControlPlane, nil)
+
+	return &netv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   b.KinkControlPlane.Namespace,
+			Labels:      labels,
+			Annotations: annotations,
+		},
+		Spec: netv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{
+				MatchLabels: manifestutils.SelectorLabels(b.KinkControlPlane.ObjectMeta, component, ConceptControlPlane),
+			},
+			PolicyTypes: []netv1.PolicyType{netv1.PolicyTypeIngress},
+			Ingress:     ingress,
+		},
+	}
+}
+
+// namespacePeer selects the pods of another namespace, all of them when labels is nil.
+func namespacePeer(namespace string, labels map[string]string) netv1.NetworkPolicyPeer {
+	peer := netv1.NetworkPolicyPeer{
+		NamespaceSelector: &metav1.LabelSelector{
+			MatchLabels: map[string]string{corev1.LabelMetadataName: namespace},
+		},
+	}
+	if labels != nil {
+		peer.PodSelector = &metav1.LabelSelector{MatchLabels: labels}
+	}
+	return peer
+}
+
+func ports(names ...string) []netv1.NetworkPolicyPort {
+	ports := make([]netv1.NetworkPolicyPort, 0, len(names))
+	for _, name := range names {
+		ports = append(ports, netv1.NetworkPolicyPort{
+			Protocol: ptr.To(corev1.ProtocolTCP),
+			Port:     ptr.To(intstr.FromString(name)),
+		})
+	}
+	return ports
+}
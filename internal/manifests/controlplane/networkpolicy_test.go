@@ -0,0 +1,201 @@
+// Copyright 2025 anza-labs contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controlplane
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	controlplanev1alpha1 "github.com/anza-labs/kink/api/controlplane/v1alpha1"
+	"github.com/anza-labs/kink/internal/manifests/manifestutils"
+	"github.com/anza-labs/kink/internal/manifests/snirouter"
+
+	corev1 "k8s.io/api/core/v1"
+	netv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func TestNetworkPolicies(t *testing.T) {
+	t.Parallel()
+
+	kcp := func(
+		endpoint controlplanev1alpha1.APIEndpoint,
+		networkPolicy *controlplanev1alpha1.NetworkPolicy,
+	) *controlplanev1alpha1.KinkControlPlane {
+		return &controlplanev1alpha1.KinkControlPlane{
+			ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "tenant"},
+			Spec: controlplanev1alpha1.KinkControlPlaneSpec{
+				ControlPlaneEndpoint: endpoint,
+				NetworkPolicy:        networkPolicy,
+			},
+		}
+	}
+	enabled := &controlplanev1alpha1.NetworkPolicy{Enabled: true}
+	metricsPeer := netv1.NetworkPolicyPeer{
+		NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"metrics": "enabled"}},
+	}
+
+	t.Run("Build", func(t *testing.T) {
+		t.Parallel()
+
+		for name, tc := range map[string]struct {
+			networkPolicy *controlplanev1alpha1.NetworkPolicy
+			expected      int
+		}{
+			"Unset":    {networkPolicy: nil, expected: 0},
+			"Disabled": {networkPolicy: &controlplanev1alpha1.NetworkPolicy{}, expected: 0},
+			"Enabled":  {networkPolicy: enabled, expected: 4},
+		} {
+			t.Run(name, func(t *testing.T) {
+				t.Parallel()
+
+				// prepare
+				networkPolicies := &NetworkPolicies{
+					KinkControlPlane: kcp(controlplanev1alpha1.APIEndpoint{}, tc.networkPolicy),
+				}
+
+				// test
+				actual := networkPolicies.Build()
+
+				// validate
+				assert.Len(t, actual, tc.expected)
+			})
+		}
+	})
+
+	t.Run("Kine", func(t *testing.T) {
+		t.Parallel()
+
+		// prepare
+		networkPolicies := &NetworkPolicies{KinkControlPlane: kcp(controlplanev1alpha1.APIEndpoint{}, enabled)}
+
+		// test
+		actual := networkPolicies.Kine()
+
+		// validate
+		assert.Equal(t, "test-kine", actual.Name)
+		assert.Equal(t, ComponentKine, actual.Spec.PodSelector.MatchLabels[manifestutils.LabelComponent])
+		require.Len(t, actual.Spec.Ingress, 2)
+		assert.Equal(t, intstr.FromString("kine"), *actual.Spec.Ingress[0].Ports[0].Port)
+		require.Len(t, actual.Spec.Ingress[0].From, 1)
+		assert.Equal(t, ComponentAPIServer,
+			actual.Spec.Ingress[0].From[0].PodSelector.MatchLabels[manifestutils.LabelComponent])
+		assert.Nil(t, actual.Spec.Ingress[0].From[0].NamespaceSelector)
+		assert.Equal(t, []netv1.NetworkPolicyPeer{metricsPeer}, actual.Spec.Ingress[1].From)
+	})
+
+	t.Run("ControllerManager", func(t *testing.T) {
+		t.Parallel()
+
+		// prepare
+		peer := netv1.NetworkPolicyPeer{
+			PodSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "prometheus"}},
+		}
+		networkPolicies := &NetworkPolicies{KinkControlPlane: kcp(controlplanev1alpha1.APIEndpoint{},
+			&controlplanev1alpha1.NetworkPolicy{Enabled: true, MetricsFrom: []netv1.NetworkPolicyPeer{peer}})}
+
+		// test
+		actual := networkPolicies.ControllerManager()
+
+		// validate
+		require.Len(t, actual.Spec.Ingress, 1)
+		assert.Equal(t, intstr.FromString("self"), *actual.Spec.Ingress[0].Ports[0].Port)
+		assert.Equal(t, []netv1.NetworkPolicyPeer{peer}, actual.Spec.Ingress[0].From)
+	})
+
+	t.Run("APIServer", func(t *testing.T) {
+		t.Parallel()
+
+		anywhere := []netv1.NetworkPolicyPeer{
+			{IPBlock: &netv1.IPBlock{CIDR: "0.0.0.0/0"}},
+			{IPBlock: &netv1.IPBlock{CIDR: "::/0"}},
+		}
+		cidr := []netv1.NetworkPolicyPeer{{IPBlock: &netv1.IPBlock{CIDR: "192.0.2.0/24"}}}
+		cluster := []netv1.NetworkPolicyPeer{{NamespaceSelector: &metav1.LabelSelector{}}}
+		router := []netv1.NetworkPolicyPeer{{
+			NamespaceSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{corev1.LabelMetadataName: "kink-system"},
+			},
+			PodSelector: &metav1.LabelSelector{MatchLabels: snirouter.SelectorLabels("kink-system")},
+		}}
+
+		for name, tc := range map[string]struct {
+			endpoint      controlplanev1alpha1.APIEndpoint
+			networkPolicy *controlplanev1alpha1.NetworkPolicy
+			expected      []netv1.NetworkPolicyPeer
+		}{
+			"LoadBalancer": {
+				endpoint:      controlplanev1alpha1.APIEndpoint{ServiceType: corev1.ServiceTypeLoadBalancer},
+				networkPolicy: enabled,
+				expected:      anywhere,
+			},
+			"LoadBalancerCIDRs": {
+				endpoint: controlplanev1alpha1.APIEndpoint{ServiceType: corev1.ServiceTypeLoadBalancer},
+				networkPolicy: &controlplanev1alpha1.NetworkPolicy{
+					Enabled:        true,
+					APIServerCIDRs: []string{"192.0.2.0/24"},
+				},
+				expected: cidr,
+			},
+			"Ingress": {
+				endpoint:      controlplanev1alpha1.APIEndpoint{Ingress: &controlplanev1alpha1.Ingress{}},
+				networkPolicy: enabled,
+				expected:      cluster,
+			},
+			"IngressPeers": {
+				endpoint: controlplanev1alpha1.APIEndpoint{Ingress: &controlplanev1alpha1.Ingress{}},
+				networkPolicy: &controlplanev1alpha1.NetworkPolicy{
+					Enabled:       true,
+					APIServerFrom: router,
+				},
+				expected: router,
+			},
+			"SNIRouter": {
+				endpoint:      controlplanev1alpha1.APIEndpoint{SNIRouter: &controlplanev1alpha1.SNIRouter{}},
+				networkPolicy: enabled,
+				expected:      router,
+			},
+		} {
+			t.Run(name, func(t *testing.T) {
+				t.Parallel()
+
+				// prepare
+				networkPolicies := &NetworkPolicies{
+					KinkControlPlane:   kcp(tc.endpoint, tc.networkPolicy),
+					OperatorNamespace:  "kink-system",
+					SNIRouterNamespace: "kink-system",
+				}
+
+				// test
+				actual := networkPolicies.APIServer()
+
+				// validate
+				require.Len(t, actual.Spec.Ingress, 1)
+				assert.Equal(t, intstr.FromString("server"), *actual.Spec.Ingress[0].Ports[0].Port)
+				from := actual.Spec.Ingress[0].From
+				require.Len(t, from, 4+len(tc.expected))
+				assert.Equal(t, ComponentControllerManager, from[0].PodSelector.MatchLabels[manifestutils.LabelComponent])
+				assert.Equal(t, ComponentScheduler, from[1].PodSelector.MatchLabels[manifestutils.LabelComponent])
+				assert.Equal(t, "kink-system", from[2].NamespaceSelector.MatchLabels[corev1.LabelMetadataName])
+				assert.Nil(t, from[2].PodSelector)
+				assert.Equal(t, tc.expected, from[3:len(from)-1])
+				assert.Equal(t, metricsPeer, from[len(from)-1])
+			})
+		}
+	})
+}
@@ -33,6 +33,7 @@ import (
 
 type Scheduler struct {
 	KinkControlPlane *controlplanev1alpha1.KinkControlPlane
+	Hibernated       bool
 }
 
 func (b *Scheduler) Build() ([]client.Object, error) {
@@ -82,13 +83,18 @@ func (b *Scheduler) Deployment() (*appsv1.Deployment, error) {
 	if *replicas > 1 {
 		ha = true
 	}
+	if b.Hibernated {
+		replicas = ptr.To[int32](0)
+	}
 
 	podSpec := corev1.PodSpec{
 		Affinity:         manifestutils.Affinity(b.KinkControlPlane),
 		Containers:       []corev1.Container{b.container(image, ha)},
 		Volumes:          b.volumes(),
 		ImagePullSecrets: b.KinkControlPlane.Spec.ImagePullSecrets,
+		SecurityContext:  manifestutils.PodSecurityContext(b.KinkControlPlane.Spec.Scheduler.Container),
 	}
+	podSpec = withExtras(podSpec, b.KinkControlPlane.Spec.Scheduler.PodExtras)
 
 	return &appsv1.Deployment{
 		ObjectMeta: metav1.ObjectMeta{
@@ -168,6 +174,7 @@ func (b *Scheduler) volumes() []corev1.Volume {
 				},
 			},
 		},
+		manifestutils.TmpVolume(),
 	}
 }
 
@@ -178,6 +185,7 @@ func (b *Scheduler) volumeMounts() []corev1.VolumeMount {
 			ReadOnly:  true,
 			MountPath: kubeconfigPath,
 		},
+		manifestutils.TmpVolumeMount(),
 	}
 }
 
@@ -214,7 +222,7 @@ func (b *Scheduler) container(image string, ha bool) corev1.Container {
 		},
 		VolumeMounts:    b.volumeMounts(),
 		ImagePullPolicy: b.KinkControlPlane.Spec.APIServer.ImagePullPolicy,
-		SecurityContext: nil,
+		SecurityContext: manifestutils.SecurityContext(b.KinkControlPlane.Spec.Scheduler.Container),
 		LivenessProbe: &corev1.Probe{
 			ProbeHandler: corev1.ProbeHandler{
 				HTTPGet: &corev1.HTTPGetAction{
@@ -0,0 +1,244 @@
+// Copyright 2025 anza-labs contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controlplane
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+	"time"
+
+	controlplanev1alpha1 "github.com/anza-labs/kink/api/controlplane/v1alpha1"
+	"github.com/anza-labs/kink/internal/manifests/manifestutils"
+	"github.com/anza-labs/kink/internal/naming"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// retireServiceAccountKeyAnnotationPrefix prefixes the annotations recording the time at
+	// which a previous service account key is removed, suffixed with the ID of the key.
+	retireServiceAccountKeyAnnotationPrefix = "control-plane.kink.anza-labs.dev/retire-"
+
+	// serviceAccountKeySuffix suffixes the keys of the Secret holding the public keys.
+	serviceAccountKeySuffix = ".pub"
+
+	defaultServiceAccountKeyRetention = 24 * time.Hour
+
+	serviceAccountKeysPKIPath = "/etc/pki/service-account-keys"
+)
+
+// ServiceAccountKeys manages the generation of the Secret holding the public keys verifying the
+// service account tokens, and of the Secret holding the key signing them. A newly issued key is
+// distributed first: its public key is added to the verifying keys, and the signing key is only
+// switched to it once all API server replicas verify the tokens with it, so that the tokens signed
+// by any replica are accepted by all the others. The previous public key is then retained for the
+// retention period, so that the tokens it signed remain valid until they are refreshed.
+type ServiceAccountKeys struct {
+	client.Client
+	KinkControlPlane *controlplanev1alpha1.KinkControlPlane
+
+	// VerifyingKeys are the IDs of the public keys loaded by all the API server replicas.
+	VerifyingKeys sets.Set[string]
+}
+
+// Build constructs the Secret holding the public keys and the Secret holding the signing key.
+func (b *ServiceAccountKeys) Build(ctx context.Context) (*corev1.Secret, *corev1.Secret, error) {
+	name := naming.ServiceAccountKeys(b.KinkControlPlane.Name)
+
+	existing := &corev1.Secret{}
+	err := b.Get(ctx, types.NamespacedName{Name: name, Namespace: b.KinkControlPlane.Namespace}, existing)
+	if client.IgnoreNotFound(err) != nil {
+		return nil, nil, fmt.Errorf("failed to fetch service account keys: %w", err)
+	}
+
+	secretRef := types.NamespacedName{
+		Name:      naming.ServiceAccountCertificate(b.KinkControlPlane.Name),
+		Namespace: b.KinkControlPlane.Namespace,
+	}
+	issued := &corev1.Secret{}
+	if err := b.Get(ctx, secretRef, issued); err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch secret %s: %w", secretRef, err)
+	}
+	issuedKey, err := publicKey(issued.Data[corev1.TLSCertKey])
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", secretRef, err)
+	}
+
+	signingRef := types.NamespacedName{
+		Name:      naming.ServiceAccountSigningKey(b.KinkControlPlane.Name),
+		Namespace: b.KinkControlPlane.Namespace,
+	}
+	signing := &corev1.Secret{}
+	err = b.Get(ctx, signingRef, signing)
+	if client.IgnoreNotFound(err) != nil {
+		return nil, nil, fmt.Errorf("failed to fetch secret %s: %w", signingRef, err)
+	}
+
+	// The issued key becomes the signing key once it is verified by all the API server replicas,
+	// or right away when there is no signing key yet, as no token has been signed.
+	signingData := map[string][]byte{
+		corev1.TLSCertKey:       issued.Data[corev1.TLSCertKey],
+		corev1.TLSPrivateKeyKey: issued.Data[corev1.TLSPrivateKeyKey],
+	}
+	signingKey := issuedKey
+	if current, err := publicKey(signing.Data[corev1.TLSCertKey]); err == nil &&
+		keyID(current) != keyID(issuedKey) && !b.VerifyingKeys.Has(keyID(issuedKey)) {
+		signingData = map[string][]byte{
+			corev1.TLSCertKey:       signing.Data[corev1.TLSCertKey],
+			corev1.TLSPrivateKeyKey: signing.Data[corev1.TLSPrivateKeyKey],
+		}
+		signingKey = current
+	}
+
+	retention := defaultServiceAccountKeyRetention
+	if certs := b.KinkControlPlane.Spec.Certificates; certs != nil && certs.ServiceAccountKeyRetention != nil {
+		retention = certs.ServiceAccountKeyRetention.Duration
+	}
+
+	selectorLabels := manifestutils.SelectorLabels(
+		b.KinkControlPlane.ObjectMeta,
+		ComponentCertificates, ConceptControlPlane,
+	)
+	annotations := manifestutils.Annotations(b.KinkControlPlane, nil)
+
+	now := time.Now()
+	data := map[string][]byte{
+		keyID(issuedKey) + serviceAccountKeySuffix:  issuedKey,
+		keyID(signingKey) + serviceAccountKeySuffix: signingKey,
+	}
+	for key, value := range existing.Data {
+		id, ok := strings.CutSuffix(key, serviceAccountKeySuffix)
+		if !ok || id == keyID(issuedKey) || id == keyID(signingKey) {
+			continue
+		}
+
+		// The retention starts when the key is replaced by a new signing key.
+		annotation := retireServiceAccountKeyAnnotationPrefix + id
+		retireAt, err := time.Parse(time.RFC3339, existing.Annotations[annotation])
+		if err != nil {
+			retireAt = now.Add(retention)
+		}
+		if !now.Before(retireAt) {
+			continue
+		}
+
+		data[key] = value
+		annotations[annotation] = retireAt.UTC().Format(time.RFC3339)
+	}
+
+	keys := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   b.KinkControlPlane.Namespace,
+			Labels:      selectorLabels,
+			Annotations: annotations,
+		},
+		Type: corev1.SecretTypeOpaque,
+		Data: data,
+	}
+	signing = &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        signingRef.Name,
+			Namespace:   b.KinkControlPlane.Namespace,
+			Labels:      selectorLabels,
+			Annotations: manifestutils.Annotations(b.KinkControlPlane, nil),
+		},
+		Type: corev1.SecretTypeTLS,
+		Data: signingData,
+	}
+	return keys, signing, nil
+}
+
+// VerifyingServiceAccountKeys returns the IDs of the public keys the pods of the API server
+// Deployment verify the service account tokens with.
+func VerifyingServiceAccountKeys(depl *appsv1.Deployment) sets.Set[string] {
+	ids := sets.New[string]()
+	for _, container := range depl.Spec.Template.Spec.Containers {
+		if container.Name != naming.APIServerContainer() {
+			continue
+		}
+		for _, arg := range container.Args {
+			file, ok := strings.CutPrefix(arg, "--service-account-key-file=")
+			if !ok || path.Dir(file) != serviceAccountKeysPKIPath {
+				continue
+			}
+			if id, ok := strings.CutSuffix(path.Base(file), serviceAccountKeySuffix); ok {
+				ids.Insert(id)
+			}
+		}
+	}
+	return ids
+}
+
+// serviceAccountKeysVolume returns the volume mounting the service account keys.
+func serviceAccountKeysVolume(kcp *controlplanev1alpha1.KinkControlPlane) corev1.Volume {
+	return corev1.Volume{
+		Name: "service-account-keys",
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{
+				SecretName:  naming.ServiceAccountKeys(kcp.Name),
+				DefaultMode: ptr.To[int32](420),
+			},
+		},
+	}
+}
+
+// serviceAccountKeyFiles returns the paths of the public keys of the mounted Secret, sorted.
+func serviceAccountKeyFiles(secret *corev1.Secret) []string {
+	files := []string{}
+	for key := range secret.Data {
+		if strings.HasSuffix(key, serviceAccountKeySuffix) {
+			files = append(files, path.Join(serviceAccountKeysPKIPath, key))
+		}
+	}
+	slices.Sort(files)
+	return files
+}
+
+// publicKey returns the PEM encoded public key of the first PEM encoded certificate of the data.
+func publicKey(data []byte) ([]byte, error) {
+	certs := certificateBlocks(data)
+	if len(certs) == 0 {
+		return nil, ErrRequiredFieldMissing
+	}
+	cert, err := x509.ParseCertificate(certs[0])
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse certificate: %w", err)
+	}
+	der, err := x509.MarshalPKIXPublicKey(cert.PublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal public key: %w", err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), nil
+}
+
+// keyID returns a short identifier of the PEM encoded public key.
+func keyID(publicKey []byte) string {
+	sum := sha256.Sum256(publicKey)
+	return hex.EncodeToString(sum[:8])
+}
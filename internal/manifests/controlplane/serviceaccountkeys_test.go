@@ -0,0 +1,243 @@
+// Copyright 2025 anza-labs contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controlplane
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	controlplanev1alpha1 "github.com/anza-labs/kink/api/controlplane/v1alpha1"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// testServiceAccountCertificate returns a self-signed PEM encoded certificate and its public key.
+func testServiceAccountCertificate(t *testing.T) ([]byte, []byte) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "service-accounts"},
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	require.NoError(t, err)
+	pub, err := x509.MarshalPKIXPublicKey(key.Public())
+	require.NoError(t, err)
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pub})
+}
+
+func TestServiceAccountKeys(t *testing.T) {
+	t.Parallel()
+
+	cert, current := testServiceAccountCertificate(t)
+	previousCert, previous := testServiceAccountCertificate(t)
+	_, retired := testServiceAccountCertificate(t)
+	currentKey := keyID(current) + serviceAccountKeySuffix
+	previousKey := keyID(previous) + serviceAccountKeySuffix
+	retiredKey := keyID(retired) + serviceAccountKeySuffix
+
+	saSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-sa", Namespace: "default"},
+		Data:       map[string][]byte{"tls.crt": cert, "tls.key": []byte("current")},
+	}
+	signingSecret := func(cert []byte, key string) *corev1.Secret {
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-sa-signing", Namespace: "default"},
+			Data:       map[string][]byte{"tls.crt": cert, "tls.key": []byte(key)},
+		}
+	}
+	retireAt := time.Now().Add(time.Hour).UTC().Format(time.RFC3339)
+
+	for name, tc := range map[string]struct {
+		objects             []client.Object
+		verifyingKeys       sets.Set[string]
+		expectedKeys        []string
+		expectedAnnotations []string
+		expectedSigningKey  string
+	}{
+		"Initial": {
+			objects:            []client.Object{saSecret},
+			expectedKeys:       []string{currentKey},
+			expectedSigningKey: "current",
+		},
+		"Distributing": {
+			objects: []client.Object{
+				saSecret,
+				signingSecret(previousCert, "previous"),
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-sa-keys", Namespace: "default"},
+					Data:       map[string][]byte{previousKey: previous},
+				},
+			},
+			verifyingKeys:      sets.New(keyID(previous)),
+			expectedKeys:       []string{currentKey, previousKey},
+			expectedSigningKey: "previous",
+		},
+		"Switching": {
+			objects: []client.Object{
+				saSecret,
+				signingSecret(previousCert, "previous"),
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-sa-keys", Namespace: "default"},
+					Data:       map[string][]byte{currentKey: current, previousKey: previous},
+				},
+			},
+			verifyingKeys:       sets.New(keyID(current), keyID(previous)),
+			expectedKeys:        []string{currentKey, previousKey},
+			expectedAnnotations: []string{retireServiceAccountKeyAnnotationPrefix + keyID(previous)},
+			expectedSigningKey:  "current",
+		},
+		"RetainingPrevious": {
+			objects: []client.Object{
+				saSecret,
+				signingSecret(cert, "current"),
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-sa-keys", Namespace: "default"},
+					Data:       map[string][]byte{currentKey: current, previousKey: previous},
+				},
+			},
+			expectedKeys:        []string{currentKey, previousKey},
+			expectedAnnotations: []string{retireServiceAccountKeyAnnotationPrefix + keyID(previous)},
+			expectedSigningKey:  "current",
+		},
+		"RetiringPrevious": {
+			objects: []client.Object{
+				saSecret,
+				signingSecret(cert, "current"),
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "test-sa-keys",
+						Namespace: "default",
+						Annotations: map[string]string{
+							retireServiceAccountKeyAnnotationPrefix + keyID(previous): retireAt,
+							retireServiceAccountKeyAnnotationPrefix + keyID(retired):  "2025-01-01T00:00:00Z",
+						},
+					},
+					Data: map[string][]byte{currentKey: current, previousKey: previous, retiredKey: retired},
+				},
+			},
+			expectedKeys:        []string{currentKey, previousKey},
+			expectedAnnotations: []string{retireServiceAccountKeyAnnotationPrefix + keyID(previous)},
+			expectedSigningKey:  "current",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			// prepare
+			b := &ServiceAccountKeys{
+				Client: fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(tc.objects...).Build(),
+				KinkControlPlane: &controlplanev1alpha1.KinkControlPlane{
+					ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+				},
+				VerifyingKeys: tc.verifyingKeys,
+			}
+
+			// test
+			actual, signing, err := b.Build(t.Context())
+
+			// validate
+			require.NoError(t, err)
+			assert.Equal(t, "test-sa-keys", actual.Name)
+			keys := []string{}
+			for key := range actual.Data {
+				keys = append(keys, key)
+			}
+			assert.ElementsMatch(t, tc.expectedKeys, keys)
+			assert.Equal(t, current, actual.Data[currentKey])
+			annotations := []string{}
+			for annotation := range actual.Annotations {
+				annotations = append(annotations, annotation)
+			}
+			assert.ElementsMatch(t, tc.expectedAnnotations, annotations)
+			assert.Equal(t, "test-sa-signing", signing.Name)
+			assert.Equal(t, tc.expectedSigningKey, string(signing.Data["tls.key"]))
+		})
+	}
+
+	t.Run("KeyNotIssued", func(t *testing.T) {
+		t.Parallel()
+
+		// prepare
+		b := &ServiceAccountKeys{
+			Client: fake.NewClientBuilder().WithScheme(scheme.Scheme).Build(),
+			KinkControlPlane: &controlplanev1alpha1.KinkControlPlane{
+				ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+			},
+		}
+
+		// test
+		_, _, err := b.Build(t.Context())
+
+		// validate
+		assert.Error(t, err)
+	})
+}
+
+func TestVerifyingServiceAccountKeys(t *testing.T) {
+	t.Parallel()
+
+	// prepare
+	depl := &appsv1.Deployment{
+		Spec: appsv1.DeploymentSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Name: "api-server",
+							Args: []string{
+								"--service-account-issuer=https://kubernetes.default.svc.cluster.local",
+								"--service-account-key-file=/etc/pki/service-account-keys/a.pub",
+								"--service-account-key-file=/etc/pki/service-account-keys/b.pub",
+								"--service-account-key-file=/etc/pki/service-accounts/tls.key",
+							},
+						},
+						{
+							Name: "sidecar",
+							Args: []string{"--service-account-key-file=/etc/pki/service-account-keys/c.pub"},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	// test
+	actual := VerifyingServiceAccountKeys(depl)
+
+	// validate
+	assert.Equal(t, sets.New("a", "b"), actual)
+}
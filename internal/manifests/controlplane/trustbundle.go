@@ -0,0 +1,267 @@
+// Copyright 2025 anza-labs contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controlplane
+
+import (
+	"bytes"
+	"context"
+	"encoding/pem"
+	"fmt"
+	"slices"
+
+	controlplanev1alpha1 "github.com/anza-labs/kink/api/controlplane/v1alpha1"
+	"github.com/anza-labs/kink/internal/manifests/manifestutils"
+	"github.com/anza-labs/kink/internal/naming"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// ClusterCABundleKey is the key of the trust bundle holding the trusted cluster CAs.
+	ClusterCABundleKey = "cluster-ca.crt"
+
+	// KineCABundleKey is the key of the trust bundle holding the trusted Kine CAs.
+	KineCABundleKey = "kine-ca.crt"
+
+	// FrontProxyCABundleKey is the key of the trust bundle holding the trusted front proxy CAs.
+	FrontProxyCABundleKey = "front-proxy-ca.crt"
+
+	// ServiceAccountsBundleKey is the key of the trust bundle holding the service account
+	// certificates, rotated with the certificate authorities. The tokens are verified with the
+	// keys of the ServiceAccountKeys Secret, which retains the previous keys.
+	ServiceAccountsBundleKey = "service-accounts.crt"
+
+	// previousBundleKeyPrefix prefixes the keys holding the certificates replaced by a rotation.
+	previousBundleKeyPrefix = "previous-"
+
+	trustBundlePKIPath = "/etc/pki/trust-bundle"
+)
+
+// TrustAnchor is a certificate distributed to the components through the trust bundle.
+type TrustAnchor struct {
+	// Key is the key of the trust bundle holding the trusted certificates.
+	Key string
+
+	// SecretName is the name of the Secret holding the current certificate.
+	SecretName string
+
+	// Certificate is the name of the Certificate issuing the Secret. It is empty when the
+	// certificate is imported, and thus cannot be rotated.
+	Certificate string
+
+	// Issued are the names of the leaf Certificates signed by the anchor.
+	Issued []string
+}
+
+// PreviousBundleKey returns the key of the trust bundle holding the certificates replaced by
+// the rotation in progress.
+func PreviousBundleKey(key string) string {
+	return previousBundleKeyPrefix + key
+}
+
+// TrustBundle manages the generation of the Secret holding the certificates trusted by the
+// components. While the certificate authorities are rotated, the bundle trusts both the
+// previous and the current certificates.
+type TrustBundle struct {
+	client.Client
+	KinkControlPlane *controlplanev1alpha1.KinkControlPlane
+}
+
+// Anchors returns the certificates distributed through the trust bundle.
+func (b *TrustBundle) Anchors() []TrustAnchor {
+	name := b.KinkControlPlane.Name
+
+	clusterCA := TrustAnchor{
+		Key:         ClusterCABundleKey,
+		SecretName:  clusterCASecretName(b.KinkControlPlane),
+		Certificate: naming.ClusterCA(name),
+		Issued: []string{
+			naming.APIServerCertificate(name),
+			naming.AdminCertificate(name),
+			naming.SchedulerCertificate(name),
+			naming.ControllerManagerCertificate(name),
+			naming.KubeletClientCertificate(name),
+		},
+	}
+	if certs := b.KinkControlPlane.Spec.Certificates; certs != nil && certs.CASecretRef != nil {
+		clusterCA.Certificate = ""
+	}
+	if (&Monitoring{KinkControlPlane: b.KinkControlPlane}).Enabled() {
+		clusterCA.Issued = append(clusterCA.Issued, naming.MetricsClientCertificate(name))
+	}
+	for _, kubeconfig := range b.KinkControlPlane.Spec.Kubeconfigs {
+		clusterCA.Issued = append(clusterCA.Issued, naming.UserCertificate(name, kubeconfig.SecretName))
+	}
+
+	return []TrustAnchor{
+		clusterCA,
+		{
+			Key:         KineCABundleKey,
+			SecretName:  naming.KineCA(name),
+			Certificate: naming.KineCA(name),
+			Issued: []string{
+				naming.KineServerCertificate(name),
+				naming.KineAPIServerClientCertificate(name),
+			},
+		},
+		{
+			Key:         FrontProxyCABundleKey,
+			SecretName:  naming.FrontProxyCA(name),
+			Certificate: naming.FrontProxyCA(name),
+		},
+		{
+			Key:         ServiceAccountsBundleKey,
+			SecretName:  naming.ServiceAccountCertificate(name),
+			Certificate: naming.ServiceAccountCertificate(name),
+		},
+	}
+}
+
+// Build constructs the trust bundle Secret from the current certificates and, while a rotation
+// is in progress, the certificates it replaces.
+func (b *TrustBundle) Build(ctx context.Context) (*corev1.Secret, error) {
+	name := naming.TrustBundle(b.KinkControlPlane.Name)
+
+	existing := &corev1.Secret{}
+	err := b.Get(ctx, types.NamespacedName{Name: name, Namespace: b.KinkControlPlane.Namespace}, existing)
+	if client.IgnoreNotFound(err) != nil {
+		return nil, fmt.Errorf("failed to fetch trust bundle: %w", err)
+	}
+	if apierrors.IsNotFound(err) {
+		existing = nil
+	}
+
+	phase := controlplanev1alpha1.CertificateRotationPhase("")
+	if status := b.KinkControlPlane.Status.CertificateRotation; status != nil {
+		phase = status.Phase
+	}
+
+	data := map[string][]byte{}
+	for _, anchor := range b.Anchors() {
+		secretRef := types.NamespacedName{Name: anchor.SecretName, Namespace: b.KinkControlPlane.Namespace}
+		secret := &corev1.Secret{}
+		if err := b.Get(ctx, secretRef, secret); err != nil {
+			return nil, fmt.Errorf("failed to fetch secret %s: %w", secretRef, err)
+		}
+		current := FirstCertificate(secret.Data["tls.crt"])
+		if current == nil {
+			return nil, fmt.Errorf("%s: %w", secretRef, ErrRequiredFieldMissing)
+		}
+
+		var previous []byte
+		switch phase {
+		case controlplanev1alpha1.RotationPhaseIssuingCA,
+			controlplanev1alpha1.RotationPhaseDistributingTrust,
+			controlplanev1alpha1.RotationPhaseReissuingCertificates:
+			if existing != nil {
+				previous = existing.Data[PreviousBundleKey(anchor.Key)]
+			}
+			// The certificates are captured before the new ones are issued.
+			if previous == nil && phase == controlplanev1alpha1.RotationPhaseIssuingCA {
+				previous = current
+			}
+		}
+
+		data[anchor.Key] = MergeCertificates(previous, current)
+		if previous != nil {
+			data[PreviousBundleKey(anchor.Key)] = previous
+		}
+	}
+
+	selectorLabels := manifestutils.SelectorLabels(
+		b.KinkControlPlane.ObjectMeta,
+		ComponentCertificates, ConceptControlPlane,
+	)
+	annotations := manifestutils.Annotations(b.KinkControlPlane, nil)
+
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   b.KinkControlPlane.Namespace,
+			Labels:      selectorLabels,
+			Annotations: annotations,
+		},
+		Type: corev1.SecretTypeOpaque,
+		Data: data,
+	}, nil
+}
+
+// trustBundleVolume returns the volume mounting the trust bundle.
+func trustBundleVolume(kcp *controlplanev1alpha1.KinkControlPlane) corev1.Volume {
+	return corev1.Volume{
+		Name: "trust-bundle",
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{
+				SecretName:  naming.TrustBundle(kcp.Name),
+				DefaultMode: ptr.To[int32](420),
+			},
+		},
+	}
+}
+
+// FirstCertificate returns the first PEM encoded certificate of the data, or nil.
+func FirstCertificate(data []byte) []byte {
+	certs := certificateBlocks(data)
+	if len(certs) == 0 {
+		return nil
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certs[0]})
+}
+
+// MergeCertificates concatenates the PEM encoded certificates, skipping duplicates.
+func MergeCertificates(bundles ...[]byte) []byte {
+	merged := []byte{}
+	seen := [][]byte{}
+	for _, data := range bundles {
+		for _, cert := range certificateBlocks(data) {
+			if slices.ContainsFunc(seen, func(s []byte) bool { return bytes.Equal(s, cert) }) {
+				continue
+			}
+			seen = append(seen, cert)
+			merged = append(merged, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert})...)
+		}
+	}
+	return merged
+}
+
+// ContainsCertificate reports whether the PEM encoded bundle contains the first certificate
+// of the PEM encoded data.
+func ContainsCertificate(bundle, data []byte) bool {
+	certs := certificateBlocks(data)
+	if len(certs) == 0 {
+		return false
+	}
+	return slices.ContainsFunc(certificateBlocks(bundle), func(c []byte) bool { return bytes.Equal(c, certs[0]) })
+}
+
+// certificateBlocks returns the DER encoded certificates of the PEM encoded data.
+func certificateBlocks(data []byte) [][]byte {
+	certs := [][]byte{}
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			return certs
+		}
+		if block.Type == "CERTIFICATE" {
+			certs = append(certs, block.Bytes)
+		}
+	}
+}
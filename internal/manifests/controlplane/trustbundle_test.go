@@ -0,0 +1,168 @@
+// Copyright 2025 anza-labs contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controlplane
+
+import (
+	"encoding/pem"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	controlplanev1alpha1 "github.com/anza-labs/kink/api/controlplane/v1alpha1"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func testCertificate(content string) []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: []byte(content)})
+}
+
+func TestTrustBundle(t *testing.T) {
+	t.Parallel()
+
+	secret := func(name string, cert []byte) *corev1.Secret {
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Data:       map[string][]byte{"tls.crt": cert},
+		}
+	}
+	current := []client.Object{
+		secret("test-ca", testCertificate("cluster-ca")),
+		secret("test-etcd", testCertificate("kine-ca")),
+		secret("test-proxy", testCertificate("front-proxy-ca")),
+		secret("test-sa", testCertificate("sa")),
+	}
+	existing := secret("test-trust-bundle", nil)
+	existing.Data = map[string][]byte{
+		PreviousBundleKey(ClusterCABundleKey): testCertificate("old-cluster-ca"),
+	}
+
+	for name, tc := range map[string]struct {
+		phase    controlplanev1alpha1.CertificateRotationPhase
+		objects  []client.Object
+		expected map[string][]byte
+	}{
+		"NoRotation": {
+			objects: append([]client.Object{existing}, current...),
+			expected: map[string][]byte{
+				ClusterCABundleKey:       testCertificate("cluster-ca"),
+				KineCABundleKey:          testCertificate("kine-ca"),
+				FrontProxyCABundleKey:    testCertificate("front-proxy-ca"),
+				ServiceAccountsBundleKey: testCertificate("sa"),
+			},
+		},
+		"CapturingPrevious": {
+			phase:   controlplanev1alpha1.RotationPhaseIssuingCA,
+			objects: current,
+			expected: map[string][]byte{
+				ClusterCABundleKey:                          testCertificate("cluster-ca"),
+				KineCABundleKey:                             testCertificate("kine-ca"),
+				FrontProxyCABundleKey:                       testCertificate("front-proxy-ca"),
+				ServiceAccountsBundleKey:                    testCertificate("sa"),
+				PreviousBundleKey(ClusterCABundleKey):       testCertificate("cluster-ca"),
+				PreviousBundleKey(KineCABundleKey):          testCertificate("kine-ca"),
+				PreviousBundleKey(FrontProxyCABundleKey):    testCertificate("front-proxy-ca"),
+				PreviousBundleKey(ServiceAccountsBundleKey): testCertificate("sa"),
+			},
+		},
+		"TrustingBoth": {
+			phase:   controlplanev1alpha1.RotationPhaseDistributingTrust,
+			objects: append([]client.Object{existing}, current...),
+			expected: map[string][]byte{
+				ClusterCABundleKey: append(
+					testCertificate("old-cluster-ca"),
+					testCertificate("cluster-ca")...,
+				),
+				KineCABundleKey:                       testCertificate("kine-ca"),
+				FrontProxyCABundleKey:                 testCertificate("front-proxy-ca"),
+				ServiceAccountsBundleKey:              testCertificate("sa"),
+				PreviousBundleKey(ClusterCABundleKey): testCertificate("old-cluster-ca"),
+			},
+		},
+		"RemovingPrevious": {
+			phase:   controlplanev1alpha1.RotationPhaseRemovingOldCA,
+			objects: append([]client.Object{existing}, current...),
+			expected: map[string][]byte{
+				ClusterCABundleKey:       testCertificate("cluster-ca"),
+				KineCABundleKey:          testCertificate("kine-ca"),
+				FrontProxyCABundleKey:    testCertificate("front-proxy-ca"),
+				ServiceAccountsBundleKey: testCertificate("sa"),
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			// prepare
+			kcp := &controlplanev1alpha1.KinkControlPlane{
+				ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+			}
+			if tc.phase != "" {
+				kcp.Status.CertificateRotation = &controlplanev1alpha1.CertificateRotationStatus{Phase: tc.phase}
+			}
+			tb := &TrustBundle{
+				Client:           fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(tc.objects...).Build(),
+				KinkControlPlane: kcp,
+			}
+
+			// test
+			actual, err := tb.Build(t.Context())
+
+			// validate
+			require.NoError(t, err)
+			assert.Equal(t, "test-trust-bundle", actual.Name)
+			assert.Equal(t, tc.expected, actual.Data)
+		})
+	}
+
+	t.Run("MissingSecret", func(t *testing.T) {
+		t.Parallel()
+
+		// prepare
+		tb := &TrustBundle{
+			Client: fake.NewClientBuilder().WithScheme(scheme.Scheme).Build(),
+			KinkControlPlane: &controlplanev1alpha1.KinkControlPlane{
+				ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+			},
+		}
+
+		// test
+		_, err := tb.Build(t.Context())
+
+		// validate
+		assert.Error(t, err)
+	})
+}
+
+func TestMergeCertificates(t *testing.T) {
+	t.Parallel()
+
+	// prepare
+	a, b := testCertificate("a"), testCertificate("b")
+
+	// test
+	actual := MergeCertificates(append(a, b...), b, nil, a)
+
+	// validate
+	assert.Equal(t, append(a, b...), actual)
+	assert.True(t, ContainsCertificate(actual, b))
+	assert.False(t, ContainsCertificate(a, b))
+	assert.False(t, ContainsCertificate(actual, nil))
+}
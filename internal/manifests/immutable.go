@@ -0,0 +1,142 @@
+// Copyright 2025 anza-labs contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Additional copyrights:
+// Copyright The OpenTelemetry Authors
+
+package manifests
+
+import (
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+type ImmutableFieldChangeErr struct {
+	Field string
+}
+
+func (e *ImmutableFieldChangeErr) Error() string {
+	return fmt.Sprintf("Immutable field change attempted: %s", e.Field)
+}
+
+var (
+	ImmutableChangeErr *ImmutableFieldChangeErr
+)
+
+// CheckImmutableFields verifies that applying desired over existing does not change
+// any of the immutable fields of the existing resource.
+// Existing is expected to be set by the controller-runtime package through a client get call.
+// Resources that have no immutable fields known to the operator always pass the check.
+func CheckImmutableFields(existing, desired client.Object) error {
+	switch existing := existing.(type) {
+	case *appsv1.Deployment:
+		return checkDeployment(existing, desired.(*appsv1.Deployment))
+
+	case *appsv1.StatefulSet:
+		return checkStatefulSet(existing, desired.(*appsv1.StatefulSet))
+
+	case *corev1.Service:
+		return checkService(existing, desired.(*corev1.Service))
+
+	default:
+		return nil
+	}
+}
+
+func checkDeployment(existing, desired *appsv1.Deployment) error {
+	if existing.CreationTimestamp.IsZero() {
+		return nil
+	}
+
+	if !apiequality.Semantic.DeepEqual(desired.Spec.Selector, existing.Spec.Selector) {
+		return &ImmutableFieldChangeErr{Field: "Spec.Selector"}
+	}
+	return hasImmutableLabelChange(existing.Spec.Selector.MatchLabels, desired.Spec.Template.Labels)
+}
+
+func checkStatefulSet(existing, desired *appsv1.StatefulSet) error {
+	if existing.CreationTimestamp.IsZero() {
+		return nil
+	}
+
+	if !apiequality.Semantic.DeepEqual(desired.Spec.Selector, existing.Spec.Selector) {
+		return &ImmutableFieldChangeErr{Field: "Spec.Selector"}
+	}
+	if err := hasImmutableLabelChange(existing.Spec.Selector.MatchLabels, desired.Spec.Template.Labels); err != nil {
+		return err
+	}
+	if hasVolumeClaimsTemplatesChanged(existing, desired) {
+		return &ImmutableFieldChangeErr{Field: "Spec.VolumeClaimTemplates"}
+	}
+	return nil
+}
+
+func checkService(existing, desired *corev1.Service) error {
+	if existing.CreationTimestamp.IsZero() {
+		return nil
+	}
+
+	// The load balancer class can only be set when the Service becomes a LoadBalancer.
+	if existing.Spec.Type == corev1.ServiceTypeLoadBalancer && desired.Spec.Type == corev1.ServiceTypeLoadBalancer &&
+		!apiequality.Semantic.DeepEqual(desired.Spec.LoadBalancerClass, existing.Spec.LoadBalancerClass) {
+		return &ImmutableFieldChangeErr{Field: "Spec.LoadBalancerClass"}
+	}
+	return nil
+}
+
+func hasImmutableLabelChange(existingSelectorLabels, desiredLabels map[string]string) error {
+	for k, v := range existingSelectorLabels {
+		if vv, ok := desiredLabels[k]; !ok || vv != v {
+			return &ImmutableFieldChangeErr{Field: "Spec.Template.Metadata.Labels"}
+		}
+	}
+	return nil
+}
+
+// hasVolumeClaimsTemplatesChanged if volume claims template change has been detected.
+// We need to do this manually due to some fields being automatically filled by the API server
+// and these needs to be excluded from the comparison to prevent false positives.
+//
+//nolint:lll // let it be long
+func hasVolumeClaimsTemplatesChanged(existing, desired *appsv1.StatefulSet) bool {
+	if len(desired.Spec.VolumeClaimTemplates) != len(existing.Spec.VolumeClaimTemplates) {
+		return true
+	}
+
+	for i := range desired.Spec.VolumeClaimTemplates {
+		// VolumeMode is automatically set by the API server, so if it is not set in the CR, assume it's the same as the existing one.
+		if desired.Spec.VolumeClaimTemplates[i].Spec.VolumeMode == nil || *desired.Spec.VolumeClaimTemplates[i].Spec.VolumeMode == "" {
+			desired.Spec.VolumeClaimTemplates[i].Spec.VolumeMode = existing.Spec.VolumeClaimTemplates[i].Spec.VolumeMode
+		}
+
+		if desired.Spec.VolumeClaimTemplates[i].Name != existing.Spec.VolumeClaimTemplates[i].Name {
+			return true
+		}
+		if !apiequality.Semantic.DeepEqual(desired.Spec.VolumeClaimTemplates[i].Annotations, existing.Spec.VolumeClaimTemplates[i].Annotations) {
+			return true
+		}
+		if !apiequality.Semantic.DeepEqual(desired.Spec.VolumeClaimTemplates[i].Labels, existing.Spec.VolumeClaimTemplates[i].Labels) {
+			return true
+		}
+		if !apiequality.Semantic.DeepEqual(desired.Spec.VolumeClaimTemplates[i].Spec, existing.Spec.VolumeClaimTemplates[i].Spec) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,775 @@
+// Copyright 2025 anza-labs contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Additional copyrights:
+// Copyright The OpenTelemetry Authors
+
+//nolint:dupl // just don't
+package manifests
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anza-labs/kink/internal/manifests/manifestutils"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestNoImmutableLabelChange(t *testing.T) {
+	existingSelectorLabels := map[string]string{
+		manifestutils.LabelComponent: "test-component",
+		manifestutils.LabelInstance:  "default.deployment",
+		manifestutils.LabelManagedBy: "test-operator",
+		manifestutils.LabelPartOf:    "test",
+	}
+	desiredLabels := map[string]string{
+		manifestutils.LabelComponent: "test-component",
+		manifestutils.LabelInstance:  "default.deployment",
+		manifestutils.LabelManagedBy: "test-operator",
+		manifestutils.LabelPartOf:    "test",
+		"extra-label":                "true",
+	}
+	err := hasImmutableLabelChange(existingSelectorLabels, desiredLabels)
+	require.NoError(t, err)
+	assert.NoError(t, err)
+}
+
+func TestHasImmutableLabelChange(t *testing.T) {
+	existingSelectorLabels := map[string]string{
+		manifestutils.LabelComponent: "test-component",
+		manifestutils.LabelInstance:  "default.deployment",
+		manifestutils.LabelManagedBy: "test-operator",
+		manifestutils.LabelPartOf:    "test",
+	}
+	desiredLabels := map[string]string{
+		manifestutils.LabelComponent: "test-component",
+		manifestutils.LabelInstance:  "default.deployment",
+		manifestutils.LabelManagedBy: "test-operator",
+		manifestutils.LabelPartOf:    "not-test",
+	}
+	err := hasImmutableLabelChange(existingSelectorLabels, desiredLabels)
+	assert.Error(t, err)
+}
+
+func TestMissingImmutableLabelChange(t *testing.T) {
+	existingSelectorLabels := map[string]string{
+		manifestutils.LabelComponent: "test-component",
+		manifestutils.LabelInstance:  "default.deployment",
+		manifestutils.LabelManagedBy: "test-operator",
+		manifestutils.LabelPartOf:    "test",
+	}
+	desiredLabels := map[string]string{
+		manifestutils.LabelComponent: "test-component",
+		manifestutils.LabelInstance:  "default.deployment",
+		manifestutils.LabelManagedBy: "test-operator",
+	}
+	err := hasImmutableLabelChange(existingSelectorLabels, desiredLabels)
+	assert.Error(t, err)
+}
+
+func TestCheckImmutableFieldsDeploymentError(t *testing.T) {
+	tests := []struct {
+		name     string
+		existing appsv1.Deployment
+		desired  appsv1.Deployment
+	}{
+		{
+			name: "modified immutable label in deployment",
+			existing: appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{
+					CreationTimestamp: metav1.Now(),
+					Name:              "deployment",
+				},
+				Spec: appsv1.DeploymentSpec{
+					Selector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{
+							manifestutils.LabelComponent: "test-component",
+							manifestutils.LabelInstance:  "default.deployment",
+							manifestutils.LabelManagedBy: "test-operator",
+							manifestutils.LabelPartOf:    "test",
+						},
+					},
+					Template: corev1.PodTemplateSpec{
+						ObjectMeta: metav1.ObjectMeta{
+							Labels: map[string]string{
+								manifestutils.LabelComponent: "test-component",
+								manifestutils.LabelInstance:  "default.deployment",
+								manifestutils.LabelManagedBy: "test-operator",
+								manifestutils.LabelPartOf:    "test",
+							},
+						},
+						Spec: corev1.PodSpec{
+							Containers: []corev1.Container{
+								{
+									Name:  "test",
+									Image: "test-image:latest",
+								},
+							},
+						},
+					},
+				},
+			},
+			desired: appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "deployment",
+				},
+				Spec: appsv1.DeploymentSpec{
+					Selector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{
+							manifestutils.LabelComponent: "test-component",
+							manifestutils.LabelInstance:  "default.deployment",
+							manifestutils.LabelManagedBy: "test-operator",
+							manifestutils.LabelPartOf:    "test",
+						},
+					},
+					Template: corev1.PodTemplateSpec{
+						ObjectMeta: metav1.ObjectMeta{
+							Labels: map[string]string{
+								manifestutils.LabelComponent: "test-component",
+								manifestutils.LabelInstance:  "default.deployment",
+								manifestutils.LabelManagedBy: "test-operator",
+								manifestutils.LabelPartOf:    "not-test",
+							},
+						},
+						Spec: corev1.PodSpec{
+							Containers: []corev1.Container{
+								{
+									Name:  "test",
+									Image: "test-image:latest",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "modified immutable selector in deployment",
+			existing: appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{
+					CreationTimestamp: metav1.Now(),
+					Name:              "deployment",
+				},
+				Spec: appsv1.DeploymentSpec{
+					Selector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{
+							manifestutils.LabelComponent: "test-component",
+							manifestutils.LabelInstance:  "default.deployment",
+							manifestutils.LabelManagedBy: "test-operator",
+							manifestutils.LabelPartOf:    "test",
+						},
+					},
+					Template: corev1.PodTemplateSpec{
+						ObjectMeta: metav1.ObjectMeta{
+							Labels: map[string]string{
+								manifestutils.LabelComponent: "test-component",
+								manifestutils.LabelInstance:  "default.deployment",
+								manifestutils.LabelManagedBy: "test-operator",
+								manifestutils.LabelPartOf:    "test",
+							},
+						},
+						Spec: corev1.PodSpec{
+							Containers: []corev1.Container{
+								{
+									Name:  "test",
+									Image: "test-image:latest",
+								},
+							},
+						},
+					},
+				},
+			},
+			desired: appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "deployment",
+				},
+				Spec: appsv1.DeploymentSpec{
+					Selector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{
+							manifestutils.LabelComponent: "test-component",
+							manifestutils.LabelInstance:  "default.deployment",
+							manifestutils.LabelManagedBy: "test-operator",
+							manifestutils.LabelPartOf:    "not-test",
+						},
+					},
+					Template: corev1.PodTemplateSpec{
+						ObjectMeta: metav1.ObjectMeta{
+							Labels: map[string]string{
+								manifestutils.LabelComponent: "test-component",
+								manifestutils.LabelInstance:  "default.deployment",
+								manifestutils.LabelManagedBy: "test-operator",
+								manifestutils.LabelPartOf:    "test",
+							},
+						},
+						Spec: corev1.PodSpec{
+							Containers: []corev1.Container{
+								{
+									Name:  "test",
+									Image: "test-image:latest",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckImmutableFields(&tt.existing, &tt.desired)
+			assert.ErrorAs(t, err, &ImmutableChangeErr)
+		})
+	}
+}
+
+func TestCheckImmutableFieldsStatefulSetError(t *testing.T) {
+	tests := []struct {
+		name     string
+		existing appsv1.StatefulSet
+		desired  appsv1.StatefulSet
+	}{
+		{
+			name: "modified immutable label in statefulset",
+			existing: appsv1.StatefulSet{
+				ObjectMeta: metav1.ObjectMeta{
+					CreationTimestamp: metav1.Now(),
+					Name:              "statefulset",
+				},
+				Spec: appsv1.StatefulSetSpec{
+					Selector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{
+							manifestutils.LabelComponent: "test-component",
+							manifestutils.LabelInstance:  "default.statefulset",
+							manifestutils.LabelManagedBy: "test-operator",
+							manifestutils.LabelPartOf:    "test",
+						},
+					},
+					Template: corev1.PodTemplateSpec{
+						ObjectMeta: metav1.ObjectMeta{
+							Labels: map[string]string{
+								manifestutils.LabelComponent: "test-component",
+								manifestutils.LabelInstance:  "default.statefulset",
+								manifestutils.LabelManagedBy: "test-operator",
+								manifestutils.LabelPartOf:    "test",
+							},
+						},
+						Spec: corev1.PodSpec{
+							Containers: []corev1.Container{
+								{
+									Name:  "test",
+									Image: "test-image:latest",
+								},
+							},
+						},
+					},
+				},
+			},
+			desired: appsv1.StatefulSet{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "statefulset",
+				},
+				Spec: appsv1.StatefulSetSpec{
+					Selector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{
+							manifestutils.LabelComponent: "test-component",
+							manifestutils.LabelInstance:  "default.statefulset",
+							manifestutils.LabelManagedBy: "test-operator",
+							manifestutils.LabelPartOf:    "test",
+						},
+					},
+					Template: corev1.PodTemplateSpec{
+						ObjectMeta: metav1.ObjectMeta{
+							Labels: map[string]string{
+								manifestutils.LabelComponent: "test-component",
+								manifestutils.LabelInstance:  "default.statefulset",
+								manifestutils.LabelManagedBy: "test-operator",
+								manifestutils.LabelPartOf:    "not-test",
+							},
+						},
+						Spec: corev1.PodSpec{
+							Containers: []corev1.Container{
+								{
+									Name:  "test",
+									Image: "test-image:latest",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "modified immutable selector in statefulset",
+			existing: appsv1.StatefulSet{
+				ObjectMeta: metav1.ObjectMeta{
+					CreationTimestamp: metav1.Now(),
+					Name:              "statefulset",
+				},
+				Spec: appsv1.StatefulSetSpec{
+					Selector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{
+							manifestutils.LabelComponent: "test-component",
+							manifestutils.LabelInstance:  "default.statefulset",
+							manifestutils.LabelManagedBy: "test-operator",
+							manifestutils.LabelPartOf:    "test",
+						},
+					},
+					Template: corev1.PodTemplateSpec{
+						ObjectMeta: metav1.ObjectMeta{
+							Labels: map[string]string{
+								manifestutils.LabelComponent: "test-component",
+								manifestutils.LabelInstance:  "default.statefulset",
+								manifestutils.LabelManagedBy: "test-operator",
+								manifestutils.LabelPartOf:    "test",
+							},
+						},
+						Spec: corev1.PodSpec{
+							Containers: []corev1.Container{
+								{
+									Name:  "test",
+									Image: "test-image:latest",
+								},
+							},
+						},
+					},
+				},
+			},
+			desired: appsv1.StatefulSet{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "statefulset",
+				},
+				Spec: appsv1.StatefulSetSpec{
+					Selector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{
+							manifestutils.LabelComponent: "test-component",
+							manifestutils.LabelInstance:  "default.statefulset",
+							manifestutils.LabelManagedBy: "test-operator",
+							manifestutils.LabelPartOf:    "not-test",
+						},
+					},
+					Template: corev1.PodTemplateSpec{
+						ObjectMeta: metav1.ObjectMeta{
+							Labels: map[string]string{
+								manifestutils.LabelComponent: "test-component",
+								manifestutils.LabelInstance:  "default.statefulset",
+								manifestutils.LabelManagedBy: "test-operator",
+								manifestutils.LabelPartOf:    "test",
+							},
+						},
+						Spec: corev1.PodSpec{
+							Containers: []corev1.Container{
+								{
+									Name:  "test",
+									Image: "test-image:latest",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckImmutableFields(&tt.existing, &tt.desired)
+			assert.ErrorAs(t, err, &ImmutableChangeErr)
+		})
+	}
+}
+
+func TestCheckImmutableFieldsDeploymentLabelChange(t *testing.T) {
+	tests := []struct {
+		name     string
+		existing appsv1.Deployment
+		desired  appsv1.Deployment
+	}{
+		{
+			name: "modified label in deployment",
+			existing: appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{
+					CreationTimestamp: metav1.Now(),
+					Name:              "deployment",
+				},
+				Spec: appsv1.DeploymentSpec{
+					Selector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{
+							manifestutils.LabelComponent: "test-component",
+							manifestutils.LabelInstance:  "default.deployment",
+							manifestutils.LabelManagedBy: "test-operator",
+							manifestutils.LabelPartOf:    "test",
+						},
+					},
+					Template: corev1.PodTemplateSpec{
+						ObjectMeta: metav1.ObjectMeta{
+							Labels: map[string]string{
+								manifestutils.LabelComponent: "test-component",
+								manifestutils.LabelInstance:  "default.deployment",
+								manifestutils.LabelManagedBy: "test-operator",
+								manifestutils.LabelPartOf:    "test",
+								"user-label":                 "existing",
+							},
+						},
+						Spec: corev1.PodSpec{
+							Containers: []corev1.Container{
+								{
+									Name:  "test",
+									Image: "test-image:latest",
+								},
+							},
+						},
+					},
+				},
+			},
+			desired: appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "deployment",
+				},
+				Spec: appsv1.DeploymentSpec{
+					Selector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{
+							manifestutils.LabelComponent: "test-component",
+							manifestutils.LabelInstance:  "default.deployment",
+							manifestutils.LabelManagedBy: "test-operator",
+							manifestutils.LabelPartOf:    "test",
+						},
+					},
+					Template: corev1.PodTemplateSpec{
+						ObjectMeta: metav1.ObjectMeta{
+							Labels: map[string]string{
+								manifestutils.LabelComponent: "test-component",
+								manifestutils.LabelInstance:  "default.deployment",
+								manifestutils.LabelManagedBy: "test-operator",
+								manifestutils.LabelPartOf:    "test",
+								"user-label":                 "desired",
+							},
+						},
+						Spec: corev1.PodSpec{
+							Containers: []corev1.Container{
+								{
+									Name:  "test",
+									Image: "test-image:latest",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "new label in deployment",
+			existing: appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{
+					CreationTimestamp: metav1.Now(),
+					Name:              "deployment",
+				},
+				Spec: appsv1.DeploymentSpec{
+					Selector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{
+							manifestutils.LabelComponent: "test-component",
+							manifestutils.LabelInstance:  "default.deployment",
+							manifestutils.LabelManagedBy: "test-operator",
+							manifestutils.LabelPartOf:    "test",
+						},
+					},
+					Template: corev1.PodTemplateSpec{
+						ObjectMeta: metav1.ObjectMeta{
+							Labels: map[string]string{
+								manifestutils.LabelComponent: "test-component",
+								manifestutils.LabelInstance:  "default.deployment",
+								manifestutils.LabelManagedBy: "test-operator",
+								manifestutils.LabelPartOf:    "test",
+								"user-label":                 "existing",
+							},
+						},
+						Spec: corev1.PodSpec{
+							Containers: []corev1.Container{
+								{
+									Name:  "test",
+									Image: "test-image:latest",
+								},
+							},
+						},
+					},
+				},
+			},
+			desired: appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "deployment",
+				},
+				Spec: appsv1.DeploymentSpec{
+					Selector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{
+							manifestutils.LabelComponent: "test-component",
+							manifestutils.LabelInstance:  "default.deployment",
+							manifestutils.LabelManagedBy: "test-operator",
+							manifestutils.LabelPartOf:    "test",
+						},
+					},
+					Template: corev1.PodTemplateSpec{
+						ObjectMeta: metav1.ObjectMeta{
+							Labels: map[string]string{
+								manifestutils.LabelComponent: "test-component",
+								manifestutils.LabelInstance:  "default.deployment",
+								manifestutils.LabelManagedBy: "test-operator",
+								manifestutils.LabelPartOf:    "test",
+								"user-label":                 "existing",
+								"new-user-label":             "desired",
+							},
+						},
+						Spec: corev1.PodSpec{
+							Containers: []corev1.Container{
+								{
+									Name:  "test",
+									Image: "test-image:latest",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckImmutableFields(&tt.existing, &tt.desired)
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestCheckImmutableFieldsStatefulSetLabelChange(t *testing.T) {
+	tests := []struct {
+		name     string
+		existing appsv1.StatefulSet
+		desired  appsv1.StatefulSet
+	}{
+		{
+			name: "modified label in statefulset",
+			existing: appsv1.StatefulSet{
+				ObjectMeta: metav1.ObjectMeta{
+					CreationTimestamp: metav1.Now(),
+					Name:              "statefulset",
+				},
+				Spec: appsv1.StatefulSetSpec{
+					Selector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{
+							manifestutils.LabelComponent: "test-component",
+							manifestutils.LabelInstance:  "default.statefulset",
+							manifestutils.LabelManagedBy: "test-operator",
+							manifestutils.LabelPartOf:    "test",
+						},
+					},
+					Template: corev1.PodTemplateSpec{
+						ObjectMeta: metav1.ObjectMeta{
+							Labels: map[string]string{
+								manifestutils.LabelComponent: "test-component",
+								manifestutils.LabelInstance:  "default.statefulset",
+								manifestutils.LabelManagedBy: "test-operator",
+								manifestutils.LabelPartOf:    "test",
+								"user-label":                 "existing",
+							},
+						},
+						Spec: corev1.PodSpec{
+							Containers: []corev1.Container{
+								{
+									Name:  "test",
+									Image: "test-image:latest",
+								},
+							},
+						},
+					},
+				},
+			},
+			desired: appsv1.StatefulSet{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "statefulset",
+				},
+				Spec: appsv1.StatefulSetSpec{
+					Selector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{
+							manifestutils.LabelComponent: "test-component",
+							manifestutils.LabelInstance:  "default.statefulset",
+							manifestutils.LabelManagedBy: "test-operator",
+							manifestutils.LabelPartOf:    "test",
+						},
+					},
+					Template: corev1.PodTemplateSpec{
+						ObjectMeta: metav1.ObjectMeta{
+							Labels: map[string]string{
+								manifestutils.LabelComponent: "test-component",
+								manifestutils.LabelInstance:  "default.statefulset",
+								manifestutils.LabelManagedBy: "test-operator",
+								manifestutils.LabelPartOf:    "test",
+								"user-label":                 "desired",
+							},
+						},
+						Spec: corev1.PodSpec{
+							Containers: []corev1.Container{
+								{
+									Name:  "test",
+									Image: "test-image:latest",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "new label in statefulset",
+			existing: appsv1.StatefulSet{
+				ObjectMeta: metav1.ObjectMeta{
+					CreationTimestamp: metav1.Now(),
+					Name:              "statefulset",
+				},
+				Spec: appsv1.StatefulSetSpec{
+					Selector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{
+							manifestutils.LabelComponent: "test-component",
+							manifestutils.LabelInstance:  "default.statefulset",
+							manifestutils.LabelManagedBy: "test-operator",
+							manifestutils.LabelPartOf:    "test",
+						},
+					},
+					Template: corev1.PodTemplateSpec{
+						ObjectMeta: metav1.ObjectMeta{
+							Labels: map[string]string{
+								manifestutils.LabelComponent: "test-component",
+								manifestutils.LabelInstance:  "default.statefulset",
+								manifestutils.LabelManagedBy: "test-operator",
+								manifestutils.LabelPartOf:    "test",
+								"user-label":                 "existing",
+							},
+						},
+						Spec: corev1.PodSpec{
+							Containers: []corev1.Container{
+								{
+									Name:  "test",
+									Image: "test-image:latest",
+								},
+							},
+						},
+					},
+				},
+			},
+			desired: appsv1.StatefulSet{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "statefulset",
+				},
+				Spec: appsv1.StatefulSetSpec{
+					Selector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{
+							manifestutils.LabelComponent: "test-component",
+							manifestutils.LabelInstance:  "default.statefulset",
+							manifestutils.LabelManagedBy: "test-operator",
+							manifestutils.LabelPartOf:    "test",
+						},
+					},
+					Template: corev1.PodTemplateSpec{
+						ObjectMeta: metav1.ObjectMeta{
+							Labels: map[string]string{
+								manifestutils.LabelComponent: "test-component",
+								manifestutils.LabelInstance:  "default.statefulset",
+								manifestutils.LabelManagedBy: "test-operator",
+								manifestutils.LabelPartOf:    "test",
+								"user-label":                 "existing",
+								"new-user-label":             "desired",
+							},
+						},
+						Spec: corev1.PodSpec{
+							Containers: []corev1.Container{
+								{
+									Name:  "test",
+									Image: "test-image:latest",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckImmutableFields(&tt.existing, &tt.desired)
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestCheckImmutableFieldsService(t *testing.T) {
+	service := func(serviceType corev1.ServiceType, class *string) corev1.Service {
+		return corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{
+				CreationTimestamp: metav1.Now(),
+				Name:              "service",
+			},
+			Spec: corev1.ServiceSpec{
+				Type:              serviceType,
+				LoadBalancerClass: class,
+			},
+		}
+	}
+	internal := "example.com/internal"
+	external := "example.com/external"
+
+	tests := []struct {
+		name      string
+		existing  corev1.Service
+		desired   corev1.Service
+		expectErr bool
+	}{
+		{
+			name:     "unchanged load balancer class",
+			existing: service(corev1.ServiceTypeLoadBalancer, &internal),
+			desired:  service(corev1.ServiceTypeLoadBalancer, &internal),
+		},
+		{
+			name:      "modified load balancer class",
+			existing:  service(corev1.ServiceTypeLoadBalancer, &internal),
+			desired:   service(corev1.ServiceTypeLoadBalancer, &external),
+			expectErr: true,
+		},
+		{
+			name:      "added load balancer class",
+			existing:  service(corev1.ServiceTypeLoadBalancer, nil),
+			desired:   service(corev1.ServiceTypeLoadBalancer, &internal),
+			expectErr: true,
+		},
+		{
+			name:     "load balancer class set with type change",
+			existing: service(corev1.ServiceTypeClusterIP, nil),
+			desired:  service(corev1.ServiceTypeLoadBalancer, &internal),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckImmutableFields(&tt.existing, &tt.desired)
+			if tt.expectErr {
+				assert.ErrorAs(t, err, &ImmutableChangeErr)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,89 @@
+// Copyright 2025 anza-labs contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manifestutils
+
+import (
+	kinkcorev1alpha1 "github.com/anza-labs/kink/api/core/v1alpha1"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+)
+
+const (
+	// NonRootUser is the user and group the components run as, the nonroot user of the
+	// distroless images.
+	NonRootUser int64 = 65532
+
+	// TmpVolumeName is the name of the writable volume mounted at /tmp, as the root filesystem
+	// of the containers is read-only.
+	TmpVolumeName = "tmp"
+	tmpMountPath  = "/tmp"
+)
+
+// PodSecurityContext returns the security context of the pod, hardened to comply with the
+// restricted Pod Security Standard unless replaced by the container configuration.
+func PodSecurityContext(container kinkcorev1alpha1.Container) *corev1.PodSecurityContext {
+	if container.PodSecurityContext != nil {
+		return container.PodSecurityContext
+	}
+
+	return &corev1.PodSecurityContext{
+		RunAsNonRoot: ptr.To(true),
+		RunAsUser:    ptr.To(NonRootUser),
+		RunAsGroup:   ptr.To(NonRootUser),
+		FSGroup:      ptr.To(NonRootUser),
+		SeccompProfile: &corev1.SeccompProfile{
+			Type: corev1.SeccompProfileTypeRuntimeDefault,
+		},
+	}
+}
+
+// SecurityContext returns the security context of the container, hardened to comply with the
+// restricted Pod Security Standard unless replaced by the container configuration.
+func SecurityContext(container kinkcorev1alpha1.Container) *corev1.SecurityContext {
+	if container.SecurityContext != nil {
+		return container.SecurityContext
+	}
+
+	return &corev1.SecurityContext{
+		RunAsNonRoot:             ptr.To(true),
+		ReadOnlyRootFilesystem:   ptr.To(true),
+		AllowPrivilegeEscalation: ptr.To(false),
+		Capabilities: &corev1.Capabilities{
+			Drop: []corev1.Capability{"ALL"},
+		},
+		SeccompProfile: &corev1.SeccompProfile{
+			Type: corev1.SeccompProfileTypeRuntimeDefault,
+		},
+	}
+}
+
+// TmpVolume returns the writable volume backing /tmp.
+func TmpVolume() corev1.Volume {
+	return corev1.Volume{
+		Name: TmpVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			EmptyDir: &corev1.EmptyDirVolumeSource{},
+		},
+	}
+}
+
+// TmpVolumeMount returns the mount of the writable volume backing /tmp.
+func TmpVolumeMount() corev1.VolumeMount {
+	return corev1.VolumeMount{
+		Name:      TmpVolumeName,
+		MountPath: tmpMountPath,
+	}
+}
@@ -0,0 +1,66 @@
+// Copyright 2025 anza-labs contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manifestutils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	kinkcorev1alpha1 "github.com/anza-labs/kink/api/core/v1alpha1"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+)
+
+func TestSecurityContext(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Default", func(t *testing.T) {
+		t.Parallel()
+
+		// test
+		pod := PodSecurityContext(kinkcorev1alpha1.Container{})
+		container := SecurityContext(kinkcorev1alpha1.Container{})
+
+		// validate
+		assert.True(t, *pod.RunAsNonRoot)
+		assert.Equal(t, NonRootUser, *pod.RunAsUser)
+		assert.Equal(t, corev1.SeccompProfileTypeRuntimeDefault, pod.SeccompProfile.Type)
+		assert.True(t, *container.RunAsNonRoot)
+		assert.True(t, *container.ReadOnlyRootFilesystem)
+		assert.False(t, *container.AllowPrivilegeEscalation)
+		assert.Equal(t, []corev1.Capability{"ALL"}, container.Capabilities.Drop)
+		assert.Equal(t, corev1.SeccompProfileTypeRuntimeDefault, container.SeccompProfile.Type)
+	})
+
+	t.Run("Override", func(t *testing.T) {
+		t.Parallel()
+
+		// prepare
+		cfg := kinkcorev1alpha1.Container{
+			SecurityContext:    &corev1.SecurityContext{RunAsUser: ptr.To[int64](1000)},
+			PodSecurityContext: &corev1.PodSecurityContext{FSGroup: ptr.To[int64](1000)},
+		}
+
+		// test
+		pod := PodSecurityContext(cfg)
+		container := SecurityContext(cfg)
+
+		// validate
+		assert.Equal(t, cfg.PodSecurityContext, pod)
+		assert.Equal(t, cfg.SecurityContext, container)
+	})
+}
@@ -0,0 +1,299 @@
+// Copyright 2025 anza-labs contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snirouter
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	controlplanev1alpha1 "github.com/anza-labs/kink/api/controlplane/v1alpha1"
+	"github.com/anza-labs/kink/internal/manifests/manifestutils"
+	"github.com/anza-labs/kink/internal/naming"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	ConceptSNIRouter   = "kink-sni-router"
+	ComponentSNIRouter = "sni-router"
+)
+
+// Port is the port on which the SNI router exposes the API servers.
+const Port int32 = 443
+
+const (
+	// listenPort is the port on which HAProxy listens, which is unprivileged.
+	listenPort = 8443
+
+	configPath = "/usr/local/etc/haproxy"
+	configKey  = "haproxy.cfg"
+
+	// configHashAnnotation records the hash of the configuration in the pod template, so that
+	// the router is rolled out when the routing table changes.
+	configHashAnnotation = "sni-router.kink.anza-labs.dev/config-hash"
+)
+
+// Route routes the TLS connections whose server name is the host to the Service of an API server.
+type Route struct {
+	Host      string
+	Service   string
+	Namespace string
+}
+
+// SNIRouter manages the generation of the HAProxy instance shared between control planes, which
+// routes the TLS connections by server name without terminating them.
+type SNIRouter struct {
+	Namespace   string
+	Image       string
+	ServiceType corev1.ServiceType
+	Routes      []Route
+}
+
+func (b *SNIRouter) Build() ([]client.Object, error) {
+	cm := b.ConfigMap()
+
+	depl, err := b.Deployment(cm)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build Deployment: %w", err)
+	}
+
+	return []client.Object{cm, depl, b.Service()}, nil
+}
+
+func (b *SNIRouter) ConfigMap() *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      naming.SNIRouter(),
+			Namespace: b.Namespace,
+			Labels:    b.labels(),
+		},
+		Data: map[string]string{
+			configKey: b.config(),
+		},
+	}
+}
+
+func (b *SNIRouter) Deployment(cm *corev1.ConfigMap) (*appsv1.Deployment, error) {
+	name := naming.SNIRouter()
+	labels := b.labels()
+
+	hash, err := manifestutils.GetConfigMapSHA(cm.Data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash configuration: %w", err)
+	}
+
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: b.Namespace,
+			Labels:    labels,
+		},
+		Spec: appsv1.DeploymentSpec{
+			Selector: &metav1.LabelSelector{
+				MatchLabels: b.selectorLabels(),
+			},
+			Replicas: ptr.To[int32](2),
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels:      labels,
+					Annotations: map[string]string{configHashAnnotation: hash},
+				},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{b.container()},
+					Volumes: []corev1.Volume{
+						{
+							Name: "config",
+							VolumeSource: corev1.VolumeSource{
+								ConfigMap: &corev1.ConfigMapVolumeSource{
+									LocalObjectReference: corev1.LocalObjectReference{Name: cm.Name},
+									DefaultMode:          ptr.To[int32](420),
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}, nil
+}
+
+func (b *SNIRouter) Service() *corev1.Service {
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      naming.SNIRouter(),
+			Namespace: b.Namespace,
+			Labels:    b.labels(),
+		},
+		Spec: corev1.ServiceSpec{
+			Selector: b.selectorLabels(),
+			Type:     b.ServiceType,
+			Ports: []corev1.ServicePort{
+				{
+					Name:       "tls",
+					Port:       Port,
+					TargetPort: intstr.FromString("tls"),
+					Protocol:   corev1.ProtocolTCP,
+				},
+			},
+		},
+	}
+}
+
+func (b *SNIRouter) container() corev1.Container {
+	probe := &corev1.Probe{
+		ProbeHandler: corev1.ProbeHandler{
+			TCPSocket: &corev1.TCPSocketAction{Port: intstr.FromString("tls")},
+		},
+		PeriodSeconds: 10,
+	}
+
+	return corev1.Container{
+		Name:  naming.SNIRouterContainer(),
+		Image: b.Image,
+		Ports: []corev1.ContainerPort{
+			{
+				Name:          "tls",
+				ContainerPort: listenPort,
+				Protocol:      corev1.ProtocolTCP,
+			},
+		},
+		VolumeMounts: []corev1.VolumeMount{
+			{
+				Name:      "config",
+				MountPath: configPath,
+				ReadOnly:  true,
+			},
+		},
+		ReadinessProbe: probe,
+		LivenessProbe:  probe,
+	}
+}
+
+// config renders the configuration of HAProxy, with one backend per route, sorted by host.
+// The addresses of the backends are resolved at runtime, so that a missing Service does not
+// prevent the router from starting.
+func (b *SNIRouter) config() string {
+	routes := slices.Clone(b.Routes)
+	slices.SortFunc(routes, func(a, b Route) int {
+		return strings.Compare(a.Host, b.Host)
+	})
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, `global
+  log stdout format raw local0
+
+resolvers cluster
+  parse-resolv-conf
+  hold valid 10s
+
+defaults
+  mode tcp
+  log global
+  option tcplog
+  timeout connect 5s
+  timeout client 1h
+  timeout server 1h
+
+frontend sni
+  bind :%d
+  tcp-request inspect-delay 5s
+  tcp-request content accept if { req_ssl_hello_type 1 }
+`, listenPort)
+	for _, route := range routes {
+		fmt.Fprintf(&sb, "  use_backend %s if { req_ssl_sni -i %s }\n", route.Host, route.Host)
+	}
+	for _, route := range routes {
+		fmt.Fprintf(&sb, "\nbackend %s\n  server api-server %s.%s.svc.cluster.local:6443 resolvers cluster init-addr none\n",
+			route.Host, route.Service, route.Namespace)
+	}
+	return sb.String()
+}
+
+func (b *SNIRouter) labels() map[string]string {
+	return manifestutils.Labels(
+		b.objectMeta(),
+		naming.SNIRouter(), b.Image, ComponentSNIRouter, ConceptSNIRouter,
+		nil,
+	)
+}
+
+func (b *SNIRouter) selectorLabels() map[string]string {
+	return SelectorLabels(b.Namespace)
+}
+
+func (b *SNIRouter) objectMeta() metav1.ObjectMeta {
+	return objectMeta(b.Namespace)
+}
+
+// SelectorLabels returns the labels selecting the pods of the SNI router in the namespace.
+func SelectorLabels(namespace string) map[string]string {
+	return manifestutils.SelectorLabels(objectMeta(namespace), ComponentSNIRouter, ConceptSNIRouter)
+}
+
+func objectMeta(namespace string) metav1.ObjectMeta {
+	return metav1.ObjectMeta{Name: naming.SNIRouter(), Namespace: namespace}
+}
+
+// Host returns the host assigned to the control plane under the base domain of the SNI router.
+func Host(kcp *controlplanev1alpha1.KinkControlPlane, baseDomain string) controlplanev1alpha1.HostnameOrIP {
+	if router := kcp.Spec.ControlPlaneEndpoint.SNIRouter; router != nil && router.Subdomain != "" {
+		return controlplanev1alpha1.HostnameOrIP(router.Subdomain + "." + baseDomain)
+	}
+	return naming.SNIRouterHost(kcp.Name, kcp.Namespace, baseDomain)
+}
+
+// ClaimedHost returns the host on which the control plane is routed, which is either configured
+// or assigned under the base domain of the SNI router.
+func ClaimedHost(kcp *controlplanev1alpha1.KinkControlPlane, baseDomain string) string {
+	if host := kcp.Spec.ControlPlaneEndpoint.Host; host != "" {
+		return string(host)
+	}
+	return string(Host(kcp, baseDomain))
+}
+
+// HostOwners returns the control planes routed by the SNI router, by host. A host claimed by
+// several control planes belongs to the first one created, so that a control plane created later
+// cannot take it over, whatever its namespace and name.
+func HostOwners(
+	kcps []controlplanev1alpha1.KinkControlPlane,
+	baseDomain string,
+) map[string]*controlplanev1alpha1.KinkControlPlane {
+	kcps = slices.Clone(kcps)
+	slices.SortFunc(kcps, func(a, b controlplanev1alpha1.KinkControlPlane) int {
+		if c := a.CreationTimestamp.Compare(b.CreationTimestamp.Time); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Namespace+"/"+a.Name, b.Namespace+"/"+b.Name)
+	})
+
+	owners := map[string]*controlplanev1alpha1.KinkControlPlane{}
+	for i := range kcps {
+		kcp := &kcps[i]
+		if kcp.Spec.ControlPlaneEndpoint.SNIRouter == nil || !kcp.DeletionTimestamp.IsZero() {
+			continue
+		}
+		host := ClaimedHost(kcp, baseDomain)
+		if _, claimed := owners[host]; !claimed {
+			owners[host] = kcp
+		}
+	}
+	return owners
+}
@@ -0,0 +1,139 @@
+// Copyright 2025 anza-labs contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snirouter
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	controlplanev1alpha1 "github.com/anza-labs/kink/api/controlplane/v1alpha1"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestSNIRouter(t *testing.T) {
+	t.Parallel()
+
+	routes := []Route{
+		{Host: "b.example.com", Service: "b-api-server", Namespace: "tenant-b"},
+		{Host: "a.example.com", Service: "a-api-server", Namespace: "tenant-a"},
+	}
+
+	t.Run("Build", func(t *testing.T) {
+		t.Parallel()
+
+		// prepare
+		router := &SNIRouter{
+			Namespace:   "kink-system",
+			Image:       "docker.io/library/haproxy:3.2.0",
+			ServiceType: corev1.ServiceTypeLoadBalancer,
+			Routes:      routes,
+		}
+
+		// test
+		actual, err := router.Build()
+
+		// validate
+		require.NoError(t, err)
+		assert.Len(t, actual, 3)
+		for _, obj := range actual {
+			assert.Equal(t, "kink-sni-router", obj.GetName())
+			assert.Equal(t, "kink-system", obj.GetNamespace())
+		}
+	})
+
+	t.Run("Config", func(t *testing.T) {
+		t.Parallel()
+
+		// prepare
+		router := &SNIRouter{Routes: routes}
+
+		// test
+		actual := router.ConfigMap().Data[configKey]
+
+		// validate
+		a := strings.Index(actual, "use_backend a.example.com if { req_ssl_sni -i a.example.com }")
+		b := strings.Index(actual, "use_backend b.example.com if { req_ssl_sni -i b.example.com }")
+		assert.NotEqual(t, -1, a)
+		assert.Less(t, a, b)
+		assert.Contains(t, actual,
+			"backend a.example.com\n  server api-server a-api-server.tenant-a.svc.cluster.local:6443")
+	})
+
+	t.Run("RolloutOnChange", func(t *testing.T) {
+		t.Parallel()
+
+		// prepare
+		hash := func(routes []Route) string {
+			router := &SNIRouter{Routes: routes}
+			depl, err := router.Deployment(router.ConfigMap())
+			require.NoError(t, err)
+			return configHash(depl)
+		}
+
+		// test
+		before := hash(routes[:1])
+		after := hash(routes)
+
+		// validate
+		assert.NotEqual(t, before, after)
+		assert.Equal(t, after, hash([]Route{routes[1], routes[0]}))
+	})
+}
+
+func TestHost(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		router   *controlplanev1alpha1.SNIRouter
+		expected controlplanev1alpha1.HostnameOrIP
+	}{
+		"Default": {
+			router:   &controlplanev1alpha1.SNIRouter{},
+			expected: "test-tenant.example.com",
+		},
+		"Subdomain": {
+			router:   &controlplanev1alpha1.SNIRouter{Subdomain: "prod"},
+			expected: "prod.example.com",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			// prepare
+			kcp := &controlplanev1alpha1.KinkControlPlane{
+				ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "tenant"},
+				Spec: controlplanev1alpha1.KinkControlPlaneSpec{
+					ControlPlaneEndpoint: controlplanev1alpha1.APIEndpoint{SNIRouter: tc.router},
+				},
+			}
+
+			// test
+			actual := Host(kcp, "example.com")
+
+			// validate
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
+func configHash(depl *appsv1.Deployment) string {
+	return depl.Spec.Template.Annotations[configHashAnnotation]
+}
@@ -0,0 +1,177 @@
+// Copyright 2025 anza-labs contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"context"
+	"time"
+
+	cmv1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/prometheus/client_golang/prometheus"
+
+	controlplanev1alpha1 "github.com/anza-labs/kink/api/controlplane/v1alpha1"
+	"github.com/anza-labs/kink/internal/manifests/manifestutils"
+
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// collectTimeout bounds the time spent listing objects during a single scrape.
+const collectTimeout = 10 * time.Second
+
+var controlPlaneLabels = []string{"namespace", "name"}
+
+var (
+	readyDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "control_plane", "ready"),
+		"Whether the control plane is ready (1) or not (0).",
+		controlPlaneLabels, nil,
+	)
+	initializedDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "control_plane", "initialized"),
+		"Whether the control plane is initialized (1) or not (0).",
+		controlPlaneLabels, nil,
+	)
+	infoDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "control_plane", "info"),
+		"Information about the control plane, the value is always 1.",
+		append(controlPlaneLabels, "version", "observed_version"), nil,
+	)
+	componentReplicasDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "control_plane", "component_replicas"),
+		"Number of replicas of a control plane component by state.",
+		append(controlPlaneLabels, "component", "state"), nil,
+	)
+	certificateExpiryDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "control_plane", "certificate_expiration_timestamp_seconds"),
+		"Expiration time of a control plane certificate in seconds since the Unix epoch.",
+		append(controlPlaneLabels, "certificate"), nil,
+	)
+)
+
+// Collector reports the state of the KinkControlPlanes and the objects they own.
+// The state is read on every scrape, usually from the manager cache.
+type Collector struct {
+	reader client.Reader
+}
+
+var _ prometheus.Collector = (*Collector)(nil)
+
+// NewCollector returns a new Collector reading objects with the given reader.
+func NewCollector(reader client.Reader) *Collector {
+	return &Collector{reader: reader}
+}
+
+// Describe implements prometheus.Collector.
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- readyDesc
+	ch <- initializedDesc
+	ch <- infoDesc
+	ch <- componentReplicasDesc
+	ch <- certificateExpiryDesc
+}
+
+// Collect implements prometheus.Collector.
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), collectTimeout)
+	defer cancel()
+
+	l := log.FromContext(ctx).WithName("metrics-collector")
+
+	kcps := &controlplanev1alpha1.KinkControlPlaneList{}
+	if err := c.reader.List(ctx, kcps); err != nil {
+		l.Error(err, "Failed to list KinkControlPlanes")
+		return
+	}
+
+	owners := map[types.UID]*controlplanev1alpha1.KinkControlPlane{}
+	for i := range kcps.Items {
+		kcp := &kcps.Items[i]
+		owners[kcp.UID] = kcp
+
+		version := ""
+		if kcp.Status.Version != nil {
+			version = *kcp.Status.Version
+		}
+		ch <- prometheus.MustNewConstMetric(readyDesc, prometheus.GaugeValue,
+			boolToFloat(kcp.Status.Ready), kcp.Namespace, kcp.Name)
+		ch <- prometheus.MustNewConstMetric(initializedDesc, prometheus.GaugeValue,
+			boolToFloat(kcp.Status.Initialized), kcp.Namespace, kcp.Name)
+		ch <- prometheus.MustNewConstMetric(infoDesc, prometheus.GaugeValue,
+			1, kcp.Namespace, kcp.Name, kcp.Spec.Version, version)
+	}
+
+	deployments := &appsv1.DeploymentList{}
+	if err := c.reader.List(ctx, deployments); err != nil {
+		l.Error(err, "Failed to list Deployments")
+	}
+	for _, depl := range deployments.Items {
+		kcp := controllerOf(owners, depl.ObjectMeta)
+		if kcp == nil {
+			continue
+		}
+
+		component := depl.Labels[manifestutils.LabelComponent]
+		desired := int32(1)
+		if depl.Spec.Replicas != nil {
+			desired = *depl.Spec.Replicas
+		}
+		for state, value := range map[string]int32{
+			"desired":     desired,
+			"ready":       depl.Status.ReadyReplicas,
+			"available":   depl.Status.AvailableReplicas,
+			"updated":     depl.Status.UpdatedReplicas,
+			"unavailable": depl.Status.UnavailableReplicas,
+		} {
+			ch <- prometheus.MustNewConstMetric(componentReplicasDesc, prometheus.GaugeValue,
+				float64(value), kcp.Namespace, kcp.Name, component, state)
+		}
+	}
+
+	certificates := &cmv1.CertificateList{}
+	if err := c.reader.List(ctx, certificates); err != nil {
+		l.Error(err, "Failed to list Certificates")
+	}
+	for _, cert := range certificates.Items {
+		kcp := controllerOf(owners, cert.ObjectMeta)
+		if kcp == nil || cert.Status.NotAfter == nil {
+			continue
+		}
+		ch <- prometheus.MustNewConstMetric(certificateExpiryDesc, prometheus.GaugeValue,
+			float64(cert.Status.NotAfter.Unix()), kcp.Namespace, kcp.Name, cert.Name)
+	}
+}
+
+// controllerOf returns the control plane controlling the object, or nil.
+func controllerOf(
+	owners map[types.UID]*controlplanev1alpha1.KinkControlPlane,
+	obj metav1.ObjectMeta,
+) *controlplanev1alpha1.KinkControlPlane {
+	ref := metav1.GetControllerOfNoCopy(&obj)
+	if ref == nil {
+		return nil
+	}
+	return owners[ref.UID]
+}
+
+func boolToFloat(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,120 @@
+// Copyright 2025 anza-labs contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	cmv1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+
+	controlplanev1alpha1 "github.com/anza-labs/kink/api/controlplane/v1alpha1"
+	"github.com/anza-labs/kink/internal/manifests/manifestutils"
+
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestCollector(t *testing.T) {
+	t.Parallel()
+
+	// prepare
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(cmv1.AddToScheme(scheme))
+	utilruntime.Must(controlplanev1alpha1.AddToScheme(scheme))
+
+	kcp := &controlplanev1alpha1.KinkControlPlane{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default", UID: "kcp-uid"},
+		Spec:       controlplanev1alpha1.KinkControlPlaneSpec{Version: "v1.33.0"},
+		Status: controlplanev1alpha1.KinkControlPlaneStatus{
+			Ready:       true,
+			Initialized: true,
+			Version:     ptr.To("v1.33.0"),
+		},
+	}
+	owner := []metav1.OwnerReference{{
+		APIVersion: controlplanev1alpha1.GroupVersion.String(),
+		Kind:       "KinkControlPlane",
+		Name:       kcp.Name,
+		UID:        kcp.UID,
+		Controller: ptr.To(true),
+	}}
+	depl := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "test-apiserver",
+			Namespace:       "default",
+			Labels:          map[string]string{manifestutils.LabelComponent: "apiserver"},
+			OwnerReferences: owner,
+		},
+		Spec: appsv1.DeploymentSpec{Replicas: ptr.To[int32](2)},
+		Status: appsv1.DeploymentStatus{
+			ReadyReplicas:     2,
+			AvailableReplicas: 2,
+			UpdatedReplicas:   1,
+		},
+	}
+	unowned := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "unowned", Namespace: "default"},
+	}
+	cert := &cmv1.Certificate{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-apiserver", Namespace: "default", OwnerReferences: owner},
+		Status: cmv1.CertificateStatus{
+			NotAfter: &metav1.Time{Time: time.Unix(1767225600, 0)},
+		},
+	}
+
+	c := NewCollector(fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(kcp, depl, unowned, cert).
+		Build())
+
+	//nolint:lll // the expected output follows the Prometheus text format
+	expected := `
+# HELP kink_control_plane_certificate_expiration_timestamp_seconds Expiration time of a control plane certificate in seconds since the Unix epoch.
+# TYPE kink_control_plane_certificate_expiration_timestamp_seconds gauge
+kink_control_plane_certificate_expiration_timestamp_seconds{certificate="test-apiserver",name="test",namespace="default"} 1.7672256e+09
+# HELP kink_control_plane_component_replicas Number of replicas of a control plane component by state.
+# TYPE kink_control_plane_component_replicas gauge
+kink_control_plane_component_replicas{component="apiserver",name="test",namespace="default",state="available"} 2
+kink_control_plane_component_replicas{component="apiserver",name="test",namespace="default",state="desired"} 2
+kink_control_plane_component_replicas{component="apiserver",name="test",namespace="default",state="ready"} 2
+kink_control_plane_component_replicas{component="apiserver",name="test",namespace="default",state="unavailable"} 0
+kink_control_plane_component_replicas{component="apiserver",name="test",namespace="default",state="updated"} 1
+# HELP kink_control_plane_info Information about the control plane, the value is always 1.
+# TYPE kink_control_plane_info gauge
+kink_control_plane_info{name="test",namespace="default",observed_version="v1.33.0",version="v1.33.0"} 1
+# HELP kink_control_plane_initialized Whether the control plane is initialized (1) or not (0).
+# TYPE kink_control_plane_initialized gauge
+kink_control_plane_initialized{name="test",namespace="default"} 1
+# HELP kink_control_plane_ready Whether the control plane is ready (1) or not (0).
+# TYPE kink_control_plane_ready gauge
+kink_control_plane_ready{name="test",namespace="default"} 1
+`
+
+	// test
+	err := testutil.CollectAndCompare(c, strings.NewReader(expected))
+
+	// validate
+	require.NoError(t, err)
+}
@@ -0,0 +1,104 @@
+// Copyright 2025 anza-labs contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package metrics contains the kink specific metrics exposed on the manager metrics server.
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	namespace = "kink"
+
+	// LabelKind is the kind of the owned object.
+	LabelKind = "kind"
+
+	// LabelPhase is the phase of the reconciliation.
+	LabelPhase = "phase"
+)
+
+// Phases of the control plane reconciliation.
+const (
+	PhaseBuild      = "build"
+	PhaseApply      = "apply"
+	PhaseKubeconfig = "kubeconfig"
+	PhaseStatus     = "status"
+)
+
+// lifecycleBuckets span from a few seconds up to an hour, which covers the provisioning
+// of a control plane including image pulls and load balancer allocation.
+var lifecycleBuckets = []float64{5, 10, 20, 30, 60, 120, 300, 600, 1200, 1800, 3600}
+
+var (
+	// DriftCorrections counts the updates applied to existing owned objects to bring them back
+	// to the desired state. Updates applying a change of the desired state are not counted.
+	DriftCorrections = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "drift_corrections_total",
+			Help:      "Number of updates applied to owned objects which drifted from the desired state.",
+		},
+		[]string{LabelKind},
+	)
+
+	// ReconcilePhaseDuration observes the duration of each phase of the reconciliation.
+	ReconcilePhaseDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "reconcile_phase_duration_seconds",
+			Help:      "Duration of the control plane reconciliation phases.",
+			Buckets:   prometheus.DefBuckets,
+		},
+		[]string{LabelPhase},
+	)
+
+	// TimeToFirstReady observes the time from the creation of a control plane until it is
+	// ready for the first time.
+	TimeToFirstReady = prometheus.NewHistogram(
+		prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "control_plane_time_to_first_ready_seconds",
+			Help:      "Time from the creation of a control plane until it became ready for the first time.",
+			Buckets:   lifecycleBuckets,
+		},
+	)
+
+	// EndpointProvisioningLatency observes the time from the creation of a control plane until
+	// its endpoint is known.
+	EndpointProvisioningLatency = prometheus.NewHistogram(
+		prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "control_plane_endpoint_provisioning_seconds",
+			Help:      "Time from the creation of a control plane until its endpoint was provisioned.",
+			Buckets:   lifecycleBuckets,
+		},
+	)
+)
+
+// PhaseTimer returns a timer which observes the duration of the reconciliation phase.
+func PhaseTimer(phase string) *prometheus.Timer {
+	return prometheus.NewTimer(ReconcilePhaseDuration.WithLabelValues(phase))
+}
+
+func init() {
+	metrics.Registry.MustRegister(
+		DriftCorrections,
+		ReconcilePhaseDuration,
+		TimeToFirstReady,
+		EndpointProvisioningLatency,
+	)
+}
@@ -74,6 +74,12 @@ func PublicAPIServerEndpoint(host v1alpha1.HostnameOrIP, port int32) string {
 	return fmt.Sprintf("https://%s", net.JoinHostPort(string(host), fmt.Sprint(port)))
 }
 
+// SNIRouterHost returns the hostname routed to the API server by the shared SNI router, which is
+// a single label under the base domain, so that a wildcard DNS record covers all control planes.
+func SNIRouterHost(name, namespace, baseDomain string) v1alpha1.HostnameOrIP {
+	return v1alpha1.HostnameOrIP(fmt.Sprintf("%s.%s", DNSName(Truncate("%s-%s", 63, name, namespace)), baseDomain))
+}
+
 func LocalAPIServerEndpoint(name, namespace string) string {
 	serviceName := APIServer(name)
 	if namespace != "" {
@@ -78,10 +78,18 @@ func KineContainer() string {
 	return "kine"
 }
 
+func MetricsClientCertificate(base string) string {
+	return DNSName(Truncate("%s-metrics-cert", 63, base))
+}
+
 func Kubeconfig(base string) string {
 	return DNSName(Truncate("%s-kubeconfig", 63, base))
 }
 
+func KubeletClientCertificate(base string) string {
+	return DNSName(Truncate("%s-kubelet-client-cert", 63, base))
+}
+
 func RootCA(base string) string {
 	return DNSName(Truncate("%s-root-ca", 63, base))
 }
@@ -102,6 +110,30 @@ func ServiceAccountCertificate(base string) string {
 	return DNSName(Truncate("%s-sa", 63, base))
 }
 
+func ServiceAccountKeys(base string) string {
+	return DNSName(Truncate("%s-sa-keys", 63, base))
+}
+
+func ServiceAccountSigningKey(base string) string {
+	return DNSName(Truncate("%s-sa-signing", 63, base))
+}
+
+func SNIRouter() string {
+	return "kink-sni-router"
+}
+
+func SNIRouterContainer() string {
+	return "haproxy"
+}
+
+func TrustBundle(base string) string {
+	return DNSName(Truncate("%s-trust-bundle", 63, base))
+}
+
+func UserCertificate(base, secretName string) string {
+	return DNSName(Truncate("%s-user-%s", 63, base, secretName))
+}
+
 func Node(base string) string {
 	return DNSName(Truncate("%s-node", 63, base))
 }
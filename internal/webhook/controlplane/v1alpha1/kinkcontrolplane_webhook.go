@@ -15,22 +15,45 @@
 package v1alpha1
 
 import (
+	"cmp"
 	"context"
+	"encoding/json"
 	"fmt"
+	"net"
+	"net/url"
+	"path"
+	"slices"
+	"strings"
+	"time"
+
+	jsonpatch "github.com/evanphx/json-patch/v5"
 
 	controlplanev1alpha1 "github.com/anza-labs/kink/api/controlplane/v1alpha1"
+	kinkcorev1alpha1 "github.com/anza-labs/kink/api/core/v1alpha1"
+	"github.com/anza-labs/kink/internal/manifests/controlplane"
+	"github.com/anza-labs/kink/internal/manifests/snirouter"
+	"github.com/anza-labs/kink/internal/naming"
 
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+	"sigs.k8s.io/yaml"
 )
 
 // SetupKinkControlPlaneWebhookWithManager registers the webhook for KinkControlPlane in the manager.
-func SetupKinkControlPlaneWebhookWithManager(mgr ctrl.Manager) error {
+// The hosts of the control planes exposed through the SNI router are restricted to its base domain.
+func SetupKinkControlPlaneWebhookWithManager(mgr ctrl.Manager, sniRouterBaseDomain string) error {
 	return ctrl.NewWebhookManagedBy(mgr).For(&controlplanev1alpha1.KinkControlPlane{}).
-		WithValidator(&KinkControlPlaneCustomValidator{}).
+		WithValidator(&KinkControlPlaneCustomValidator{SNIRouterBaseDomain: sniRouterBaseDomain}).
 		WithDefaulter(&KinkControlPlaneCustomDefaulter{}).
 		Complete()
 }
@@ -69,7 +92,11 @@ func (d *KinkControlPlaneCustomDefaulter) Default(
 
 // KinkControlPlaneCustomValidator struct is responsible for validating the KinkControlPlane resource
 // when it is created, updated, or deleted.
-type KinkControlPlaneCustomValidator struct{}
+type KinkControlPlaneCustomValidator struct {
+	// SNIRouterBaseDomain is the domain under which the hosts of the control planes exposed
+	// through the SNI router are assigned, empty when the SNI router is not enabled.
+	SNIRouterBaseDomain string
+}
 
 var _ webhook.CustomValidator = &KinkControlPlaneCustomValidator{}
 
@@ -86,9 +113,11 @@ func (v *KinkControlPlaneCustomValidator) ValidateCreate(
 	}
 	log.Info("Validation for KinkControlPlane upon creation", "name", kinkcontrolplane.GetName())
 
-	// TODO(user): fill in your validation logic upon object creation.
-
-	return nil, validate(kinkcontrolplane.Spec)
+	return nil, invalid("KinkControlPlane", kinkcontrolplane.GetName(),
+		validate(field.NewPath("spec"), kinkcontrolplane.Spec, validationOptions{
+			name:                kinkcontrolplane.Name,
+			sniRouterBaseDomain: v.SNIRouterBaseDomain,
+		}))
 }
 
 // ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type KinkControlPlane.
@@ -98,15 +127,23 @@ func (v *KinkControlPlaneCustomValidator) ValidateUpdate(
 ) (admission.Warnings, error) {
 	log := log.FromContext(ctx)
 
+	oldKinkControlPlane, ok := oldObj.(*controlplanev1alpha1.KinkControlPlane)
+	if !ok {
+		return nil, fmt.Errorf("expected a KinkControlPlane object for the oldObj but got %T", oldObj)
+	}
 	kinkcontrolplane, ok := newObj.(*controlplanev1alpha1.KinkControlPlane)
 	if !ok {
 		return nil, fmt.Errorf("expected a KinkControlPlane object for the newObj but got %T", newObj)
 	}
 	log.Info("Validation for KinkControlPlane upon update", "name", kinkcontrolplane.GetName())
 
-	// TODO(user): fill in your validation logic upon object update.
-
-	return nil, validate(kinkcontrolplane.Spec)
+	path := field.NewPath("spec")
+	errs := validate(path, kinkcontrolplane.Spec, validationOptions{
+		name:                kinkcontrolplane.Name,
+		sniRouterBaseDomain: v.SNIRouterBaseDomain,
+	})
+	errs = append(errs, validateUpdate(path, oldKinkControlPlane.Spec, kinkcontrolplane.Spec)...)
+	return nil, invalid("KinkControlPlane", kinkcontrolplane.GetName(), errs)
 }
 
 // ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type KinkControlPlane.
@@ -127,10 +164,573 @@ func (v *KinkControlPlaneCustomValidator) ValidateDelete(
 	return nil, nil
 }
 
+// invalid returns the validation errors of the object as an Invalid status error, or nil when
+// there are none.
+func invalid(kind, name string, errs field.ErrorList) error {
+	if len(errs) == 0 {
+		return nil
+	}
+	return apierrors.NewInvalid(controlplanev1alpha1.GroupVersion.WithKind(kind).GroupKind(), name, errs)
+}
+
+// validationOptions carries the configuration of the operator the control planes are validated
+// against.
+type validationOptions struct {
+	// name is the name of the control plane, empty for templates, whose control planes are named
+	// only when instantiated.
+	name string
+
+	// sniRouterBaseDomain is the domain under which the hosts of the control planes exposed
+	// through the SNI router are assigned, empty when the SNI router is not enabled.
+	sniRouterBaseDomain string
+}
+
+// validate checks the spec of a control plane, rooted at path so that the same rules apply to
+// the KinkControlPlane and to the template of the KinkControlPlaneTemplate.
 func validate(
+	path *field.Path,
 	kinkCP controlplanev1alpha1.KinkControlPlaneSpec,
-) error {
-	// TODO: validate mutual exclusive fields:
-	// - .spec.controlPlaneEndpoint.ingress || .spec.controlPlaneEndpoint.gateway
+	opts validationOptions,
+) field.ErrorList {
+	var errs field.ErrorList
+
+	errs = append(errs, validateEndpoint(path.Child("controlPlaneEndpoint"), kinkCP.ControlPlaneEndpoint, opts)...)
+
+	if certs := kinkCP.Certificates; certs != nil {
+		certsPath := path.Child("certificates")
+		if certs.IssuerRef != nil && certs.CASecretRef != nil {
+			errs = append(errs, field.Forbidden(certsPath.Child("caSecretRef"), "may not be set together with issuerRef"))
+		}
+		if certs.IssuerRef != nil && certs.IssuerRef.Name == "" {
+			errs = append(errs, field.Required(certsPath.Child("issuerRef", "name"), ""))
+		}
+		if certs.CASecretRef != nil && certs.CASecretRef.Name == "" {
+			errs = append(errs, field.Required(certsPath.Child("caSecretRef", "name"), ""))
+		}
+		errs = append(errs, validateCertificateProfile(certsPath.Child("ca"), certs.CA,
+			controlplane.DefaultCADuration)...)
+		errs = append(errs, validateCertificateProfile(certsPath.Child("leaf"), certs.Leaf,
+			controlplane.DefaultLeafDuration)...)
+
+		caDuration := certificateDuration(certs.CA, controlplane.DefaultCADuration)
+		if leafDuration := certificateDuration(certs.Leaf, controlplane.DefaultLeafDuration); caDuration < leafDuration {
+			errs = append(errs, field.Invalid(certsPath.Child("ca", "duration"), caDuration.String(),
+				fmt.Sprintf("must not be shorter than the duration of the leaf certificates (%s)", leafDuration)))
+		}
+	}
+
+	if hibernation := kinkCP.Hibernation; hibernation != nil && (hibernation.Enabled || len(hibernation.Schedules) > 0) {
+		if !durablePersistence(kinkCP.Kine.Persistence) {
+			errs = append(errs, field.Forbidden(path.Child("hibernation"), "requires kine.persistence to be "+
+				"persistentVolumeClaim or persistentVolumeClaimTemplate, the datastore is lost when Kine is scaled to zero"))
+		}
+	}
+
+	if remediation := kinkCP.Remediation; remediation != nil {
+		// Only the API server connects to Kine, restarting Kine does not heal the other components.
+		for _, policy := range []struct {
+			field  string
+			policy *controlplanev1alpha1.RemediationPolicy
+		}{
+			{field: "scheduler", policy: remediation.Scheduler},
+			{field: "controllerManager", policy: remediation.ControllerManager},
+		} {
+			if policy.policy != nil && policy.policy.Action == controlplanev1alpha1.RemediationActionRestartKine {
+				errs = append(errs, field.NotSupported(path.Child("remediation", policy.field, "action"),
+					policy.policy.Action, []controlplanev1alpha1.RemediationAction{
+						controlplanev1alpha1.RemediationActionDeletePod,
+						controlplanev1alpha1.RemediationActionRestartRollout,
+					}))
+			}
+		}
+	}
+
+	if networkPolicy := kinkCP.NetworkPolicy; networkPolicy != nil {
+		for i, cidr := range networkPolicy.APIServerCIDRs {
+			if _, _, err := net.ParseCIDR(cidr); err != nil {
+				errs = append(errs, field.Invalid(path.Child("networkPolicy", "apiServerCIDRs").Index(i), cidr,
+					"must be a CIDR"))
+			}
+		}
+	}
+
+	// The service account flags are repeated to keep the previous keys and issuers valid, so
+	// they are set from apiServer.serviceAccount only.
+	for _, arg := range []string{"service-account-issuer", "service-account-key-file"} {
+		if _, ok := kinkCP.APIServer.ExtraArgs[arg]; ok {
+			errs = append(errs, field.Forbidden(path.Child("apiServer", "extraArgs").Key(arg),
+				"is managed by the operator, configure apiServer.serviceAccount instead"))
+		}
+	}
+
+	kcp := &controlplanev1alpha1.KinkControlPlane{ObjectMeta: metav1.ObjectMeta{Name: opts.name}, Spec: kinkCP}
+	for _, component := range []struct {
+		field     string
+		name      string
+		container string
+		extras    kinkcorev1alpha1.PodExtras
+	}{
+		{
+			field:     "apiServer",
+			name:      controlplane.ComponentAPIServer,
+			container: naming.APIServerContainer(),
+			extras:    kinkCP.APIServer.PodExtras,
+		},
+		{
+			field:     "controllerManager",
+			name:      controlplane.ComponentControllerManager,
+			container: naming.ControllerManagerContainer(),
+			extras:    kinkCP.ControllerManager.PodExtras,
+		},
+		{
+			field:     "scheduler",
+			name:      controlplane.ComponentScheduler,
+			container: naming.SchedulerContainer(),
+			extras:    kinkCP.Scheduler.PodExtras,
+		},
+		{
+			field:     "kine",
+			name:      controlplane.ComponentKine,
+			container: naming.KineContainer(),
+			extras:    kinkCP.Kine.PodExtras,
+		},
+	} {
+		volumes, mounts := controlplane.ManagedVolumes(kcp, component.name)
+		errs = append(errs, validatePodExtras(path.Child(component.field), component.container,
+			volumes, mounts, component.extras)...)
+	}
+
+	for i, patch := range kinkCP.Patches {
+		errs = append(errs, validatePatch(path.Child("patches").Index(i), patch)...)
+	}
+
+	managedSecrets := managedSecretNames(opts.name, kinkCP)
+	for i, kubeconfig := range kinkCP.Kubeconfigs {
+		errs = append(errs, validateKubeconfig(path.Child("kubeconfigs").Index(i), kubeconfig, managedSecrets)...)
+	}
+
+	return errs
+}
+
+// validateUpdate rejects the changes which would break the running control plane.
+func validateUpdate(path *field.Path, oldSpec, newSpec controlplanev1alpha1.KinkControlPlaneSpec) field.ErrorList {
+	var errs field.ErrorList
+
+	// Changing the class recreates the Service, which gets another load balancer address, while
+	// the host of the endpoint cannot change once set.
+	if oldSpec.ControlPlaneEndpoint.Host != "" &&
+		!ptr.Equal(loadBalancerClass(oldSpec.ControlPlaneEndpoint), loadBalancerClass(newSpec.ControlPlaneEndpoint)) {
+		errs = append(errs, field.Forbidden(path.Child("controlPlaneEndpoint", "service", "loadBalancerClass"),
+			"may not be changed once the host of the endpoint is set"))
+	}
+
+	return errs
+}
+
+// loadBalancerClass returns the class of the load balancer of the Service, if any.
+func loadBalancerClass(endpoint controlplanev1alpha1.APIEndpoint) *string {
+	if endpoint.Service == nil {
+		return nil
+	}
+	return endpoint.Service.LoadBalancerClass
+}
+
+// durablePersistence reports whether the datastore of Kine outlives its pod, following the
+// precedence of the volume sources of the Kine Deployment.
+func durablePersistence(persistence *kinkcorev1alpha1.Persistence) bool {
+	if persistence == nil || persistence.EmptyDir != nil || persistence.Ephemeral != nil || persistence.HostPath != nil {
+		return false
+	}
+	return persistence.PersistentVolumeClaim != nil || persistence.PersistentVolumeClaimTemplate != nil
+}
+
+// supportedServiceTypes lists the Service types the API server can be exposed with.
+var supportedServiceTypes = []corev1.ServiceType{
+	corev1.ServiceTypeClusterIP,
+	corev1.ServiceTypeNodePort,
+	corev1.ServiceTypeLoadBalancer,
+}
+
+// validateEndpoint checks that the API server is exposed through a single path, consistent with
+// the Service type, and that the host and the port set or resolved for it are well-formed.
+func validateEndpoint(
+	path *field.Path,
+	endpoint controlplanev1alpha1.APIEndpoint,
+	opts validationOptions,
+) field.ErrorList {
+	var errs field.ErrorList
+
+	// exposedBy is the field exposing the API server instead of its Service, if any.
+	exposedBy := ""
+	for _, exposure := range []struct {
+		field string
+		set   bool
+	}{
+		{field: "gateway", set: endpoint.Gateway != nil},
+		{field: "ingress", set: endpoint.Ingress != nil},
+		{field: "sniRouter", set: endpoint.SNIRouter != nil},
+	} {
+		switch {
+		case !exposure.set:
+		case exposedBy == "":
+			exposedBy = exposure.field
+		default:
+			errs = append(errs, field.Forbidden(path.Child(exposure.field),
+				fmt.Sprintf("may not be set together with %s", exposedBy)))
+		}
+	}
+
+	if host := string(endpoint.Host); host != "" && net.ParseIP(host) == nil {
+		if msgs := validation.IsDNS1123Subdomain(host); len(msgs) > 0 {
+			errs = append(errs, field.Invalid(path.Child("host"), host,
+				"must be an IP address or a DNS name: "+strings.Join(msgs, ", ")))
+		}
+	}
+
+	// The hosts outside of the base domain are not covered by its wildcard DNS record, and could
+	// claim the hosts of other services routed by the SNI router.
+	if endpoint.SNIRouter != nil && endpoint.Host != "" && opts.sniRouterBaseDomain != "" {
+		label, ok := strings.CutSuffix(string(endpoint.Host), "."+opts.sniRouterBaseDomain)
+		if !ok || label == "" || strings.Contains(label, ".") {
+			errs = append(errs, field.Invalid(path.Child("host"), endpoint.Host,
+				fmt.Sprintf("must be a single label under %s when sniRouter is set", opts.sniRouterBaseDomain)))
+		}
+	}
+
+	if endpoint.Port != 0 {
+		for _, msg := range validation.IsValidPortNum(int(endpoint.Port)) {
+			errs = append(errs, field.Invalid(path.Child("port"), endpoint.Port, msg))
+		}
+	}
+	if endpoint.SNIRouter != nil && endpoint.Port != 0 && endpoint.Port != snirouter.Port {
+		errs = append(errs, field.Invalid(path.Child("port"), endpoint.Port,
+			fmt.Sprintf("must be %d when sniRouter is set", snirouter.Port)))
+	}
+
+	serviceTypePath := path.Child("serviceType")
+	switch endpoint.ServiceType {
+	case "", corev1.ServiceTypeLoadBalancer:
+	case corev1.ServiceTypeNodePort:
+		if exposedBy != "" {
+			errs = append(errs, field.Invalid(serviceTypePath, endpoint.ServiceType,
+				fmt.Sprintf("must not be NodePort when %s is set", exposedBy)))
+		}
+	case corev1.ServiceTypeClusterIP:
+		// A ClusterIP Service is only reachable from the management cluster, so the endpoint cannot
+		// be resolved from it.
+		if exposedBy == "" && (endpoint.Host == "" || endpoint.Port == 0) {
+			errs = append(errs, field.Invalid(serviceTypePath, endpoint.ServiceType,
+				"requires one of gateway, ingress or sniRouter, or both the host and the port to be set"))
+		}
+	default:
+		errs = append(errs, field.NotSupported(serviceTypePath, endpoint.ServiceType, supportedServiceTypes))
+	}
+
+	if endpoint.NodePort != nil && (exposedBy != "" || endpoint.ServiceType != corev1.ServiceTypeNodePort) {
+		errs = append(errs, field.Forbidden(path.Child("nodePort"),
+			"may only be set when the API server is exposed by a NodePort Service"))
+	}
+
+	if endpoint.Gateway != nil {
+		errs = append(errs, validateGateway(path.Child("gateway"), endpoint.Gateway)...)
+	}
+
+	if endpoint.Service != nil {
+		errs = append(errs, validateService(path.Child("service"), endpoint)...)
+	}
+
+	return errs
+}
+
+func validateKubeconfig(
+	path *field.Path,
+	kubeconfig controlplanev1alpha1.Kubeconfig,
+	managedSecrets sets.Set[string],
+) field.ErrorList {
+	var errs field.ErrorList
+
+	if managedSecrets.Has(kubeconfig.SecretName) {
+		errs = append(errs, field.Invalid(path.Child("secretName"), kubeconfig.SecretName,
+			"must not be the name of a Secret managed by the operator"))
+	}
+
+	custom := kubeconfig.Endpoint == controlplanev1alpha1.KubeconfigEndpointCustom
+	if custom && kubeconfig.Server == "" {
+		errs = append(errs, field.Required(path.Child("server"), "must be set when the endpoint is Custom"))
+	} else if custom {
+		if server, err := url.Parse(kubeconfig.Server); err != nil || server.Scheme != "https" || server.Host == "" {
+			errs = append(errs, field.Invalid(path.Child("server"), kubeconfig.Server, "must be an absolute https URL"))
+		}
+	}
+	if !custom && kubeconfig.Server != "" {
+		errs = append(errs, field.Forbidden(path.Child("server"), "may only be set when the endpoint is Custom"))
+	}
+	if kubeconfig.Duration != nil && kubeconfig.Duration.Duration < minCertificateDuration {
+		errs = append(errs, field.Invalid(path.Child("duration"), kubeconfig.Duration.Duration.String(),
+			fmt.Sprintf("must be at least %s", minCertificateDuration)))
+	}
+
+	return errs
+}
+
+// managedSecretNames returns the names of the Secrets the operator manages for the control plane,
+// which the user kubeconfigs must not overwrite. The names depend on the name of the control
+// plane, so only the provided CA Secret is known for templates.
+func managedSecretNames(name string, kinkCP controlplanev1alpha1.KinkControlPlaneSpec) sets.Set[string] {
+	names := sets.New[string]()
+	if certs := kinkCP.Certificates; certs != nil && certs.CASecretRef != nil {
+		names.Insert(certs.CASecretRef.Name)
+	}
+	if name == "" {
+		return names
+	}
+
+	for _, kubeconfig := range kinkCP.Kubeconfigs {
+		names.Insert(naming.UserCertificate(name, kubeconfig.SecretName))
+	}
+
+	return names.Insert(
+		naming.Kubeconfig(name),
+		naming.Kubeconfig(naming.Scheduler(name)),
+		naming.Kubeconfig(naming.ControllerManager(name)),
+		naming.ClusterCA(name),
+		naming.AdminCertificate(name),
+		naming.APIServerCertificate(name),
+		naming.ControllerManagerCertificate(name),
+		naming.SchedulerCertificate(name),
+		naming.KubeletClientCertificate(name),
+		naming.MetricsClientCertificate(name),
+		naming.KineCA(name),
+		naming.KineServerCertificate(name),
+		naming.KineAPIServerClientCertificate(name),
+		naming.FrontProxyCA(name),
+		naming.ServiceAccountCertificate(name),
+		naming.ServiceAccountKeys(name),
+		naming.ServiceAccountSigningKey(name),
+		naming.TrustBundle(name),
+	)
+}
+
+func validateGateway(
+	path *field.Path,
+	gateway *controlplanev1alpha1.Gateway,
+) field.ErrorList {
+	switch {
+	case gateway.GatewayClassName != "" && len(gateway.ParentRefs) > 0:
+		return field.ErrorList{
+			field.Forbidden(path.Child("parentRefs"), "may not be set together with gatewayClassName"),
+		}
+	case gateway.GatewayClassName == "" && len(gateway.ParentRefs) == 0:
+		return field.ErrorList{
+			field.Required(path, "one of gatewayClassName or parentRefs must be set"),
+		}
+	}
 	return nil
 }
+
+// validateService rejects the fields of the Service which would be dropped for its type. The
+// Service of a control plane exposed through the SNI router is always of type ClusterIP.
+func validateService(
+	path *field.Path,
+	endpoint controlplanev1alpha1.APIEndpoint,
+) field.ErrorList {
+	var errs field.ErrorList
+
+	service := endpoint.Service
+	serviceType := cmp.Or(endpoint.ServiceType, corev1.ServiceTypeLoadBalancer)
+	if endpoint.SNIRouter != nil {
+		serviceType = corev1.ServiceTypeClusterIP
+	}
+
+	if serviceType != corev1.ServiceTypeLoadBalancer {
+		const detail = "may only be set when the Service type is LoadBalancer"
+		if service.LoadBalancerClass != nil {
+			errs = append(errs, field.Forbidden(path.Child("loadBalancerClass"), detail))
+		}
+		if len(service.LoadBalancerSourceRanges) > 0 {
+			errs = append(errs, field.Forbidden(path.Child("loadBalancerSourceRanges"), detail))
+		}
+		if service.LoadBalancerIP != "" {
+			errs = append(errs, field.Forbidden(path.Child("loadBalancerIP"), detail))
+		}
+	}
+	if serviceType == corev1.ServiceTypeClusterIP && service.ExternalTrafficPolicy != "" {
+		errs = append(errs, field.Forbidden(path.Child("externalTrafficPolicy"),
+			"may only be set when the Service type is NodePort or LoadBalancer"))
+	}
+
+	for i, cidr := range service.LoadBalancerSourceRanges {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			errs = append(errs, field.Invalid(path.Child("loadBalancerSourceRanges").Index(i), cidr, "must be a CIDR"))
+		}
+	}
+	if service.LoadBalancerIP != "" && net.ParseIP(service.LoadBalancerIP) == nil {
+		errs = append(errs, field.Invalid(path.Child("loadBalancerIP"), service.LoadBalancerIP,
+			"must be an IP address"))
+	}
+
+	return errs
+}
+
+// validatePodExtras rejects the extra containers and volumes whose names collide, as their
+// schemas are not part of the CRD, and the extra mounts overlapping the mounts of the operator.
+func validatePodExtras(
+	path *field.Path,
+	container string,
+	managedVolumes []corev1.Volume,
+	managedMounts []corev1.VolumeMount,
+	extras kinkcorev1alpha1.PodExtras,
+) field.ErrorList {
+	var errs field.ErrorList
+
+	containers := map[string]bool{container: true}
+	for _, list := range []struct {
+		field      string
+		containers []corev1.Container
+	}{
+		{field: "extraContainers", containers: extras.ExtraContainers},
+		{field: "extraInitContainers", containers: extras.ExtraInitContainers},
+	} {
+		for i, c := range list.containers {
+			namePath := path.Child(list.field).Index(i).Child("name")
+			switch {
+			case c.Name == "":
+				errs = append(errs, field.Required(namePath, ""))
+			case containers[c.Name]:
+				errs = append(errs, field.Duplicate(namePath, c.Name))
+			}
+			containers[c.Name] = true
+		}
+	}
+
+	volumes := map[string]bool{}
+	for _, v := range managedVolumes {
+		volumes[v.Name] = true
+	}
+	for i, v := range extras.ExtraVolumes {
+		namePath := path.Child("extraVolumes").Index(i).Child("name")
+		switch {
+		case v.Name == "":
+			errs = append(errs, field.Required(namePath, ""))
+		case volumes[v.Name]:
+			errs = append(errs, field.Duplicate(namePath, v.Name))
+		}
+		volumes[v.Name] = true
+	}
+
+	mounts := slices.Clone(managedMounts)
+	for i, m := range extras.ExtraVolumeMounts {
+		mountPath := path.Child("extraVolumeMounts").Index(i).Child("mountPath")
+		if m.MountPath == "" {
+			errs = append(errs, field.Required(mountPath, ""))
+			continue
+		}
+		for _, other := range mounts {
+			if overlappingPaths(m.MountPath, other.MountPath) {
+				errs = append(errs, field.Invalid(mountPath, m.MountPath,
+					fmt.Sprintf("must not overlap the mount path %s of the volume %s", other.MountPath, other.Name)))
+				break
+			}
+		}
+		mounts = append(mounts, m)
+	}
+
+	return errs
+}
+
+// overlappingPaths reports whether one of the mount paths is the other one or contains it, in
+// which case one of the mounts shadows the other.
+func overlappingPaths(a, b string) bool {
+	a, b = path.Clean(a), path.Clean(b)
+	return a == b || strings.HasPrefix(a, strings.TrimSuffix(b, "/")+"/") ||
+		strings.HasPrefix(b, strings.TrimSuffix(a, "/")+"/")
+}
+
+// validatePatch rejects the patches which cannot be decoded. Whether a patch applies to the
+// generated objects is only known when reconciling the control plane.
+func validatePatch(
+	path *field.Path,
+	patch controlplanev1alpha1.Patch,
+) field.ErrorList {
+	patchPath := path.Child("patch")
+
+	data, err := yaml.YAMLToJSON([]byte(patch.Patch))
+	if err != nil {
+		return field.ErrorList{field.Invalid(patchPath, patch.Patch, "must be valid YAML or JSON: "+err.Error())}
+	}
+
+	switch patch.Type {
+	case controlplanev1alpha1.JSON6902PatchType:
+		if _, err := jsonpatch.DecodePatch(data); err != nil {
+			return field.ErrorList{
+				field.Invalid(patchPath, patch.Patch, "must be a list of JSON patch operations: "+err.Error()),
+			}
+		}
+	default:
+		var object map[string]any
+		if err := json.Unmarshal(data, &object); err != nil {
+			return field.ErrorList{field.Invalid(patchPath, patch.Patch, "must be an object: "+err.Error())}
+		}
+	}
+	return nil
+}
+
+// certificateDuration returns the lifetime of the certificates of the profile, which defaults to
+// the given duration.
+func certificateDuration(
+	profile *controlplanev1alpha1.CertificateProfile,
+	defaultDuration time.Duration,
+) time.Duration {
+	if profile == nil || profile.Duration == nil {
+		return defaultDuration
+	}
+	return profile.Duration.Duration
+}
+
+// minCertificateDuration is the minimum lifetime of a certificate accepted by cert-manager.
+const minCertificateDuration = time.Hour
+
+// allowedKeySizes lists the accepted private key sizes for each algorithm.
+var allowedKeySizes = map[controlplanev1alpha1.PrivateKeyAlgorithm][]int32{
+	controlplanev1alpha1.RSAKeyAlgorithm:   {2048, 3072, 4096},
+	controlplanev1alpha1.ECDSAKeyAlgorithm: {256, 384, 521},
+}
+
+func validateCertificateProfile(
+	path *field.Path,
+	profile *controlplanev1alpha1.CertificateProfile,
+	defaultDuration time.Duration,
+) field.ErrorList {
+	if profile == nil {
+		return nil
+	}
+
+	var errs field.ErrorList
+
+	if profile.Duration != nil && profile.Duration.Duration < minCertificateDuration {
+		errs = append(errs, field.Invalid(path.Child("duration"), profile.Duration.Duration.String(),
+			fmt.Sprintf("must be at least %s", minCertificateDuration)))
+	}
+	if profile.RenewBefore != nil && profile.RenewBefore.Duration <= 0 {
+		errs = append(errs, field.Invalid(path.Child("renewBefore"), profile.RenewBefore.Duration.String(),
+			"must be positive"))
+	}
+	if duration := certificateDuration(profile, defaultDuration); profile.RenewBefore != nil &&
+		profile.RenewBefore.Duration >= duration {
+		errs = append(errs, field.Invalid(path.Child("renewBefore"), profile.RenewBefore.Duration.String(),
+			fmt.Sprintf("must be shorter than duration (%s)", duration)))
+	}
+
+	if key := profile.PrivateKey; key != nil && key.Size != 0 {
+		algorithm := key.Algorithm
+		if algorithm == "" {
+			algorithm = controlplanev1alpha1.RSAKeyAlgorithm
+		}
+		if sizes := allowedKeySizes[algorithm]; !slices.Contains(sizes, key.Size) {
+			errs = append(errs, field.Invalid(path.Child("privateKey", "size"), key.Size,
+				fmt.Sprintf("must be one of %v for %s keys", sizes, algorithm)))
+		}
+	}
+
+	return errs
+}
@@ -13,3 +13,740 @@
 // limitations under the License.
 
 package v1alpha1
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	controlplanev1alpha1 "github.com/anza-labs/kink/api/controlplane/v1alpha1"
+	kinkcorev1alpha1 "github.com/anza-labs/kink/api/core/v1alpha1"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/ptr"
+)
+
+func TestValidate(t *testing.T) {
+	t.Parallel()
+
+	day := 24 * time.Hour
+
+	for name, tc := range map[string]struct {
+		spec          controlplanev1alpha1.KinkControlPlaneSpec
+		expectedError bool
+	}{
+		"Empty": {
+			spec: controlplanev1alpha1.KinkControlPlaneSpec{},
+		},
+		"IssuerRefAndCASecretRef": {
+			spec: controlplanev1alpha1.KinkControlPlaneSpec{
+				Certificates: &controlplanev1alpha1.Certificates{
+					IssuerRef:   &controlplanev1alpha1.IssuerReference{Name: "vault"},
+					CASecretRef: &corev1.LocalObjectReference{Name: "ca"},
+				},
+			},
+			expectedError: true,
+		},
+		"ValidProfiles": {
+			spec: controlplanev1alpha1.KinkControlPlaneSpec{
+				Certificates: &controlplanev1alpha1.Certificates{
+					CA: &controlplanev1alpha1.CertificateProfile{
+						PrivateKey: &controlplanev1alpha1.PrivateKey{
+							Algorithm: controlplanev1alpha1.RSAKeyAlgorithm,
+							Size:      4096,
+						},
+					},
+					Leaf: &controlplanev1alpha1.CertificateProfile{
+						Duration:    &metav1.Duration{Duration: 90 * day},
+						RenewBefore: &metav1.Duration{Duration: 30 * day},
+						PrivateKey: &controlplanev1alpha1.PrivateKey{
+							Algorithm: controlplanev1alpha1.ECDSAKeyAlgorithm,
+							Size:      256,
+						},
+					},
+				},
+			},
+		},
+		"InvalidKeySize": {
+			spec: controlplanev1alpha1.KinkControlPlaneSpec{
+				Certificates: &controlplanev1alpha1.Certificates{
+					Leaf: &controlplanev1alpha1.CertificateProfile{
+						PrivateKey: &controlplanev1alpha1.PrivateKey{
+							Algorithm: controlplanev1alpha1.ECDSAKeyAlgorithm,
+							Size:      2048,
+						},
+					},
+				},
+			},
+			expectedError: true,
+		},
+		"RenewBeforeLongerThanDuration": {
+			spec: controlplanev1alpha1.KinkControlPlaneSpec{
+				Certificates: &controlplanev1alpha1.Certificates{
+					Leaf: &controlplanev1alpha1.CertificateProfile{
+						Duration:    &metav1.Duration{Duration: 30 * day},
+						RenewBefore: &metav1.Duration{Duration: 60 * day},
+					},
+				},
+			},
+			expectedError: true,
+		},
+		"RenewBeforeLongerThanDefaultDuration": {
+			spec: controlplanev1alpha1.KinkControlPlaneSpec{
+				Certificates: &controlplanev1alpha1.Certificates{
+					Leaf: &controlplanev1alpha1.CertificateProfile{
+						RenewBefore: &metav1.Duration{Duration: 400 * day},
+					},
+				},
+			},
+			expectedError: true,
+		},
+		"CADurationShorterThanLeafDuration": {
+			spec: controlplanev1alpha1.KinkControlPlaneSpec{
+				Certificates: &controlplanev1alpha1.Certificates{
+					CA: &controlplanev1alpha1.CertificateProfile{
+						Duration: &metav1.Duration{Duration: 90 * day},
+					},
+					Leaf: &controlplanev1alpha1.CertificateProfile{
+						Duration: &metav1.Duration{Duration: 180 * day},
+					},
+				},
+			},
+			expectedError: true,
+		},
+		"CADurationShorterThanDefaultLeafDuration": {
+			spec: controlplanev1alpha1.KinkControlPlaneSpec{
+				Certificates: &controlplanev1alpha1.Certificates{
+					CA: &controlplanev1alpha1.CertificateProfile{
+						Duration: &metav1.Duration{Duration: 180 * day},
+					},
+				},
+			},
+			expectedError: true,
+		},
+		"DurationTooShort": {
+			spec: controlplanev1alpha1.KinkControlPlaneSpec{
+				Certificates: &controlplanev1alpha1.Certificates{
+					CA: &controlplanev1alpha1.CertificateProfile{
+						Duration: &metav1.Duration{Duration: time.Minute},
+					},
+				},
+			},
+			expectedError: true,
+		},
+		"ValidKubeconfigs": {
+			spec: controlplanev1alpha1.KinkControlPlaneSpec{
+				Kubeconfigs: []controlplanev1alpha1.Kubeconfig{
+					{SecretName: "ci", Username: "ci", Duration: &metav1.Duration{Duration: day}},
+					{
+						SecretName: "dashboard",
+						Username:   "dashboard",
+						Endpoint:   controlplanev1alpha1.KubeconfigEndpointCustom,
+						Server:     "https://proxy.example.com",
+					},
+				},
+			},
+		},
+		"CustomKubeconfigWithoutServer": {
+			spec: controlplanev1alpha1.KinkControlPlaneSpec{
+				Kubeconfigs: []controlplanev1alpha1.Kubeconfig{
+					{SecretName: "ci", Username: "ci", Endpoint: controlplanev1alpha1.KubeconfigEndpointCustom},
+				},
+			},
+			expectedError: true,
+		},
+		"PublicKubeconfigWithServer": {
+			spec: controlplanev1alpha1.KinkControlPlaneSpec{
+				Kubeconfigs: []controlplanev1alpha1.Kubeconfig{
+					{SecretName: "ci", Username: "ci", Server: "https://proxy.example.com"},
+				},
+			},
+			expectedError: true,
+		},
+		"KubeconfigWithOperatorSecretName": {
+			spec: controlplanev1alpha1.KinkControlPlaneSpec{
+				Kubeconfigs: []controlplanev1alpha1.Kubeconfig{
+					{SecretName: "test-kubeconfig", Username: "ci"},
+				},
+			},
+			expectedError: true,
+		},
+		"KubeconfigWithServiceAccountKeysSecretName": {
+			spec: controlplanev1alpha1.KinkControlPlaneSpec{
+				Kubeconfigs: []controlplanev1alpha1.Kubeconfig{
+					{SecretName: "test-sa-keys", Username: "ci"},
+				},
+			},
+			expectedError: true,
+		},
+		"KubeconfigWithCASecretName": {
+			spec: controlplanev1alpha1.KinkControlPlaneSpec{
+				Certificates: &controlplanev1alpha1.Certificates{
+					CASecretRef: &corev1.LocalObjectReference{Name: "ca"},
+				},
+				Kubeconfigs: []controlplanev1alpha1.Kubeconfig{
+					{SecretName: "ca", Username: "ci"},
+				},
+			},
+			expectedError: true,
+		},
+		"KubeconfigWithClientCertificateSecretName": {
+			spec: controlplanev1alpha1.KinkControlPlaneSpec{
+				Kubeconfigs: []controlplanev1alpha1.Kubeconfig{
+					{SecretName: "ci", Username: "ci"},
+					{SecretName: "test-user-ci", Username: "dashboard"},
+				},
+			},
+			expectedError: true,
+		},
+		"CustomKubeconfigWithHTTPServer": {
+			spec: controlplanev1alpha1.KinkControlPlaneSpec{
+				Kubeconfigs: []controlplanev1alpha1.Kubeconfig{
+					{
+						SecretName: "ci",
+						Username:   "ci",
+						Endpoint:   controlplanev1alpha1.KubeconfigEndpointCustom,
+						Server:     "http://proxy.example.com",
+					},
+				},
+			},
+			expectedError: true,
+		},
+		"CustomKubeconfigWithRelativeServer": {
+			spec: controlplanev1alpha1.KinkControlPlaneSpec{
+				Kubeconfigs: []controlplanev1alpha1.Kubeconfig{
+					{
+						SecretName: "ci",
+						Username:   "ci",
+						Endpoint:   controlplanev1alpha1.KubeconfigEndpointCustom,
+						Server:     "proxy.example.com:6443",
+					},
+				},
+			},
+			expectedError: true,
+		},
+		"SharedGateway": {
+			spec: controlplanev1alpha1.KinkControlPlaneSpec{
+				ControlPlaneEndpoint: controlplanev1alpha1.APIEndpoint{
+					Gateway: &controlplanev1alpha1.Gateway{
+						ParentRefs: []controlplanev1alpha1.GatewayParentReference{{Name: "shared"}},
+					},
+				},
+			},
+		},
+		"GatewayClassAndParentRefs": {
+			spec: controlplanev1alpha1.KinkControlPlaneSpec{
+				ControlPlaneEndpoint: controlplanev1alpha1.APIEndpoint{
+					Gateway: &controlplanev1alpha1.Gateway{
+						GatewayClassName: "test",
+						ParentRefs:       []controlplanev1alpha1.GatewayParentReference{{Name: "shared"}},
+					},
+				},
+			},
+			expectedError: true,
+		},
+		"SNIRouter": {
+			spec: controlplanev1alpha1.KinkControlPlaneSpec{
+				ControlPlaneEndpoint: controlplanev1alpha1.APIEndpoint{
+					SNIRouter: &controlplanev1alpha1.SNIRouter{},
+				},
+			},
+		},
+		"SNIRouterAndIngress": {
+			spec: controlplanev1alpha1.KinkControlPlaneSpec{
+				ControlPlaneEndpoint: controlplanev1alpha1.APIEndpoint{
+					SNIRouter: &controlplanev1alpha1.SNIRouter{},
+					Ingress:   &controlplanev1alpha1.Ingress{IngressClassName: "test"},
+				},
+			},
+			expectedError: true,
+		},
+		"LoadBalancerService": {
+			spec: controlplanev1alpha1.KinkControlPlaneSpec{
+				ControlPlaneEndpoint: controlplanev1alpha1.APIEndpoint{
+					ServiceType: corev1.ServiceTypeLoadBalancer,
+					Service: &controlplanev1alpha1.Service{
+						LoadBalancerClass:        ptr.To("example.com/internal"),
+						LoadBalancerSourceRanges: []string{"10.0.0.0/8", "fd00::/8"},
+						LoadBalancerIP:           "10.0.0.1",
+						ExternalTrafficPolicy:    corev1.ServiceExternalTrafficPolicyLocal,
+					},
+				},
+			},
+		},
+		"InvalidSourceRange": {
+			spec: controlplanev1alpha1.KinkControlPlaneSpec{
+				ControlPlaneEndpoint: controlplanev1alpha1.APIEndpoint{
+					ServiceType: corev1.ServiceTypeLoadBalancer,
+					Service: &controlplanev1alpha1.Service{
+						LoadBalancerSourceRanges: []string{"10.0.0.1"},
+					},
+				},
+			},
+			expectedError: true,
+		},
+		"LoadBalancerFieldsOnNodePort": {
+			spec: controlplanev1alpha1.KinkControlPlaneSpec{
+				ControlPlaneEndpoint: controlplanev1alpha1.APIEndpoint{
+					ServiceType: corev1.ServiceTypeNodePort,
+					Service: &controlplanev1alpha1.Service{
+						LoadBalancerClass: ptr.To("example.com/internal"),
+					},
+				},
+			},
+			expectedError: true,
+		},
+		"ExternalTrafficPolicyWithSNIRouter": {
+			spec: controlplanev1alpha1.KinkControlPlaneSpec{
+				ControlPlaneEndpoint: controlplanev1alpha1.APIEndpoint{
+					SNIRouter: &controlplanev1alpha1.SNIRouter{},
+					Service: &controlplanev1alpha1.Service{
+						ExternalTrafficPolicy: corev1.ServiceExternalTrafficPolicyLocal,
+					},
+				},
+			},
+			expectedError: true,
+		},
+		"NetworkPolicy": {
+			spec: controlplanev1alpha1.KinkControlPlaneSpec{
+				NetworkPolicy: &controlplanev1alpha1.NetworkPolicy{
+					Enabled:        true,
+					APIServerCIDRs: []string{"192.0.2.0/24"},
+				},
+			},
+		},
+		"InvalidNetworkPolicyCIDR": {
+			spec: controlplanev1alpha1.KinkControlPlaneSpec{
+				NetworkPolicy: &controlplanev1alpha1.NetworkPolicy{
+					Enabled:        true,
+					APIServerCIDRs: []string{"192.0.2.300/24"},
+				},
+			},
+			expectedError: true,
+		},
+		"PodExtras": {
+			spec: controlplanev1alpha1.KinkControlPlaneSpec{
+				APIServer: controlplanev1alpha1.APIServer{
+					KubeComponent: controlplanev1alpha1.KubeComponent{
+						PodExtras: kinkcorev1alpha1.PodExtras{
+							ExtraVolumes:        []corev1.Volume{{Name: "kms"}},
+							ExtraVolumeMounts:   []corev1.VolumeMount{{Name: "kms", MountPath: "/var/run/kmsplugin"}},
+							ExtraContainers:     []corev1.Container{{Name: "kms-plugin"}},
+							ExtraInitContainers: []corev1.Container{{Name: "init"}},
+						},
+					},
+				},
+			},
+		},
+		"ServiceAccountIssuerExtraArg": {
+			spec: controlplanev1alpha1.KinkControlPlaneSpec{
+				APIServer: controlplanev1alpha1.APIServer{
+					KubeComponent: controlplanev1alpha1.KubeComponent{
+						ExtraArgs: map[string]string{"service-account-issuer": "https://issuer.example.com"},
+					},
+				},
+			},
+			expectedError: true,
+		},
+		"ServiceAccountKeyFileExtraArg": {
+			spec: controlplanev1alpha1.KinkControlPlaneSpec{
+				APIServer: controlplanev1alpha1.APIServer{
+					KubeComponent: controlplanev1alpha1.KubeComponent{
+						ExtraArgs: map[string]string{"service-account-key-file": "/etc/keys/sa.pub"},
+					},
+				},
+			},
+			expectedError: true,
+		},
+		"ExtraContainerNameCollision": {
+			spec: controlplanev1alpha1.KinkControlPlaneSpec{
+				Kine: controlplanev1alpha1.Kine{
+					PodExtras: kinkcorev1alpha1.PodExtras{
+						ExtraContainers: []corev1.Container{{Name: "kine"}},
+					},
+				},
+			},
+			expectedError: true,
+		},
+		"DuplicateExtraVolumes": {
+			spec: controlplanev1alpha1.KinkControlPlaneSpec{
+				Scheduler: controlplanev1alpha1.Scheduler{
+					KubeComponent: controlplanev1alpha1.KubeComponent{
+						PodExtras: kinkcorev1alpha1.PodExtras{
+							ExtraVolumes: []corev1.Volume{{Name: "ca"}, {Name: "ca"}},
+						},
+					},
+				},
+			},
+			expectedError: true,
+		},
+		"ExtraVolumeNameCollision": {
+			spec: controlplanev1alpha1.KinkControlPlaneSpec{
+				Kine: controlplanev1alpha1.Kine{
+					PodExtras: kinkcorev1alpha1.PodExtras{
+						ExtraVolumes: []corev1.Volume{{Name: "data"}},
+					},
+				},
+			},
+			expectedError: true,
+		},
+		"ExtraTmpVolume": {
+			spec: controlplanev1alpha1.KinkControlPlaneSpec{
+				Scheduler: controlplanev1alpha1.Scheduler{
+					KubeComponent: controlplanev1alpha1.KubeComponent{
+						PodExtras: kinkcorev1alpha1.PodExtras{
+							ExtraVolumes: []corev1.Volume{{Name: "tmp"}},
+						},
+					},
+				},
+			},
+			expectedError: true,
+		},
+		"ExtraVolumeMountPathCollision": {
+			spec: controlplanev1alpha1.KinkControlPlaneSpec{
+				Kine: controlplanev1alpha1.Kine{
+					PodExtras: kinkcorev1alpha1.PodExtras{
+						ExtraVolumes:      []corev1.Volume{{Name: "certs"}},
+						ExtraVolumeMounts: []corev1.VolumeMount{{Name: "certs", MountPath: "/etc/kine/tls/"}},
+					},
+				},
+			},
+			expectedError: true,
+		},
+		"ExtraVolumeMountShadowingOperatorMount": {
+			spec: controlplanev1alpha1.KinkControlPlaneSpec{
+				APIServer: controlplanev1alpha1.APIServer{
+					KubeComponent: controlplanev1alpha1.KubeComponent{
+						PodExtras: kinkcorev1alpha1.PodExtras{
+							ExtraVolumes:      []corev1.Volume{{Name: "pki"}},
+							ExtraVolumeMounts: []corev1.VolumeMount{{Name: "pki", MountPath: "/etc/pki"}},
+						},
+					},
+				},
+			},
+			expectedError: true,
+		},
+		"DuplicateExtraVolumeMountPaths": {
+			spec: controlplanev1alpha1.KinkControlPlaneSpec{
+				ControllerManager: controlplanev1alpha1.ControllerManager{
+					KubeComponent: controlplanev1alpha1.KubeComponent{
+						PodExtras: kinkcorev1alpha1.PodExtras{
+							ExtraVolumes: []corev1.Volume{{Name: "a"}, {Name: "b"}},
+							ExtraVolumeMounts: []corev1.VolumeMount{
+								{Name: "a", MountPath: "/etc/plugins"},
+								{Name: "b", MountPath: "/etc/plugins"},
+							},
+						},
+					},
+				},
+			},
+			expectedError: true,
+		},
+		"Patches": {
+			spec: controlplanev1alpha1.KinkControlPlaneSpec{
+				Patches: []controlplanev1alpha1.Patch{
+					{
+						Target: controlplanev1alpha1.PatchTarget{Kind: "Deployment", Component: "api-server"},
+						Patch:  "spec:\n  revisionHistoryLimit: 1\n",
+					},
+					{
+						Target: controlplanev1alpha1.PatchTarget{Kind: "Service"},
+						Type:   controlplanev1alpha1.JSON6902PatchType,
+						Patch:  `[{"op": "add", "path": "/metadata/labels/team", "value": "platform"}]`,
+					},
+				},
+			},
+		},
+		"InvalidStrategicMergePatch": {
+			spec: controlplanev1alpha1.KinkControlPlaneSpec{
+				Patches: []controlplanev1alpha1.Patch{
+					{
+						Target: controlplanev1alpha1.PatchTarget{Kind: "Deployment"},
+						Patch:  "- op: add",
+					},
+				},
+			},
+			expectedError: true,
+		},
+		"InvalidJSONPatch": {
+			spec: controlplanev1alpha1.KinkControlPlaneSpec{
+				Patches: []controlplanev1alpha1.Patch{
+					{
+						Target: controlplanev1alpha1.PatchTarget{Kind: "Deployment"},
+						Type:   controlplanev1alpha1.JSON6902PatchType,
+						Patch:  `{"op": "add"}`,
+					},
+				},
+			},
+			expectedError: true,
+		},
+		"HibernationWithPersistentVolumeClaim": {
+			spec: controlplanev1alpha1.KinkControlPlaneSpec{
+				Hibernation: &controlplanev1alpha1.Hibernation{Enabled: true},
+				Kine: controlplanev1alpha1.Kine{
+					Persistence: &kinkcorev1alpha1.Persistence{
+						PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "kine"},
+					},
+				},
+			},
+		},
+		"HibernationWithDefaultPersistence": {
+			spec: controlplanev1alpha1.KinkControlPlaneSpec{
+				Hibernation: &controlplanev1alpha1.Hibernation{
+					Schedules: []controlplanev1alpha1.HibernationSchedule{{Start: "0 20 * * *", End: "0 7 * * *"}},
+				},
+			},
+			expectedError: true,
+		},
+		"HibernationWithHostPath": {
+			spec: controlplanev1alpha1.KinkControlPlaneSpec{
+				Hibernation: &controlplanev1alpha1.Hibernation{Enabled: true},
+				Kine: controlplanev1alpha1.Kine{
+					Persistence: &kinkcorev1alpha1.Persistence{
+						HostPath: &corev1.HostPathVolumeSource{Path: "/var/lib/kine"},
+					},
+				},
+			},
+			expectedError: true,
+		},
+		"RestartKineForAPIServer": {
+			spec: controlplanev1alpha1.KinkControlPlaneSpec{
+				Remediation: &controlplanev1alpha1.Remediation{
+					APIServer: &controlplanev1alpha1.RemediationPolicy{
+						Action: controlplanev1alpha1.RemediationActionRestartKine,
+					},
+					Kine: &controlplanev1alpha1.RemediationPolicy{
+						Action: controlplanev1alpha1.RemediationActionRestartKine,
+					},
+				},
+			},
+		},
+		"RestartKineForScheduler": {
+			spec: controlplanev1alpha1.KinkControlPlaneSpec{
+				Remediation: &controlplanev1alpha1.Remediation{
+					Scheduler: &controlplanev1alpha1.RemediationPolicy{
+						Action: controlplanev1alpha1.RemediationActionRestartKine,
+					},
+				},
+			},
+			expectedError: true,
+		},
+		"RestartKineForControllerManager": {
+			spec: controlplanev1alpha1.KinkControlPlaneSpec{
+				Remediation: &controlplanev1alpha1.Remediation{
+					ControllerManager: &controlplanev1alpha1.RemediationPolicy{
+						Action: controlplanev1alpha1.RemediationActionRestartKine,
+					},
+				},
+			},
+			expectedError: true,
+		},
+		"EmptyGateway": {
+			spec: controlplanev1alpha1.KinkControlPlaneSpec{
+				ControlPlaneEndpoint: controlplanev1alpha1.APIEndpoint{
+					Gateway: &controlplanev1alpha1.Gateway{},
+				},
+			},
+			expectedError: true,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			// test
+			errs := validate(field.NewPath("spec"), tc.spec, validationOptions{name: "test"})
+
+			// validate
+			if tc.expectedError {
+				assert.NotEmpty(t, errs)
+			} else {
+				assert.Empty(t, errs)
+			}
+		})
+	}
+}
+
+func TestValidateEndpoint(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		endpoint controlplanev1alpha1.APIEndpoint
+		expected []string
+	}{
+		"Default": {
+			endpoint: controlplanev1alpha1.APIEndpoint{},
+		},
+		"Resolved": {
+			endpoint: controlplanev1alpha1.APIEndpoint{
+				Host:        "a1b2c3.elb.us-east-1.amazonaws.com",
+				Port:        6443,
+				ServiceType: corev1.ServiceTypeLoadBalancer,
+			},
+		},
+		"IPv6Host": {
+			endpoint: controlplanev1alpha1.APIEndpoint{Host: "2001:db8::1", Port: 443},
+		},
+		"InvalidHost": {
+			endpoint: controlplanev1alpha1.APIEndpoint{Host: "Not_A_Host"},
+			expected: []string{"spec.controlPlaneEndpoint.host"},
+		},
+		"PortOutOfRange": {
+			endpoint: controlplanev1alpha1.APIEndpoint{Port: 65536},
+			expected: []string{"spec.controlPlaneEndpoint.port"},
+		},
+		"NegativePort": {
+			endpoint: controlplanev1alpha1.APIEndpoint{Port: -1},
+			expected: []string{"spec.controlPlaneEndpoint.port"},
+		},
+		"GatewayAndIngress": {
+			endpoint: controlplanev1alpha1.APIEndpoint{
+				ServiceType: corev1.ServiceTypeClusterIP,
+				Gateway:     &controlplanev1alpha1.Gateway{GatewayClassName: "test"},
+				Ingress:     &controlplanev1alpha1.Ingress{IngressClassName: "test"},
+			},
+			expected: []string{"spec.controlPlaneEndpoint.ingress"},
+		},
+		"ClusterIPWithGateway": {
+			endpoint: controlplanev1alpha1.APIEndpoint{
+				ServiceType: corev1.ServiceTypeClusterIP,
+				Gateway:     &controlplanev1alpha1.Gateway{GatewayClassName: "test"},
+			},
+		},
+		"ClusterIPWithoutExposure": {
+			endpoint: controlplanev1alpha1.APIEndpoint{ServiceType: corev1.ServiceTypeClusterIP},
+			expected: []string{"spec.controlPlaneEndpoint.serviceType"},
+		},
+		"ClusterIPWithEndpoint": {
+			endpoint: controlplanev1alpha1.APIEndpoint{
+				Host:        "api.example.com",
+				Port:        6443,
+				ServiceType: corev1.ServiceTypeClusterIP,
+			},
+		},
+		"NodePortWithIngress": {
+			endpoint: controlplanev1alpha1.APIEndpoint{
+				ServiceType: corev1.ServiceTypeNodePort,
+				Ingress:     &controlplanev1alpha1.Ingress{IngressClassName: "test"},
+			},
+			expected: []string{"spec.controlPlaneEndpoint.serviceType"},
+		},
+		"NodePortSelection": {
+			endpoint: controlplanev1alpha1.APIEndpoint{
+				Port:        31443,
+				ServiceType: corev1.ServiceTypeNodePort,
+				NodePort:    &controlplanev1alpha1.NodePortEndpoint{},
+			},
+		},
+		"NodePortSelectionOnLoadBalancer": {
+			endpoint: controlplanev1alpha1.APIEndpoint{
+				ServiceType: corev1.ServiceTypeLoadBalancer,
+				NodePort:    &controlplanev1alpha1.NodePortEndpoint{},
+			},
+			expected: []string{"spec.controlPlaneEndpoint.nodePort"},
+		},
+		"ExternalName": {
+			endpoint: controlplanev1alpha1.APIEndpoint{ServiceType: corev1.ServiceTypeExternalName},
+			expected: []string{"spec.controlPlaneEndpoint.serviceType"},
+		},
+		"SNIRouterHost": {
+			endpoint: controlplanev1alpha1.APIEndpoint{
+				Host:      "prod.kink.example.com",
+				SNIRouter: &controlplanev1alpha1.SNIRouter{},
+			},
+		},
+		"SNIRouterHostOutsideOfBaseDomain": {
+			endpoint: controlplanev1alpha1.APIEndpoint{
+				Host:      "api.example.com",
+				SNIRouter: &controlplanev1alpha1.SNIRouter{},
+			},
+			expected: []string{"spec.controlPlaneEndpoint.host"},
+		},
+		"SNIRouterNestedHost": {
+			endpoint: controlplanev1alpha1.APIEndpoint{
+				Host:      "api.prod.kink.example.com",
+				SNIRouter: &controlplanev1alpha1.SNIRouter{},
+			},
+			expected: []string{"spec.controlPlaneEndpoint.host"},
+		},
+		"SNIRouterPort": {
+			endpoint: controlplanev1alpha1.APIEndpoint{
+				Port:      6443,
+				SNIRouter: &controlplanev1alpha1.SNIRouter{},
+			},
+			expected: []string{"spec.controlPlaneEndpoint.port"},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			// test
+			errs := validateEndpoint(field.NewPath("spec", "controlPlaneEndpoint"), tc.endpoint,
+				validationOptions{sniRouterBaseDomain: "kink.example.com"})
+
+			// validate
+			actual := make([]string, 0, len(errs))
+			for _, err := range errs {
+				actual = append(actual, err.Field)
+			}
+			assert.ElementsMatch(t, tc.expected, actual)
+		})
+	}
+}
+
+func TestValidateUpdate(t *testing.T) {
+	t.Parallel()
+
+	endpoint := func(host controlplanev1alpha1.HostnameOrIP, class *string) controlplanev1alpha1.KinkControlPlaneSpec {
+		return controlplanev1alpha1.KinkControlPlaneSpec{
+			ControlPlaneEndpoint: controlplanev1alpha1.APIEndpoint{
+				Host:        host,
+				ServiceType: corev1.ServiceTypeLoadBalancer,
+				Service:     &controlplanev1alpha1.Service{LoadBalancerClass: class},
+			},
+		}
+	}
+
+	for name, tc := range map[string]struct {
+		oldSpec  controlplanev1alpha1.KinkControlPlaneSpec
+		newSpec  controlplanev1alpha1.KinkControlPlaneSpec
+		expected []string
+	}{
+		"Unchanged": {
+			oldSpec: endpoint("a1b2c3.elb.us-east-1.amazonaws.com", ptr.To("service.k8s.aws/nlb")),
+			newSpec: endpoint("a1b2c3.elb.us-east-1.amazonaws.com", ptr.To("service.k8s.aws/nlb")),
+		},
+		"LoadBalancerClassBeforeResolved": {
+			oldSpec: endpoint("", nil),
+			newSpec: endpoint("", ptr.To("service.k8s.aws/nlb")),
+		},
+		"LoadBalancerClassAfterResolved": {
+			oldSpec:  endpoint("a1b2c3.elb.us-east-1.amazonaws.com", nil),
+			newSpec:  endpoint("a1b2c3.elb.us-east-1.amazonaws.com", ptr.To("service.k8s.aws/nlb")),
+			expected: []string{"spec.controlPlaneEndpoint.service.loadBalancerClass"},
+		},
+		"LoadBalancerClassRemoved": {
+			oldSpec: endpoint("a1b2c3.elb.us-east-1.amazonaws.com", ptr.To("service.k8s.aws/nlb")),
+			newSpec: controlplanev1alpha1.KinkControlPlaneSpec{
+				ControlPlaneEndpoint: controlplanev1alpha1.APIEndpoint{Host: "a1b2c3.elb.us-east-1.amazonaws.com"},
+			},
+			expected: []string{"spec.controlPlaneEndpoint.service.loadBalancerClass"},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			// test
+			errs := validateUpdate(field.NewPath("spec"), tc.oldSpec, tc.newSpec)
+
+			// validate
+			actual := make([]string, 0, len(errs))
+			for _, err := range errs {
+				actual = append(actual, err.Field)
+			}
+			assert.ElementsMatch(t, tc.expected, actual)
+		})
+	}
+}
@@ -21,6 +21,7 @@ import (
 	controlplanev1alpha1 "github.com/anza-labs/kink/api/controlplane/v1alpha1"
 
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
@@ -28,9 +29,10 @@ import (
 )
 
 // SetupKinkControlPlaneTemplateWebhookWithManager registers the webhook for KinkControlPlaneTemplate in the manager.
-func SetupKinkControlPlaneTemplateWebhookWithManager(mgr ctrl.Manager) error {
+// The hosts of the control planes exposed through the SNI router are restricted to its base domain.
+func SetupKinkControlPlaneTemplateWebhookWithManager(mgr ctrl.Manager, sniRouterBaseDomain string) error {
 	return ctrl.NewWebhookManagedBy(mgr).For(&controlplanev1alpha1.KinkControlPlaneTemplate{}).
-		WithValidator(&KinkControlPlaneTemplateCustomValidator{}).
+		WithValidator(&KinkControlPlaneTemplateCustomValidator{SNIRouterBaseDomain: sniRouterBaseDomain}).
 		WithDefaulter(&KinkControlPlaneTemplateCustomDefaulter{}).
 		Complete()
 }
@@ -69,7 +71,11 @@ func (d *KinkControlPlaneTemplateCustomDefaulter) Default(
 
 // KinkControlPlaneTemplateCustomValidator struct is responsible for validating the KinkControlPlaneTemplate resource
 // when it is created, updated, or deleted.
-type KinkControlPlaneTemplateCustomValidator struct{}
+type KinkControlPlaneTemplateCustomValidator struct {
+	// SNIRouterBaseDomain is the domain under which the hosts of the control planes exposed
+	// through the SNI router are assigned, empty when the SNI router is not enabled.
+	SNIRouterBaseDomain string
+}
 
 var _ webhook.CustomValidator = &KinkControlPlaneTemplateCustomValidator{}
 
@@ -88,9 +94,9 @@ func (v *KinkControlPlaneTemplateCustomValidator) ValidateCreate(
 	log.Info("Validation for KinkControlPlaneTemplate upon creation",
 		"name", kinkcontrolplanetemplate.GetName())
 
-	// TODO(user): fill in your validation logic upon object creation.
-
-	return nil, nil
+	return nil, invalid("KinkControlPlaneTemplate", kinkcontrolplanetemplate.GetName(),
+		validate(field.NewPath("spec", "template", "spec"), kinkcontrolplanetemplate.Spec.Template.Spec,
+			validationOptions{sniRouterBaseDomain: v.SNIRouterBaseDomain}))
 }
 
 // ValidateUpdate implements webhook.CustomValidator so a webhook will be registered
@@ -108,9 +114,9 @@ func (v *KinkControlPlaneTemplateCustomValidator) ValidateUpdate(
 	log.Info("Validation for KinkControlPlaneTemplate upon update",
 		"name", kinkcontrolplanetemplate.GetName())
 
-	// TODO(user): fill in your validation logic upon object update.
-
-	return nil, validate(kinkcontrolplanetemplate.Spec.Template.Spec)
+	return nil, invalid("KinkControlPlaneTemplate", kinkcontrolplanetemplate.GetName(),
+		validate(field.NewPath("spec", "template", "spec"), kinkcontrolplanetemplate.Spec.Template.Spec,
+			validationOptions{sniRouterBaseDomain: v.SNIRouterBaseDomain}))
 }
 
 // ValidateDelete implements webhook.CustomValidator so a webhook will be registered
@@ -130,5 +136,5 @@ func (v *KinkControlPlaneTemplateCustomValidator) ValidateDelete(
 
 	// TODO(user): fill in your validation logic upon object deletion.
 
-	return nil, validate(kinkcontrolplanetemplate.Spec.Template.Spec)
+	return nil, nil
 }
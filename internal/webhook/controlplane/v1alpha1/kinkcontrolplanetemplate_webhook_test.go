@@ -13,3 +13,67 @@
 // limitations under the License.
 
 package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	controlplanev1alpha1 "github.com/anza-labs/kink/api/controlplane/v1alpha1"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestKinkControlPlaneTemplateValidateCreate(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		endpoint controlplanev1alpha1.APIEndpoint
+		expected []string
+	}{
+		"Valid": {
+			endpoint: controlplanev1alpha1.APIEndpoint{
+				ServiceType: corev1.ServiceTypeClusterIP,
+				Ingress:     &controlplanev1alpha1.Ingress{IngressClassName: "test"},
+			},
+		},
+		"Invalid": {
+			endpoint: controlplanev1alpha1.APIEndpoint{
+				ServiceType: corev1.ServiceTypeClusterIP,
+				Gateway:     &controlplanev1alpha1.Gateway{GatewayClassName: "test"},
+				Ingress:     &controlplanev1alpha1.Ingress{IngressClassName: "test"},
+			},
+			expected: []string{"spec.template.spec.controlPlaneEndpoint.ingress"},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			// prepare
+			template := &controlplanev1alpha1.KinkControlPlaneTemplate{
+				ObjectMeta: metav1.ObjectMeta{Name: "test"},
+			}
+			template.Spec.Template.Spec.ControlPlaneEndpoint = tc.endpoint
+
+			// test
+			_, err := (&KinkControlPlaneTemplateCustomValidator{}).ValidateCreate(t.Context(), template)
+
+			// validate
+			if len(tc.expected) == 0 {
+				assert.NoError(t, err)
+				return
+			}
+			require.True(t, apierrors.IsInvalid(err))
+			statusErr := &apierrors.StatusError{}
+			require.ErrorAs(t, err, &statusErr)
+			actual := []string{}
+			for _, cause := range statusErr.ErrStatus.Details.Causes {
+				actual = append(actual, cause.Field)
+			}
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
@@ -30,4 +30,5 @@ type Values struct {
 	Scheduler         Config `yaml:"scheduler"`
 	NodeVM            Config `yaml:"nodeVM"`
 	Kine              Config `yaml:"kine"`
+	SNIRouter         Config `yaml:"sniRouter"`
 }
@@ -34,6 +34,7 @@ var (
 	controllerManager string
 	scheduler         string
 	kine              string
+	sniRouter         string
 )
 
 const (
@@ -49,6 +50,7 @@ func init() {
 	controllerManager = initControllerManager(vals.ControllerManager.Image)
 	scheduler = initScheduler(vals.Scheduler.Image)
 	kine = initKine(vals.Kine.Image)
+	sniRouter = initSNIRouter(vals.SNIRouter.Image)
 
 }
 
@@ -103,6 +105,19 @@ func initKine(image values.Image) string {
 	return fmt.Sprintf("%s/%s:%s", registry, repository, tag)
 }
 
+func initSNIRouter(image values.Image) string {
+	registry := image.Registry
+	if registry == "" {
+		registry = dockerRegistry
+	}
+	repository := image.Repository
+	tag := image.Tag
+	if tag == "" {
+		tag = "latest"
+	}
+	return fmt.Sprintf("%s/%s:%s", registry, repository, tag)
+}
+
 func APIServer() string {
 	return apiServer
 }
@@ -118,3 +133,7 @@ func Scheduler() string {
 func Kine() string {
 	return kine
 }
+
+func SNIRouter() string {
+	return sniRouter
+}
@@ -25,4 +25,5 @@ func TestSmoke(t *testing.T) {
 	assert.Regexp(t, "^registry.k8s.io/kube-controller-manager:v.+$", ControllerManager())
 	assert.Regexp(t, "^registry.k8s.io/kube-scheduler:v.+$", Scheduler())
 	assert.Regexp(t, "^ghcr.io/anza-labs/library/kine:.+$", Kine())
+	assert.Regexp(t, "^docker.io/library/haproxy:.+$", SNIRouter())
 }